@@ -2,8 +2,11 @@ package repo
 
 import (
 	"context"
+	"errors"
 	"fin-auth/cache"
 	"fin-auth/models"
+	"fin-auth/utils"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -29,8 +32,115 @@ func (o *Address) GetDB(tx ...*gorm.DB) *gorm.DB {
 }
 
 func (o *Address) Create(ctx context.Context, address *models.Address) (*models.Address, error) {
-	if err := o.db.Create(address).Error; err != nil {
+	if err := o.db.WithContext(ctx).Create(address).Error; err != nil {
 		return nil, err
 	}
 	return address, nil
 }
+
+func (o *Address) ListByCustomer(ctx context.Context, customerId string, history bool) ([]*models.Address, error) {
+	var addresses []*models.Address
+	query := o.db.WithContext(ctx).Where("customer_id = ?", customerId)
+	if !history {
+		query = query.Where("valid_to IS NULL")
+	}
+	if err := query.Order("type, valid_from DESC, id DESC").Find(&addresses).Error; err != nil {
+		return nil, err
+	}
+	return addresses, nil
+}
+
+func (o *Address) FindByID(ctx context.Context, customerId string, addressId int) (*models.Address, error) {
+	var address models.Address
+	err := o.db.WithContext(ctx).Where("id = ? AND customer_id = ?", addressId, customerId).First(&address).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, utils.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &address, nil
+}
+
+func (o *Address) Add(ctx context.Context, address *models.Address) error {
+	return o.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if address.IsPrimary {
+			if err := demotePrimary(tx, address); err != nil {
+				return err
+			}
+		}
+		if err := tx.Create(address).Error; err != nil {
+			return err
+		}
+		return syncPersonAddress(tx, address)
+	})
+}
+
+func (o *Address) Replace(ctx context.Context, current, next *models.Address) error {
+	return o.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// The closed version gives up the primary flag first so the successor can take
+		// it without breaking the one-primary-per-type index.
+		err := tx.Model(current).Select("valid_to", "is_primary").Updates(&models.Address{ValidTo: &next.ValidFrom, IsPrimary: false}).Error
+		if err != nil {
+			return err
+		}
+		current.ValidTo = &next.ValidFrom
+
+		next.PreviousID = &current.ID
+		next.IsPrimary = current.IsPrimary
+		current.IsPrimary = false
+		if err := tx.Create(next).Error; err != nil {
+			return err
+		}
+		return syncPersonAddress(tx, next)
+	})
+}
+
+func (o *Address) Close(ctx context.Context, address *models.Address, at time.Time) error {
+	if err := o.db.WithContext(ctx).Model(address).Update("valid_to", at).Error; err != nil {
+		return err
+	}
+	address.ValidTo = &at
+	return nil
+}
+
+func (o *Address) SetPrimary(ctx context.Context, address *models.Address) error {
+	return o.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := demotePrimary(tx, address); err != nil {
+			return err
+		}
+		if err := tx.Model(address).Update("is_primary", true).Error; err != nil {
+			return err
+		}
+		address.IsPrimary = true
+		return syncPersonAddress(tx, address)
+	})
+}
+
+// demotePrimary clears the primary flag of the current address sharing the type.
+func demotePrimary(tx *gorm.DB, address *models.Address) error {
+	return tx.Model(&models.Address{}).
+		Where("customer_id = ? AND type = ? AND is_primary AND valid_to IS NULL", address.CustomerID, address.Type).
+		Update("is_primary", false).Error
+}
+
+// syncPersonAddress points the primary person at its primary residential address and
+// moves its country of residence along, which the TIN blind index depends on.
+func syncPersonAddress(tx *gorm.DB, address *models.Address) error {
+	if !address.IsPrimary || address.Type != utils.ADDRESS_TYPE_RESIDENTIAL {
+		return nil
+	}
+	var person models.Person
+	err := tx.Where("customer_id = ? AND role = ?", address.CustomerID, utils.PERSON_ROLE_PRIMARY).First(&person).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	person.AddressID = &address.ID
+	if address.Country != nil {
+		person.CountryOfResidence = address.Country
+	}
+	return tx.Model(&person).Select("address_id", "country_of_residence", "tin_bidx").Updates(&person).Error
+}
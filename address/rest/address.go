@@ -1,19 +1,170 @@
 package rest
 
 import (
+	"errors"
 	"fin-auth/domain"
+	"fin-auth/dto"
+	"fin-auth/models"
+	"fin-auth/utils"
+	"strconv"
 
 	"github.com/labstack/echo/v4"
 )
 
 type AddressHandler struct {
-	Service  domain.AddressService
-	Response domain.Response
+	Service   domain.AddressService
+	PIIAccess domain.PIIAccessService
+	Response  domain.Response
 }
 
-func SetupAddressRoutes(api *echo.Group, s domain.AddressService) {
-	// handler := &AddressHandler{
-	// 	Service:  s,
-	// 	Response: domain.NewResponse(),
-	// }
+func SetupAddressRoutes(api *echo.Group, s domain.AddressService, piiAccess domain.PIIAccessService) {
+	handler := &AddressHandler{
+		Service:   s,
+		PIIAccess: piiAccess,
+		Response:  domain.NewResponse(),
+	}
+	addresses := api.Group("/customers/:id/addresses")
+	addresses.GET("", handler.listAddresses)
+	addresses.POST("", handler.addAddress)
+	addresses.GET("/:address_id", handler.getAddress)
+	addresses.PUT("/:address_id", handler.updateAddress)
+	addresses.DELETE("/:address_id", handler.closeAddress)
+	addresses.POST("/:address_id/primary", handler.setPrimary)
+}
+
+func (h *AddressHandler) listAddresses(c echo.Context) error {
+	clientId := c.Get("client_id").(string)
+	history := c.QueryParam("history") == "true"
+	addresses, err := h.Service.List(c.Request().Context(), clientId, c.Param("id"), history)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+	return h.present(c, addresses)
+}
+
+func (h *AddressHandler) getAddress(c echo.Context) error {
+	addressId, err := strconv.Atoi(c.Param("address_id"))
+	if err != nil {
+		return h.Response.InvalidData(c, utils.StringPtr("Invalid address id"))
+	}
+
+	clientId := c.Get("client_id").(string)
+	address, err := h.Service.Get(c.Request().Context(), clientId, c.Param("id"), addressId)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+	return h.presentOne(c, address)
+}
+
+func (h *AddressHandler) addAddress(c echo.Context) error {
+	var req dto.AddressReq
+	if err := c.Bind(&req); err != nil {
+		return h.Response.InvalidData(c, nil)
+	}
+	v := req.Validate()
+	if v.Status {
+		return h.Response.ValidationFail(c, v, nil)
+	}
+
+	clientId := c.Get("client_id").(string)
+	address, err := h.Service.Add(c.Request().Context(), clientId, c.Param("id"), &req)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+	return h.presentOne(c, address)
+}
+
+func (h *AddressHandler) updateAddress(c echo.Context) error {
+	addressId, err := strconv.Atoi(c.Param("address_id"))
+	if err != nil {
+		return h.Response.InvalidData(c, utils.StringPtr("Invalid address id"))
+	}
+	var req dto.AddressReq
+	if err := c.Bind(&req); err != nil {
+		return h.Response.InvalidData(c, nil)
+	}
+	v := req.Validate()
+	if v.Status {
+		return h.Response.ValidationFail(c, v, nil)
+	}
+
+	clientId := c.Get("client_id").(string)
+	address, err := h.Service.Update(c.Request().Context(), clientId, c.Param("id"), addressId, &req)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+	return h.presentOne(c, address)
+}
+
+func (h *AddressHandler) closeAddress(c echo.Context) error {
+	addressId, err := strconv.Atoi(c.Param("address_id"))
+	if err != nil {
+		return h.Response.InvalidData(c, utils.StringPtr("Invalid address id"))
+	}
+
+	clientId := c.Get("client_id").(string)
+	if err := h.Service.Close(c.Request().Context(), clientId, c.Param("id"), addressId); err != nil {
+		return h.handleError(c, err)
+	}
+	return h.Response.SuccessMessage(c, "Address closed")
+}
+
+func (h *AddressHandler) setPrimary(c echo.Context) error {
+	addressId, err := strconv.Atoi(c.Param("address_id"))
+	if err != nil {
+		return h.Response.InvalidData(c, utils.StringPtr("Invalid address id"))
+	}
+
+	clientId := c.Get("client_id").(string)
+	address, err := h.Service.SetPrimary(c.Request().Context(), clientId, c.Param("id"), addressId)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+	return h.presentOne(c, address)
+}
+
+func (h *AddressHandler) present(c echo.Context, addresses []*models.Address) error {
+	unmasked := domain.Unmasked(c)
+	if unmasked && len(addresses) > 0 {
+		err := h.PIIAccess.RecordUnmaskedRead(c.Request().Context(), domain.NewPIIAccess(c), []string{c.Param("id")})
+		if err != nil {
+			return h.Response.InternalServerError(c, err)
+		}
+	}
+
+	res := make([]*dto.AddressRes, 0, len(addresses))
+	for _, address := range addresses {
+		res = append(res, dto.NewAddressRes(address, unmasked))
+	}
+	return h.Response.SuccessOk(c, res)
+}
+
+func (h *AddressHandler) presentOne(c echo.Context, address *models.Address) error {
+	unmasked := domain.Unmasked(c)
+	if unmasked {
+		err := h.PIIAccess.RecordUnmaskedRead(c.Request().Context(), domain.NewPIIAccess(c), []string{c.Param("id")})
+		if err != nil {
+			return h.Response.InternalServerError(c, err)
+		}
+	}
+	return h.Response.SuccessOk(c, dto.NewAddressRes(address, unmasked))
+}
+
+func (h *AddressHandler) handleError(c echo.Context, err error) error {
+	var fieldErrs utils.FieldErrors
+	switch {
+	case errors.As(err, &fieldErrs):
+		v := utils.NewValidationError()
+		v.Response = utils.ErrorResponse{}
+		for path, codes := range fieldErrs {
+			v.Response.Add(path, codes)
+		}
+		return h.Response.ValidationFail(c, v, nil)
+	case errors.Is(err, utils.ErrNotFound):
+		return h.Response.NotFound(c, utils.StringPtr("Address not found"))
+	case errors.Is(err, utils.ErrConflict):
+		return h.Response.ConflictError(c, utils.StringPtr(err.Error()), nil)
+	default:
+		return h.Response.InternalServerError(c, err)
+	}
 }
@@ -4,18 +4,33 @@ import (
 	"context"
 	"fin-auth/cache"
 	"fin-auth/domain"
+	"fin-auth/dto"
 	"fin-auth/models"
+	"fin-auth/utils"
+	"fmt"
+	"log"
+	"time"
 )
 
 type Address struct {
-	AddressRepository domain.AddressRepository
-	Cache             *cache.RedisCache
+	AddressRepository   domain.AddressRepository
+	CustomerRepository  domain.CustomerRepository
+	JurisdictionService domain.JurisdictionService
+	KYCService          domain.KYCService
+	ScreeningService    domain.ScreeningService
+	RiskService         domain.RiskService
+	Cache               *cache.RedisCache
 }
 
-func NewAddressService(repo domain.AddressRepository, cache *cache.RedisCache) *Address {
+func NewAddressService(repo domain.AddressRepository, customerRepo domain.CustomerRepository, jurisdictionService domain.JurisdictionService, kycService domain.KYCService, screeningService domain.ScreeningService, riskService domain.RiskService, cache *cache.RedisCache) *Address {
 	return &Address{
-		AddressRepository: repo,
-		Cache:             cache,
+		AddressRepository:   repo,
+		CustomerRepository:  customerRepo,
+		JurisdictionService: jurisdictionService,
+		KYCService:          kycService,
+		ScreeningService:    screeningService,
+		RiskService:         riskService,
+		Cache:               cache,
 	}
 }
 
@@ -26,3 +41,243 @@ func (s *Address) Create(ctx context.Context, address *models.Address) (*models.
 	}
 	return createdAddress, nil
 }
+
+func (s *Address) List(ctx context.Context, clientId, customerId string, history bool) ([]*models.Address, error) {
+	if _, err := s.CustomerRepository.FindByID(ctx, clientId, customerId); err != nil {
+		return nil, err
+	}
+	return s.AddressRepository.ListByCustomer(ctx, customerId, history)
+}
+
+func (s *Address) Get(ctx context.Context, clientId, customerId string, addressId int) (*models.Address, error) {
+	if _, err := s.CustomerRepository.FindByID(ctx, clientId, customerId); err != nil {
+		return nil, err
+	}
+	return s.AddressRepository.FindByID(ctx, customerId, addressId)
+}
+
+// Add stores a new address. The first current address of a type becomes its primary.
+func (s *Address) Add(ctx context.Context, clientId, customerId string, req *dto.AddressReq) (*models.Address, error) {
+	customer, err := s.findCustomer(ctx, clientId, customerId)
+	if err != nil {
+		return nil, err
+	}
+
+	address := req.GetAddress(customer.ID)
+	if address.Type == "" {
+		address.Type = utils.ADDRESS_TYPE_RESIDENTIAL
+	}
+	if errs := dto.CheckStreet(address); errs != nil {
+		return nil, errs
+	}
+	if !address.IsPrimary {
+		current, err := s.AddressRepository.ListByCustomer(ctx, customer.ID, false)
+		if err != nil {
+			return nil, err
+		}
+		address.IsPrimary = !hasType(current, address.Type)
+	}
+
+	if err := s.checkJurisdiction(ctx, customer, address); err != nil {
+		return nil, err
+	}
+	if err := s.AddressRepository.Add(ctx, address); err != nil {
+		return nil, err
+	}
+	s.invalidate(ctx, customer)
+	if err := s.residenceChanged(ctx, customer, address); err != nil {
+		return nil, err
+	}
+	return address, nil
+}
+
+func (s *Address) Update(ctx context.Context, clientId, customerId string, addressId int, req *dto.AddressReq) (*models.Address, error) {
+	customer, err := s.findCustomer(ctx, clientId, customerId)
+	if err != nil {
+		return nil, err
+	}
+	current, err := s.AddressRepository.FindByID(ctx, customer.ID, addressId)
+	if err != nil {
+		return nil, err
+	}
+	if current.ValidTo != nil {
+		return nil, fmt.Errorf("address %d was replaced or removed: %w", current.ID, utils.ErrConflict)
+	}
+	if req.Type != "" && req.Type != current.Type {
+		return nil, utils.FieldErrors{"type": {utils.InvalidValueCode()}}
+	}
+	// valid_from is a date, so an address replaced on the day it started is compared by
+	// day and the new version starts no earlier than the old one.
+	if truncateDay(req.ValidFromTime).Before(truncateDay(current.ValidFrom)) {
+		return nil, utils.FieldErrors{"valid_from": {utils.InvalidDateCode()}}
+	}
+
+	next := req.GetAddress(customer.ID)
+	next.Type = current.Type
+	if next.ValidFrom.Before(current.ValidFrom) {
+		next.ValidFrom = current.ValidFrom
+	}
+	if errs := dto.CheckStreet(next); errs != nil {
+		return nil, errs
+	}
+
+	if err := s.checkJurisdiction(ctx, customer, next); err != nil {
+		return nil, err
+	}
+	if err := s.AddressRepository.Replace(ctx, current, next); err != nil {
+		return nil, err
+	}
+	s.invalidate(ctx, customer)
+	if err := s.residenceChanged(ctx, customer, next); err != nil {
+		return nil, err
+	}
+	return next, nil
+}
+
+// Close ends an address. Primary addresses stay until another one is made primary.
+func (s *Address) Close(ctx context.Context, clientId, customerId string, addressId int) error {
+	customer, err := s.findCustomer(ctx, clientId, customerId)
+	if err != nil {
+		return err
+	}
+	address, err := s.AddressRepository.FindByID(ctx, customer.ID, addressId)
+	if err != nil {
+		return err
+	}
+	if address.ValidTo != nil {
+		return fmt.Errorf("address %d is already closed: %w", address.ID, utils.ErrConflict)
+	}
+	if address.IsPrimary {
+		return fmt.Errorf("address %d is the primary %s address: %w", address.ID, address.Type, utils.ErrConflict)
+	}
+
+	if err := s.AddressRepository.Close(ctx, address, time.Now().UTC()); err != nil {
+		return err
+	}
+	s.invalidate(ctx, customer)
+	return nil
+}
+
+func (s *Address) SetPrimary(ctx context.Context, clientId, customerId string, addressId int) (*models.Address, error) {
+	customer, err := s.findCustomer(ctx, clientId, customerId)
+	if err != nil {
+		return nil, err
+	}
+	address, err := s.AddressRepository.FindByID(ctx, customer.ID, addressId)
+	if err != nil {
+		return nil, err
+	}
+	if address.ValidTo != nil {
+		return nil, fmt.Errorf("address %d is closed: %w", address.ID, utils.ErrConflict)
+	}
+	if address.IsPrimary {
+		return address, nil
+	}
+
+	if err := s.AddressRepository.SetPrimary(ctx, address); err != nil {
+		return nil, err
+	}
+	s.invalidate(ctx, customer)
+	if err := s.residenceChanged(ctx, customer, address); err != nil {
+		return nil, err
+	}
+	return address, nil
+}
+
+// findCustomer loads the customer an address change applies to. Erased customers keep
+// no addresses.
+func (s *Address) findCustomer(ctx context.Context, clientId, customerId string) (*models.Customer, error) {
+	cached, err := s.CustomerRepository.FindByID(ctx, clientId, customerId)
+	if err != nil {
+		return nil, err
+	}
+	if cached.Customer.ErasedAt != nil {
+		return nil, fmt.Errorf("customer %s was erased: %w", customerId, utils.ErrConflict)
+	}
+	return cached.Customer, nil
+}
+
+// checkJurisdiction rejects addresses in prohibited jurisdictions and flags those in
+// controlled ones for review, as onboarding does.
+func (s *Address) checkJurisdiction(ctx context.Context, customer *models.Customer, address *models.Address) error {
+	target := dto.JurisdictionTarget{
+		Field:            "country",
+		SubdivisionField: "state",
+		Country:          utils.SafeString(address.Country),
+		Subdivision:      utils.SafeString(address.StateCode),
+	}
+	hits, err := s.JurisdictionService.Evaluate(ctx, utils.SafeString(customer.ClientID), []dto.JurisdictionTarget{target})
+	if err != nil {
+		return err
+	}
+
+	var controlled []dto.JurisdictionHit
+	prohibited := utils.FieldErrors{}
+	for _, hit := range hits {
+		switch {
+		case hit.Category == utils.JURISDICTION_CONTROLLED:
+			controlled = append(controlled, hit)
+		case hit.Subdivision != nil:
+			prohibited[hit.Field] = append(prohibited[hit.Field], utils.RestrictedSubdivisionCode())
+		default:
+			prohibited[hit.Field] = append(prohibited[hit.Field], utils.RestrictedCountryCode())
+		}
+	}
+	if len(prohibited) > 0 {
+		return prohibited
+	}
+
+	if len(controlled) > 0 {
+		details := map[string]interface{}{"hits": controlled, "address_type": address.Type}
+		if err := s.KYCService.FlagForReview(ctx, customer, utils.REVIEW_SOURCE_JURISDICTION, utils.ControlledJurisdictionCode(), details); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// residenceChanged screens and scores the customer again once its primary residential
+// address, and with it the person's country of residence, changed. Reassessing the
+// risk also refreshes the corridors.
+func (s *Address) residenceChanged(ctx context.Context, customer *models.Customer, address *models.Address) error {
+	if !address.IsPrimary || address.Type != utils.ADDRESS_TYPE_RESIDENTIAL {
+		return nil
+	}
+	clientId := utils.SafeString(customer.ClientID)
+	cached, err := s.CustomerRepository.FindByID(ctx, clientId, customer.ID)
+	if err != nil {
+		return err
+	}
+	if cached.Person != nil {
+		if _, err := s.ScreeningService.ScreenPerson(ctx, cached.Customer, cached.Person); err != nil {
+			log.Printf("[address] failed to screen person %d of customer %s: %v", cached.Person.ID, customer.ID, err)
+			details := map[string]interface{}{"person_id": cached.Person.ID}
+			if err := s.KYCService.FlagForReview(ctx, cached.Customer, utils.REVIEW_SOURCE_SANCTIONS, utils.ScreeningUnavailableCode(), details); err != nil {
+				return err
+			}
+		}
+	}
+	_, err = s.RiskService.Reassess(ctx, clientId, customer.ID)
+	return err
+}
+
+func (s *Address) invalidate(ctx context.Context, customer *models.Customer) {
+	if s.Cache == nil || customer.ClientID == nil {
+		return
+	}
+	s.Cache.InvalidateCustomer(ctx, *customer.ClientID, customer.ID)
+	s.Cache.InvalidateCustomerList(ctx, *customer.ClientID)
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func hasType(addresses []*models.Address, addressType string) bool {
+	for _, address := range addresses {
+		if address.Type == addressType {
+			return true
+		}
+	}
+	return false
+}
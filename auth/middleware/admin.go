@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// AdminMiddleware guards operator endpoints with a static key sent in X-Admin-Key.
+func AdminMiddleware(keys []string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			key := c.Request().Header.Get("X-Admin-Key")
+			if key == "" {
+				return echo.NewHTTPError(http.StatusUnauthorized, "Missing admin key")
+			}
+			for _, k := range keys {
+				if k != "" && subtle.ConstantTimeCompare([]byte(k), []byte(key)) == 1 {
+					return next(c)
+				}
+			}
+			return echo.NewHTTPError(http.StatusUnauthorized, "Invalid admin key")
+		}
+	}
+}
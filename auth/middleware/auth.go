@@ -36,8 +36,15 @@ func AuthMiddleware(repo domain.AuthRepository, redisCache *cache.RedisCache) ec
 			if redisCache != nil {
 				redisCache.UpdateLastActivity(c.Request().Context(), accessToken.ClientId, token) // Ignore errors
 			}
+			// Scopes are read from the client on every request rather than from the token,
+			// so a revoked scope stops working before the token expires.
+			client, err := repo.FindClientWithSecrets(c.Request().Context(), accessToken.ClientId)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusUnauthorized, "Invalid or expired token")
+			}
 			c.Set("client_id", accessToken.ClientId)
 			c.Set("token", token)
+			c.Set("scopes", []string(client.User.Scopes))
 
 			return next(c)
 		}
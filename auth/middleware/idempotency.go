@@ -0,0 +1,160 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fin-auth/config"
+	"fin-auth/domain"
+	"fin-auth/models"
+	"fin-auth/utils"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	maxIdempotencyKeyLength   = 255
+	defaultIdempotencyTTL     = 24 * time.Hour
+	defaultIdempotencyLockTTL = time.Minute
+	defaultIdempotencyBodyMB  = 16
+)
+
+// IdempotencyMiddleware replays the stored response for POST and PATCH requests that
+// repeat an Idempotency-Key with the same body. Keys are scoped to the client, so it
+// must run after AuthMiddleware. Server errors are not stored, so they can be retried.
+func IdempotencyMiddleware(store domain.IdempotencyStore, cfg config.IdempotencyConfig) echo.MiddlewareFunc {
+	ttl := defaultIdempotencyTTL
+	if cfg.TTLHours > 0 {
+		ttl = time.Duration(cfg.TTLHours) * time.Hour
+	}
+	lockTTL := defaultIdempotencyLockTTL
+	if cfg.LockTimeoutSeconds > 0 {
+		lockTTL = time.Duration(cfg.LockTimeoutSeconds) * time.Second
+	}
+	maxBodyBytes := int64(defaultIdempotencyBodyMB) << 20
+	if cfg.MaxBodyMB > 0 {
+		maxBodyBytes = int64(cfg.MaxBodyMB) << 20
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			key := req.Header.Get("Idempotency-Key")
+			if key == "" || (req.Method != http.MethodPost && req.Method != http.MethodPatch) {
+				return next(c)
+			}
+			if len(key) > maxIdempotencyKeyLength {
+				return idempotencyError(c, http.StatusBadRequest, "Idempotency-Key must be at most 255 characters")
+			}
+			clientId, _ := c.Get("client_id").(string)
+
+			body, err := io.ReadAll(http.MaxBytesReader(c.Response(), req.Body, maxBodyBytes))
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				return idempotencyError(c, http.StatusRequestEntityTooLarge, "Request body is too large")
+			}
+			if err != nil {
+				return idempotencyError(c, http.StatusBadRequest, "Failed to read request body")
+			}
+			req.Body = io.NopCloser(bytes.NewReader(body))
+
+			fingerprint := requestFingerprint(req.Method, req.URL.Path, body)
+			ctx := req.Context()
+
+			stored, err := store.Get(ctx, clientId, key)
+			switch {
+			case err == nil:
+				return replay(c, stored, fingerprint)
+			case !errors.Is(err, utils.ErrNotFound):
+				return err
+			}
+
+			record := &models.IdempotencyRecord{
+				ClientID:    clientId,
+				Key:         key,
+				Method:      req.Method,
+				Path:        req.URL.Path,
+				Fingerprint: fingerprint,
+				ExpiresAt:   utils.CurrentTime().Add(ttl),
+			}
+			locked, err := store.Lock(ctx, record, lockTTL)
+			if err != nil {
+				return err
+			}
+			if !locked {
+				// The key may have completed between Get and Lock.
+				if stored, err := store.Get(ctx, clientId, key); err == nil {
+					return replay(c, stored, fingerprint)
+				}
+				return idempotencyError(c, http.StatusConflict, "A request with this Idempotency-Key is already in progress")
+			}
+			defer func() {
+				if err := store.Unlock(ctx, record); err != nil {
+					log.Printf("[idempotency] failed to release key %s: %v", key, err)
+				}
+			}()
+
+			recorder := &responseRecorder{ResponseWriter: c.Response().Writer}
+			c.Response().Writer = recorder
+			if err := next(c); err != nil {
+				return err
+			}
+
+			status := c.Response().Status
+			if status >= http.StatusInternalServerError {
+				return nil
+			}
+			record.ResponseStatus = status
+			record.ContentType = c.Response().Header().Get(echo.HeaderContentType)
+			record.ResponseBody = recorder.body.Bytes()
+			if err := store.Save(ctx, record); err != nil {
+				log.Printf("[idempotency] failed to store response for key %s: %v", key, err)
+			}
+			return nil
+		}
+	}
+}
+
+func replay(c echo.Context, record *models.IdempotencyRecord, fingerprint string) error {
+	if record.Fingerprint != fingerprint {
+		return idempotencyError(c, http.StatusConflict, "Idempotency-Key was already used with a different request")
+	}
+	c.Response().Header().Set("Idempotent-Replayed", "true")
+	return c.Blob(record.ResponseStatus, record.ContentType, record.ResponseBody)
+}
+
+func requestFingerprint(method, path string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method + " " + path + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func idempotencyError(c echo.Context, status int, message string) error {
+	return c.JSON(status, map[string]interface{}{
+		"success": false,
+		"message": message,
+	})
+}
+
+// responseRecorder copies everything written to the client so it can be stored.
+type responseRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *responseRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"fin-auth/domain"
+	"fin-auth/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+// UnmaskMiddleware decides once per request whether PII is returned unmasked. A caller
+// asking for it with ?unmasked=true without the scope gets a 403; handlers read the
+// decision with domain.Unmasked.
+func UnmaskMiddleware() echo.MiddlewareFunc {
+	response := domain.NewResponse()
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if c.QueryParam("unmasked") != "true" {
+				return next(c)
+			}
+			if !domain.UnmaskPermitted(c) {
+				return response.ForbiddenResponse(c, nil, utils.StringPtr("Unmasked PII requires the "+utils.SCOPE_PII_UNMASKED+" scope"))
+			}
+			c.Set("unmasked", true)
+			return next(c)
+		}
+	}
+}
@@ -2,12 +2,15 @@ package repo
 
 import (
 	"context"
+	"errors"
 	"fin-auth/cache"
 	"fin-auth/domain"
 	"fin-auth/dto"
 	"fin-auth/models"
+	"fin-auth/utils"
 	"time"
 
+	"github.com/lib/pq"
 	"gorm.io/gorm"
 )
 
@@ -147,3 +150,23 @@ func (auth *Auth) FindValidAccessToken(ctx context.Context, tokenStr string) (*m
 
 	return &token, nil
 }
+
+func (auth *Auth) UpdateClientScopes(ctx context.Context, clientId string, scopes []string) (*models.User, error) {
+	var user models.User
+	if err := auth.db.Where("client_id = ?", clientId).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, err
+	}
+
+	user.Scopes = pq.StringArray(scopes)
+	if err := auth.db.Model(&user).Update("scopes", user.Scopes).Error; err != nil {
+		return nil, err
+	}
+	if auth.cache != nil {
+		auth.cache.InvalidateClient(ctx, clientId)
+	}
+
+	return &user, nil
+}
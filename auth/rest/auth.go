@@ -1,8 +1,10 @@
 package rest
 
 import (
+	"errors"
 	"fin-auth/domain"
 	"fin-auth/dto"
+	"fin-auth/utils"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
@@ -38,6 +40,16 @@ func SetupProtectedRoutes(api *echo.Group, s domain.AuthService) {
 	api.DELETE("/auth/sessions/:token", handler.revokeSession)
 }
 
+// SetupAuthAdminRoutes lets operators grant scopes such as unmasked PII reads to clients.
+func SetupAuthAdminRoutes(admin *echo.Group, s domain.AuthService) {
+	handler := &AuthHandler{
+		Service:  s,
+		Response: domain.NewResponse(),
+	}
+
+	admin.PUT("/clients/:client_id/scopes", handler.updateClientScopes)
+}
+
 func (authHandler *AuthHandler) register(c echo.Context) error {
 	req := dto.RegisterClientReq{}
 	err := c.Bind(&req)
@@ -133,6 +145,7 @@ func (authHandler *AuthHandler) me(c echo.Context) error {
 	return c.JSON(http.StatusOK, map[string]interface{}{
 		"success":   true,
 		"client_id": clientId,
+		"scopes":    c.Get("scopes"),
 		"message":   "Authenticated client",
 	})
 }
@@ -202,3 +215,24 @@ func (authHandler *AuthHandler) revokeSession(c echo.Context) error {
 		"message": "Session revoked successfully",
 	})
 }
+
+func (authHandler *AuthHandler) updateClientScopes(c echo.Context) error {
+	req := dto.ClientScopesReq{}
+	if err := c.Bind(&req); err != nil {
+		return authHandler.Response.InvalidData(c, nil)
+	}
+
+	v := req.Validate()
+	if v.Status {
+		return authHandler.Response.ValidationFail(c, v, nil)
+	}
+
+	user, err := authHandler.Service.UpdateClientScopes(c.Request().Context(), c.Param("client_id"), req.Scopes)
+	if errors.Is(err, utils.ErrNotFound) {
+		return authHandler.Response.NotFound(c, utils.StringPtr("Client not found"))
+	}
+	if err != nil {
+		return authHandler.Response.InternalServerError(c, err)
+	}
+	return authHandler.Response.SuccessOk(c, user)
+}
@@ -210,3 +210,7 @@ func (auth *Auth) RefreshToken(ctx context.Context, req *dto.RefreshTokenReq) (*
 
 	return response, nil
 }
+
+func (auth *Auth) UpdateClientScopes(ctx context.Context, clientId string, scopes []string) (*models.User, error) {
+	return auth.Repo.UpdateClientScopes(ctx, clientId, scopes)
+}
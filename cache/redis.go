@@ -5,10 +5,13 @@ import (
 	"encoding/json"
 	"fin-auth/domain"
 	"fin-auth/models"
+	"fin-auth/pii"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/lib/pq"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -244,6 +247,7 @@ func (r *RedisCache) CacheClient(ctx context.Context, clientId string, data *dom
 		"name":             data.User.Name,
 		"email":            data.User.Email,
 		"is_active":        data.User.IsActive,
+		"scopes":           strings.Join(data.User.Scopes, ","),
 		"secret":           data.Secret.Secret,
 		"secondary_secret": data.Secret.SecondarySecret,
 	}
@@ -276,6 +280,9 @@ func (r *RedisCache) GetCachedClient(ctx context.Context, clientId string) (*dom
 		Email:    result["email"],
 		IsActive: isActive,
 	}
+	if result["scopes"] != "" {
+		user.Scopes = pq.StringArray(strings.Split(result["scopes"], ","))
+	}
 
 	secret := &models.Secret{
 		ClientId:        clientId,
@@ -296,7 +303,7 @@ func (r *RedisCache) InvalidateClient(ctx context.Context, clientId string) erro
 
 func (r *RedisCache) CacheCustomer(ctx context.Context, clientId, customerId string, data *domain.CachedCustomer) error {
 	key := fmt.Sprintf("customer:%s:%s", clientId, customerId)
-	b, err := json.Marshal(data)
+	b, err := sealJSON(data)
 	if err != nil {
 		return err
 	}
@@ -310,15 +317,20 @@ func (r *RedisCache) GetCachedCustomer(ctx context.Context, clientId, customerId
 		return nil, err
 	}
 	var cached domain.CachedCustomer
-	if err := json.Unmarshal([]byte(data), &cached); err != nil {
+	if err := openJSON(data, &cached); err != nil {
 		return nil, err
 	}
 	return &cached, nil
 }
 
+func (r *RedisCache) InvalidateCustomer(ctx context.Context, clientId, customerId string) error {
+	key := fmt.Sprintf("customer:%s:%s", clientId, customerId)
+	return r.client.Del(ctx, key).Err()
+}
+
 func (r *RedisCache) CacheCustomerList(ctx context.Context, clientId string, customers []*domain.CachedCustomer) error {
 	key := fmt.Sprintf("customers:%s", clientId)
-	b, err := json.Marshal(customers)
+	b, err := sealJSON(customers)
 	if err != nil {
 		return err
 	}
@@ -332,19 +344,248 @@ func (r *RedisCache) GetCachedCustomerList(ctx context.Context, clientId string)
 		return nil, err
 	}
 	var customers []*domain.CachedCustomer
-	if err := json.Unmarshal([]byte(data), &customers); err != nil {
+	if err := openJSON(data, &customers); err != nil {
 		return nil, err
 	}
 	return customers, nil
 }
 
+// sealJSON encrypts cached customer payloads, which carry the person's PII.
+func sealJSON(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return pii.Encrypt(b)
+}
+
+func openJSON(data string, v interface{}) error {
+	b, err := pii.Decrypt(data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// PurgeCustomer deletes the cached customer and its client's customer list. It returns
+// the number of keys removed.
+func (r *RedisCache) PurgeCustomer(ctx context.Context, clientId, customerId string) (int64, error) {
+	if clientId == "" {
+		return 0, nil
+	}
+	return r.client.Del(ctx, fmt.Sprintf("customer:%s:%s", clientId, customerId), fmt.Sprintf("customers:%s", clientId)).Result()
+}
+
 func (r *RedisCache) InvalidateCustomerList(ctx context.Context, clientId string) error {
 	key := fmt.Sprintf("customers:%s", clientId)
 	return r.client.Del(ctx, key).Err()
 }
 
+// Webhook Deduplication
+
+// MarkWebhookEventSeen records an inbound webhook event ID once the event was processed.
+func (r *RedisCache) MarkWebhookEventSeen(ctx context.Context, source, eventId string, ttl time.Duration) error {
+	key := fmt.Sprintf("webhook:%s:%s", source, eventId)
+	return r.client.Set(ctx, key, "1", ttl).Err()
+}
+
+func (r *RedisCache) IsWebhookEventSeen(ctx context.Context, source, eventId string) (bool, error) {
+	key := fmt.Sprintf("webhook:%s:%s", source, eventId)
+	n, err := r.client.Exists(ctx, key).Result()
+	return n > 0, err
+}
+
+// One-Time Codes
+
+func otpChallengeKey(channel string, personId int) string {
+	return fmt.Sprintf("otp:%s:%d", channel, personId)
+}
+
+// StoreOTPChallenge replaces any pending code of the person on the channel.
+func (r *RedisCache) StoreOTPChallenge(ctx context.Context, channel string, personId int, challenge *domain.OTPChallenge) error {
+	key := otpChallengeKey(channel, personId)
+	pipe := r.client.TxPipeline()
+	pipe.Del(ctx, key)
+	pipe.HSet(ctx, key, map[string]interface{}{
+		"code_hash":  challenge.CodeHash,
+		"target":     challenge.TargetIndex,
+		"attempts":   challenge.Attempts,
+		"expires_at": challenge.ExpiresAt.Unix(),
+	})
+	pipe.ExpireAt(ctx, key, challenge.ExpiresAt)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// GetOTPChallenge returns redis.Nil when no code is pending.
+func (r *RedisCache) GetOTPChallenge(ctx context.Context, channel string, personId int) (*domain.OTPChallenge, error) {
+	values, err := r.client.HGetAll(ctx, otpChallengeKey(channel, personId)).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, redis.Nil
+	}
+
+	attempts, _ := strconv.Atoi(values["attempts"])
+	expiresAt, _ := strconv.ParseInt(values["expires_at"], 10, 64)
+	return &domain.OTPChallenge{
+		CodeHash:    values["code_hash"],
+		TargetIndex: values["target"],
+		Attempts:    attempts,
+		ExpiresAt:   time.Unix(expiresAt, 0).UTC(),
+	}, nil
+}
+
+// incrementOTPAttempts only counts against a challenge that still exists: HINCRBY on
+// an expired one would recreate it without a TTL.
+var incrementOTPAttempts = redis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 0 then
+	return false
+end
+return redis.call("HINCRBY", KEYS[1], "attempts", 1)
+`)
+
+// IncrementOTPAttempts counts a guess against the pending code and returns the
+// number of guesses made so far, or redis.Nil when the code expired meanwhile.
+func (r *RedisCache) IncrementOTPAttempts(ctx context.Context, channel string, personId int) (int64, error) {
+	return incrementOTPAttempts.Run(ctx, r.client, []string{otpChallengeKey(channel, personId)}).Int64()
+}
+
+func (r *RedisCache) DeleteOTPChallenge(ctx context.Context, channel string, personId int) error {
+	return r.client.Del(ctx, otpChallengeKey(channel, personId)).Err()
+}
+
+// CountOTPSend counts a code sent to the address behind targetIndex and returns the
+// number sent within the window, which starts with the first send.
+func (r *RedisCache) CountOTPSend(ctx context.Context, channel, targetIndex string, window time.Duration) (int64, error) {
+	key := fmt.Sprintf("otp-sends:%s:%s", channel, targetIndex)
+	pipe := r.client.TxPipeline()
+	count := pipe.Incr(ctx, key)
+	pipe.ExpireNX(ctx, key, window)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return count.Val(), nil
+}
+
+// RefundOTPSend takes back a send counted by CountOTPSend for a code that was not sent.
+func (r *RedisCache) RefundOTPSend(ctx context.Context, channel, targetIndex string) error {
+	key := fmt.Sprintf("otp-sends:%s:%s", channel, targetIndex)
+	return r.client.Decr(ctx, key).Err()
+}
+
+// Streams
+
+func (r *RedisCache) PublishStream(ctx context.Context, stream string, maxLen int64, values map[string]interface{}) error {
+	return r.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: stream,
+		MaxLen: maxLen,
+		Approx: true,
+		Values: values,
+	}).Err()
+}
+
 // Health Check
 
 func (r *RedisCache) Ping(ctx context.Context) error {
 	return r.client.Ping(ctx).Err()
 }
+
+func (r *RedisCache) CacheJurisdictionRestrictions(ctx context.Context, partner string, restrictions []*models.JurisdictionRestriction, ttl time.Duration) error {
+	key := fmt.Sprintf("jurisdiction:%s", partner)
+	b, err := json.Marshal(restrictions)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(ctx, key, b, ttl).Err()
+}
+
+func (r *RedisCache) GetCachedJurisdictionRestrictions(ctx context.Context, partner string) ([]*models.JurisdictionRestriction, error) {
+	key := fmt.Sprintf("jurisdiction:%s", partner)
+	data, err := r.client.Get(ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+	var restrictions []*models.JurisdictionRestriction
+	if err := json.Unmarshal([]byte(data), &restrictions); err != nil {
+		return nil, err
+	}
+	return restrictions, nil
+}
+
+func (r *RedisCache) InvalidateJurisdictionRestrictions(ctx context.Context, partner string) error {
+	key := fmt.Sprintf("jurisdiction:%s", partner)
+	return r.client.Del(ctx, key).Err()
+}
+
+func (r *RedisCache) CacheReferenceItems(ctx context.Context, catalog string, items []*models.ReferenceItem, ttl time.Duration) error {
+	key := fmt.Sprintf("reference:%s", catalog)
+	b, err := json.Marshal(items)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(ctx, key, b, ttl).Err()
+}
+
+func (r *RedisCache) GetCachedReferenceItems(ctx context.Context, catalog string) ([]*models.ReferenceItem, error) {
+	key := fmt.Sprintf("reference:%s", catalog)
+	data, err := r.client.Get(ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+	var items []*models.ReferenceItem
+	if err := json.Unmarshal([]byte(data), &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// Idempotency
+
+func (r *RedisCache) CacheIdempotencyRecord(ctx context.Context, record *models.IdempotencyRecord, ttl time.Duration) error {
+	key := fmt.Sprintf("idempotency:%s:%s", record.ClientID, record.Key)
+	b, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	// The stored response may hold personal data, so it is sealed like PII columns.
+	sealed, err := pii.Encrypt(b)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(ctx, key, sealed, ttl).Err()
+}
+
+func (r *RedisCache) GetCachedIdempotencyRecord(ctx context.Context, clientId, idempotencyKey string) (*models.IdempotencyRecord, error) {
+	key := fmt.Sprintf("idempotency:%s:%s", clientId, idempotencyKey)
+	data, err := r.client.Get(ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := pii.Decrypt(data)
+	if err != nil {
+		return nil, err
+	}
+	var record models.IdempotencyRecord
+	if err := json.Unmarshal(plaintext, &record); err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+func (r *RedisCache) InvalidateIdempotencyRecord(ctx context.Context, clientId, idempotencyKey string) error {
+	key := fmt.Sprintf("idempotency:%s:%s", clientId, idempotencyKey)
+	return r.client.Del(ctx, key).Err()
+}
+
+// AcquireIdempotencyLock reports whether the lock for the key was free and is now held.
+func (r *RedisCache) AcquireIdempotencyLock(ctx context.Context, clientId, idempotencyKey string, ttl time.Duration) (bool, error) {
+	key := fmt.Sprintf("idempotency-lock:%s:%s", clientId, idempotencyKey)
+	return r.client.SetNX(ctx, key, "1", ttl).Result()
+}
+
+func (r *RedisCache) ReleaseIdempotencyLock(ctx context.Context, clientId, idempotencyKey string) error {
+	key := fmt.Sprintf("idempotency-lock:%s:%s", clientId, idempotencyKey)
+	return r.client.Del(ctx, key).Err()
+}
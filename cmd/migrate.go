@@ -3,6 +3,9 @@ package cmd
 import (
 	"fin-auth/config"
 	"fin-auth/database"
+	"fin-auth/dto"
+	"fin-auth/models"
+	"fmt"
 	"log"
 
 	"github.com/spf13/cobra"
@@ -62,9 +65,56 @@ func runMigration() {
 		log.Fatalf("Migration failed: %v", err)
 	}
 
+	if err := database.BackfillAddresses(db); err != nil {
+		log.Fatalf("Backfilling addresses failed: %v", err)
+	}
+
+	if err := database.BackfillTOSPolicies(db); err != nil {
+		log.Fatalf("Backfilling terms of service policies failed: %v", err)
+	}
+
+	if err := database.SeedJurisdictionRestrictions(db); err != nil {
+		log.Fatalf("Seeding jurisdiction restrictions failed: %v", err)
+	}
+
+	if err := database.SeedReferenceData(db); err != nil {
+		log.Fatalf("Seeding reference data failed: %v", err)
+	}
+
+	if err := database.BackfillFinancialProfileCodes(db); err != nil {
+		log.Fatalf("Backfilling financial profile codes failed: %v", err)
+	}
+
+	policy, err := initialTOSPolicy()
+	if err != nil {
+		log.Fatalf("Invalid terms of service config: %v", err)
+	}
+	if err := database.SeedTOSPolicy(db, policy); err != nil {
+		log.Fatalf("Seeding terms of service policy failed: %v", err)
+	}
+
 	log.Println("Migration completed successfully!")
 }
 
+// initialTOSPolicy validates the configured first terms of service version like a
+// published one. It returns nil when none is configured.
+func initialTOSPolicy() (*models.TOSPolicy, error) {
+	cfg := config.GetConfig().TOS
+	if cfg.Version == "" {
+		return nil, nil
+	}
+	req := &dto.TOSPolicyReq{
+		Version:        cfg.Version,
+		Title:          cfg.Title,
+		DocumentURL:    cfg.DocumentURL,
+		DocumentSHA256: cfg.DocumentSHA256,
+	}
+	if v := req.Validate(); v.Status {
+		return nil, fmt.Errorf("%v", v.Response)
+	}
+	return req.GetPolicy(), nil
+}
+
 func checkMigrationStatus() {
 	if err := config.LoadConfig(); err != nil {
 		log.Fatalf("Failed to load config: %v", err)
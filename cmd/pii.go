@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"fin-auth/config"
+	"fin-auth/models"
+	"fin-auth/pii"
+	"log"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+)
+
+var (
+	rotateBatchSize int
+	rotateReindex   bool
+)
+
+var personPIIColumns = []string{"email", "phone", "dob", "tin"}
+var personIndexColumns = []string{"email_bidx", "phone_bidx", "tin_bidx"}
+var addressPIIColumns = []string{"street", "city", "state", "state_code", "postal_code", "country", "normalized_street", "normalized_city", "normalized_postal_code"}
+
+var piiCmd = &cobra.Command{
+	Use:   "pii",
+	Short: "Manage encrypted personal data",
+}
+
+var piiRotateKeysCmd = &cobra.Command{
+	Use:   "rotate-keys",
+	Short: "Re-encrypt stored PII under the active master key",
+	Long: `Re-encrypts every person and address column that is still plaintext or sealed
+with an older master key version. Keep the old key versions configured until this
+finishes, then they can be removed. Use --reindex after changing the blind index key
+to rewrite every row and recompute the lookup indexes.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		rotatePIIKeys()
+	},
+}
+
+func init() {
+	piiRotateKeysCmd.Flags().IntVar(&rotateBatchSize, "batch-size", 500, "rows re-encrypted per batch")
+	piiRotateKeysCmd.Flags().BoolVar(&rotateReindex, "reindex", false, "rewrite every row, including those already under the active key")
+	piiCmd.AddCommand(piiRotateKeysCmd)
+	rootCmd.AddCommand(piiCmd)
+}
+
+func rotatePIIKeys() {
+	if err := config.LoadConfig(); err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	db, err := config.InitGormDB()
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	prefix, err := pii.ActivePrefix()
+	if err != nil {
+		log.Fatalf("Failed to read active pii key: %v", err)
+	}
+	if rotateBatchSize <= 0 {
+		rotateBatchSize = 500
+	}
+
+	persons, err := rotateTable(db, prefix, personPIIColumns, append(append([]string{}, personPIIColumns...), personIndexColumns...), func() interface{} { return &[]*models.Person{} })
+	if err != nil {
+		log.Fatalf("Failed to rotate person pii: %v", err)
+	}
+	addresses, err := rotateTable(db, prefix, addressPIIColumns, addressPIIColumns, func() interface{} { return &[]*models.Address{} })
+	if err != nil {
+		log.Fatalf("Failed to rotate address pii: %v", err)
+	}
+
+	log.Printf("Re-encrypted %d persons and %d addresses under %s", persons, addresses, strings.TrimSuffix(prefix, ":"))
+}
+
+// rotateTable pages through rows by id and rewrites the encrypted columns, which the
+// pii serializer seals under the active key. Unless --reindex is set, only rows with a
+// column outside the active key are loaded.
+func rotateTable(db *gorm.DB, prefix string, encrypted, updated []string, newBatch func() interface{}) (int, error) {
+	var stale []string
+	var args []interface{}
+	for _, column := range encrypted {
+		stale = append(stale, "("+column+" IS NOT NULL AND "+column+" NOT LIKE ?)")
+		args = append(args, prefix+"%")
+	}
+
+	total, lastId := 0, 0
+	for {
+		batch := newBatch()
+		query := db.Where("id > ?", lastId).Order("id").Limit(rotateBatchSize)
+		if !rotateReindex {
+			query = query.Where(strings.Join(stale, " OR "), args...)
+		}
+		if err := query.Find(batch).Error; err != nil {
+			return total, err
+		}
+
+		rows := rowsOf(batch)
+		if len(rows) == 0 {
+			return total, nil
+		}
+		for _, row := range rows {
+			if err := db.Model(row.value).Select(updated).Updates(row.value).Error; err != nil {
+				return total, err
+			}
+			lastId = row.id
+			total++
+		}
+	}
+}
+
+type rotateRow struct {
+	id    int
+	value interface{}
+}
+
+func rowsOf(batch interface{}) []rotateRow {
+	var rows []rotateRow
+	switch b := batch.(type) {
+	case *[]*models.Person:
+		for _, p := range *b {
+			rows = append(rows, rotateRow{id: p.ID, value: p})
+		}
+	case *[]*models.Address:
+		for _, a := range *b {
+			rows = append(rows, rotateRow{id: a.ID, value: a})
+		}
+	}
+	return rows
+}
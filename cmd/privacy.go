@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fin-auth/cache"
+	"fin-auth/config"
+	privacyRepo "fin-auth/privacy/repo"
+	privacyService "fin-auth/privacy/service"
+	"fin-auth/storage"
+	"fin-auth/utils"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var exportOutput string
+
+var privacyCmd = &cobra.Command{
+	Use:   "privacy",
+	Short: "Handle data subject access and erasure requests",
+}
+
+var privacyExportCmd = &cobra.Command{
+	Use:   "export <customer-id>",
+	Short: "Write everything held about a customer to a JSON archive",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		exportCustomer(args[0])
+	},
+}
+
+var privacyEraseCmd = &cobra.Command{
+	Use:   "erase <customer-id>",
+	Short: "Erase a customer's personal data and print the receipt",
+	Long: `Deletes the customer's document files, clears the PII columns of the person and
+address, redacts event payloads and purges the customer's cache entries. Records the
+law requires, such as KYC status and sanctions screening results, are kept.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		eraseCustomer(args[0])
+	},
+}
+
+func init() {
+	privacyExportCmd.Flags().StringVarP(&exportOutput, "out", "o", "", "archive path (default customer-<id>.json)")
+	privacyCmd.AddCommand(privacyExportCmd, privacyEraseCmd)
+	rootCmd.AddCommand(privacyCmd)
+}
+
+func newPrivacyService() *privacyService.Privacy {
+	if err := config.LoadConfig(); err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	db, err := config.InitGormDB()
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	var redisCache *cache.RedisCache
+	redisClient, err := config.InitRedis()
+	if err != nil {
+		log.Printf("Warning: Failed to connect to Redis: %v (cache entries will not be purged)", err)
+	} else {
+		redisCache = cache.NewRedisCache(redisClient)
+	}
+
+	localStorage, err := storage.NewLocalStorage(config.GetConfig().Storage.LocalPath)
+	if err != nil {
+		log.Fatalf("Failed to initialize document storage: %v", err)
+	}
+	documentStorage := storage.NewSealedStorage(localStorage)
+
+	return privacyService.NewPrivacyService(privacyRepo.NewPrivacyRepository(db, redisCache), documentStorage, redisCache)
+}
+
+func exportCustomer(customerId string) {
+	archive, receipt, err := newPrivacyService().Export(context.Background(), "", customerId, utils.DATA_REQUEST_BY_CLI)
+	if err != nil {
+		log.Fatalf("Failed to export customer %s: %v", customerId, err)
+	}
+
+	path := exportOutput
+	if path == "" {
+		path = fmt.Sprintf("customer-%s.json", customerId)
+	}
+	if err := os.WriteFile(path, archive, 0o600); err != nil {
+		log.Fatalf("Failed to write archive: %v", err)
+	}
+	log.Printf("Wrote %s", path)
+	printJSON(receipt)
+}
+
+func eraseCustomer(customerId string) {
+	receipt, err := newPrivacyService().Erase(context.Background(), "", customerId, utils.DATA_REQUEST_BY_CLI)
+	if err != nil {
+		log.Fatalf("Failed to erase customer %s: %v", customerId, err)
+	}
+	printJSON(receipt)
+}
+
+func printJSON(v interface{}) {
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		log.Fatalf("Failed to encode output: %v", err)
+	}
+	fmt.Println(string(out))
+}
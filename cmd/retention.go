@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"context"
+	"fin-auth/cache"
+	"fin-auth/config"
+	retentionRepo "fin-auth/retention/repo"
+	retentionService "fin-auth/retention/service"
+	"fin-auth/storage"
+	"log"
+
+	"github.com/spf13/cobra"
+)
+
+var retentionDryRun bool
+
+var retentionCmd = &cobra.Command{
+	Use:   "retention",
+	Short: "Apply data retention policies",
+}
+
+var retentionRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Purge rows past their retention period once and record the run",
+	Long: `Applies every enabled retention policy once, as the retention-purge worker does.
+With --dry-run nothing is deleted and the run records what would have been purged.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		runRetention()
+	},
+}
+
+func init() {
+	retentionRunCmd.Flags().BoolVar(&retentionDryRun, "dry-run", false, "only count what would be purged")
+	retentionCmd.AddCommand(retentionRunCmd)
+	rootCmd.AddCommand(retentionCmd)
+}
+
+func runRetention() {
+	if err := config.LoadConfig(); err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	db, err := config.InitGormDB()
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	var redisCache *cache.RedisCache
+	redisClient, err := config.InitRedis()
+	if err != nil {
+		log.Printf("Warning: Failed to connect to Redis: %v (cache entries will not be purged)", err)
+	} else {
+		redisCache = cache.NewRedisCache(redisClient)
+	}
+
+	localStorage, err := storage.NewLocalStorage(config.GetConfig().Storage.LocalPath)
+	if err != nil {
+		log.Fatalf("Failed to initialize document storage: %v", err)
+	}
+	documentStorage := storage.NewSealedStorage(localStorage)
+
+	svc := retentionService.NewRetentionService(retentionRepo.NewRetentionRepository(db, redisCache), documentStorage, config.GetConfig().Retention)
+	run, err := svc.Run(context.Background(), retentionDryRun)
+	if err != nil {
+		log.Fatalf("Retention run failed: %v", err)
+	}
+	log.Printf("Retention run %d %s", run.ID, run.Status)
+	printJSON(run)
+}
@@ -1,7 +1,24 @@
 package cmd
 
 import (
+	"fin-auth/cache"
 	"fin-auth/config"
+	corridorRepo "fin-auth/corridor/repo"
+	corridorService "fin-auth/corridor/service"
+	customerRepo "fin-auth/customer/repo"
+	"fin-auth/domain"
+	kycProvider "fin-auth/kyc/provider"
+	kycRepo "fin-auth/kyc/repo"
+	kycService "fin-auth/kyc/service"
+	outboxRepo "fin-auth/outbox/repo"
+	"fin-auth/outbox/sink"
+	retentionRepo "fin-auth/retention/repo"
+	retentionService "fin-auth/retention/service"
+	screeningRepo "fin-auth/screening/repo"
+	screeningService "fin-auth/screening/service"
+	"fin-auth/storage"
+	"fin-auth/utils"
+	webhookRepo "fin-auth/webhook/repo"
 	"fin-auth/worker"
 	"log"
 	"time"
@@ -31,8 +48,78 @@ func starWorker() {
 		log.Fatalf("Failed to initialize database: %v", error)
 	}
 
+	var redisCache *cache.RedisCache
+	redisClient, err := config.InitRedis()
+	if err != nil {
+		log.Printf("Warning: Failed to connect to Redis: %v (redis outbox sinks disabled)", err)
+	} else {
+		redisCache = cache.NewRedisCache(redisClient)
+	}
+
 	tokenCleanupWorker := worker.NewTokenCleanupWorker(db)
+	webhookDeliveryWorker := worker.NewWebhookDeliveryWorker(db)
+	outboxDispatchWorker := worker.NewOutboxDispatchWorker(db, outboxSinks(config.GetConfig().Outbox, webhookRepo.NewWebhookRepository(db, redisCache), redisCache)...)
+
+	cr := customerRepo.NewCustomerRepository(db, redisCache, outboxRepo.NewOutboxRepository(db, redisCache))
+	corridorSvc := corridorService.NewCorridorService(corridorRepo.NewCorridorRepository(db, redisCache), cr)
+	kycSvc := kycService.NewKYCService(cr, kycRepo.NewKYCRepository(db, redisCache), kycProvider.NewRegistry(kycProvider.NewFake(), kycProvider.NewBridge()), corridorSvc, config.GetConfig().KYC, redisCache)
+	screeningSvc := screeningService.NewScreeningService(screeningRepo.NewScreeningRepository(db, redisCache), kycSvc, config.GetConfig().Screening)
+	sanctionsRescreenWorker := worker.NewSanctionsRescreenWorker(screeningSvc)
+	corridorRefreshWorker := worker.NewCorridorRefreshWorker(corridorSvc)
+	rescreenInterval := time.Duration(config.GetConfig().Screening.RefreshIntervalSeconds) * time.Second
+	if rescreenInterval <= 0 {
+		rescreenInterval = time.Hour
+	}
+
+	localStorage, err := storage.NewLocalStorage(config.GetConfig().Storage.LocalPath)
+	if err != nil {
+		log.Fatalf("Failed to initialize document storage: %v", err)
+	}
+	documentStorage := storage.NewSealedStorage(localStorage)
+	retentionCfg := config.GetConfig().Retention
+	retentionSvc := retentionService.NewRetentionService(retentionRepo.NewRetentionRepository(db, redisCache), documentStorage, retentionCfg)
+	retentionPurgeWorker := worker.NewRetentionPurgeWorker(retentionSvc, retentionCfg.DryRun)
+	retentionInterval := time.Duration(retentionCfg.IntervalSeconds) * time.Second
+	if retentionInterval <= 0 {
+		retentionInterval = 24 * time.Hour
+	}
+
+	worker.StartAll(
+		worker.NewWorker(5*time.Minute, tokenCleanupWorker.Run, "token-cleanup"),
+		worker.NewWorker(5*time.Second, outboxDispatchWorker.Run, "outbox-dispatch"),
+		worker.NewWorker(15*time.Second, webhookDeliveryWorker.Run, "webhook-delivery"),
+		worker.NewWorker(rescreenInterval, sanctionsRescreenWorker.Run, "sanctions-rescreen"),
+		worker.NewWorker(time.Minute, corridorRefreshWorker.Run, "corridor-refresh"),
+		worker.NewWorker(retentionInterval, retentionPurgeWorker.Run, "retention-purge"),
+	)
+}
 
-	worker := worker.NewWorker(5*time.Minute, tokenCleanupWorker.Run, "token-cleanup")
-	worker.Start()
+func outboxSinks(cfg config.OutboxConfig, webhooks domain.WebhookRepository, redisCache *cache.RedisCache) []domain.OutboxSink {
+	names := cfg.Sinks
+	if len(names) == 0 {
+		names = []string{utils.OUTBOX_SINK_WEBHOOK, utils.OUTBOX_SINK_CACHE, utils.OUTBOX_SINK_LOG}
+	}
+
+	sinks := make([]domain.OutboxSink, 0, len(names))
+	for _, name := range names {
+		switch name {
+		case utils.OUTBOX_SINK_WEBHOOK:
+			sinks = append(sinks, sink.NewWebhookSink(webhooks))
+		case utils.OUTBOX_SINK_STREAM, utils.OUTBOX_SINK_CACHE:
+			if redisCache == nil {
+				log.Printf("Warning: outbox sink %q needs Redis, skipping", name)
+				continue
+			}
+			if name == utils.OUTBOX_SINK_STREAM {
+				sinks = append(sinks, sink.NewStreamSink(redisCache, cfg.Stream, cfg.StreamMaxLen))
+			} else {
+				sinks = append(sinks, sink.NewCacheSink(redisCache))
+			}
+		case utils.OUTBOX_SINK_LOG:
+			sinks = append(sinks, sink.NewLogSink())
+		default:
+			log.Printf("Warning: unknown outbox sink %q, skipping", name)
+		}
+	}
+	return sinks
 }
@@ -0,0 +1,6 @@
+package config
+
+// AdminConfig holds the static keys accepted on /admin routes via the X-Admin-Key header.
+type AdminConfig struct {
+	APIKeys []string `json:"api_keys"`
+}
@@ -7,10 +7,23 @@ import (
 )
 
 type Config struct {
-	AppEnv   string         `json:"app_env"`
-	Server   ServerConfig   `json:"server"`
-	Database DatabaseConfig `json:"database"`
-	Redis    RedisConfig    `json:"redis"`
+	AppEnv       string             `json:"app_env"`
+	Server       ServerConfig       `json:"server"`
+	Database     DatabaseConfig     `json:"database"`
+	Redis        RedisConfig        `json:"redis"`
+	KYC          KYCConfig          `json:"kyc"`
+	Outbox       OutboxConfig       `json:"outbox"`
+	Storage      StorageConfig      `json:"storage"`
+	Admin        AdminConfig        `json:"admin"`
+	Jurisdiction JurisdictionConfig `json:"jurisdiction"`
+	Screening    ScreeningConfig    `json:"screening"`
+	Risk         RiskConfig         `json:"risk"`
+	Dedupe       DedupeConfig       `json:"dedupe"`
+	Idempotency  IdempotencyConfig  `json:"idempotency"`
+	PII          PIIConfig          `json:"pii"`
+	Retention    RetentionConfig    `json:"retention"`
+	OTP          OTPConfig          `json:"otp"`
+	TOS          TOSConfig          `json:"tos"`
 }
 
 type ServerConfig struct {
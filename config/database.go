@@ -58,7 +58,13 @@ func InitDB() (*pgxpool.Pool, error) {
 	return pool, nil
 }
 
+// InitGormDB also loads the PII keyring, since encrypted columns cannot be read or
+// written without it.
 func InitGormDB() (*gorm.DB, error) {
+	if err := InitPII(); err != nil {
+		return nil, fmt.Errorf("failed to load pii keys: %w", err)
+	}
+
 	cfg := GetConfig().Database
 	dsn := cfg.GetConnectionString()
 
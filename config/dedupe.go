@@ -0,0 +1,17 @@
+package config
+
+// DedupeConfig lists the identity fields checked for duplicates at customer creation.
+// Without rules, email and phone are rejected within a client and TIN matches across
+// clients are flagged for review.
+type DedupeConfig struct {
+	Rules []DedupeRule `json:"rules"`
+}
+
+// DedupeRule matches one field ("email", "phone" or "tin") within the same client or
+// across all clients ("client" or "global") and either rejects the new customer or
+// links it to the existing one and flags it ("reject" or "flag").
+type DedupeRule struct {
+	Field  string `json:"field"`
+	Scope  string `json:"scope"`
+	Action string `json:"action"`
+}
@@ -0,0 +1,9 @@
+package config
+
+type IdempotencyConfig struct {
+	TTLHours           int `json:"ttl_hours"`
+	LockTimeoutSeconds int `json:"lock_timeout_seconds"`
+	// MaxBodyMB caps the request body read for the fingerprint; it must cover the
+	// largest document upload.
+	MaxBodyMB int `json:"max_body_mb"`
+}
@@ -0,0 +1,6 @@
+package config
+
+type JurisdictionConfig struct {
+	Partner         string `json:"partner"`
+	CacheTTLSeconds int    `json:"cache_ttl_seconds"`
+}
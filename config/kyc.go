@@ -0,0 +1,7 @@
+package config
+
+type KYCConfig struct {
+	Provider                string            `json:"provider"`
+	WebhookSecrets          map[string]string `json:"webhook_secrets"`
+	WebhookToleranceSeconds int               `json:"webhook_tolerance_seconds"`
+}
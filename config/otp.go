@@ -0,0 +1,14 @@
+package config
+
+// OTPConfig controls the one-time codes sent to verify a person's email and phone.
+// MaxSends codes may go to one address within SendWindowSeconds; each code allows
+// MaxAttempts guesses and expires after TTLSeconds.
+type OTPConfig struct {
+	Length            int    `json:"length"`
+	TTLSeconds        int    `json:"ttl_seconds"`
+	MaxAttempts       int    `json:"max_attempts"`
+	MaxSends          int    `json:"max_sends"`
+	SendWindowSeconds int    `json:"send_window_seconds"`
+	Notifier          string `json:"notifier"`
+	FilePath          string `json:"file_path"`
+}
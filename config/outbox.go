@@ -0,0 +1,7 @@
+package config
+
+type OutboxConfig struct {
+	Sinks        []string `json:"sinks"`
+	Stream       string   `json:"stream"`
+	StreamMaxLen int64    `json:"stream_max_len"`
+}
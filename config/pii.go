@@ -0,0 +1,66 @@
+package config
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fin-auth/pii"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// PIIConfig holds the master keys for PII encryption as base64 32-byte keys keyed by
+// version. Keys may also come from MasterKeyFile, a JSON file with the same
+// master_keys and blind_index_key fields, which takes precedence.
+type PIIConfig struct {
+	ActiveKeyVersion int               `json:"active_key_version"`
+	MasterKeys       map[string]string `json:"master_keys"`
+	MasterKeyFile    string            `json:"master_key_file"`
+	BlindIndexKey    string            `json:"blind_index_key"`
+}
+
+// InitPII loads the configured keys into the pii keyring.
+func InitPII() error {
+	cfg := GetConfig().PII
+	masterKeys := map[string]string{}
+	for version, key := range cfg.MasterKeys {
+		masterKeys[version] = key
+	}
+	blindIndexKey := cfg.BlindIndexKey
+
+	if cfg.MasterKeyFile != "" {
+		data, err := os.ReadFile(cfg.MasterKeyFile)
+		if err != nil {
+			return fmt.Errorf("failed to read pii key file: %w", err)
+		}
+		var file PIIConfig
+		if err := json.Unmarshal(data, &file); err != nil {
+			return fmt.Errorf("failed to parse pii key file: %w", err)
+		}
+		for version, key := range file.MasterKeys {
+			masterKeys[version] = key
+		}
+		if file.BlindIndexKey != "" {
+			blindIndexKey = file.BlindIndexKey
+		}
+	}
+
+	keys := make(map[int][]byte, len(masterKeys))
+	for version, encoded := range masterKeys {
+		v, err := strconv.Atoi(version)
+		if err != nil {
+			return fmt.Errorf("pii master key version %q is not a number", version)
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return fmt.Errorf("pii master key version %d is not valid base64: %w", v, err)
+		}
+		keys[v] = key
+	}
+	blindKey, err := base64.StdEncoding.DecodeString(blindIndexKey)
+	if err != nil {
+		return fmt.Errorf("pii blind index key is not valid base64: %w", err)
+	}
+
+	return pii.Init(cfg.ActiveKeyVersion, keys, blindKey)
+}
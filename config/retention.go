@@ -0,0 +1,14 @@
+package config
+
+type RetentionConfig struct {
+	IntervalSeconds int               `json:"interval_seconds"`
+	BatchSize       int               `json:"batch_size"`
+	DryRun          bool              `json:"dry_run"`
+	Policies        []RetentionPolicy `json:"policies"`
+}
+
+// RetentionPolicy keeps an entity's rows for RetainDays; zero or less disables it.
+type RetentionPolicy struct {
+	Entity     string `json:"entity"`
+	RetainDays int    `json:"retain_days"`
+}
@@ -0,0 +1,30 @@
+package config
+
+// RiskConfig holds the rules used to score customers. Points from every matching rule
+// are summed and the total is compared against the tier thresholds.
+type RiskConfig struct {
+	MediumThreshold     int       `json:"medium_threshold"`
+	HighThreshold       int       `json:"high_threshold"`
+	ForceStandardOnHigh bool      `json:"force_standard_on_high"`
+	Rules               RiskRules `json:"rules"`
+}
+
+// RiskRules maps profile attributes to points. Occupations, sources of funds and
+// purposes are keyed by their reference codes, which unlike ids are the same in
+// every database.
+type RiskRules struct {
+	HighRiskCountries   []string         `json:"high_risk_countries"`
+	HighRiskNationality int              `json:"high_risk_nationality"`
+	HighRiskResidence   int              `json:"high_risk_residence"`
+	ResidenceMismatch   int              `json:"residence_mismatch"`
+	MonthlyVolume       []RiskVolumeBand `json:"monthly_volume"`
+	Occupations         map[string]int   `json:"occupations"`
+	SourcesOfFunds      map[string]int   `json:"sources_of_funds"`
+	Purposes            map[string]int   `json:"purposes"`
+}
+
+// RiskVolumeBand scores a monthly volume at or above MinUSD. The highest matching band wins.
+type RiskVolumeBand struct {
+	MinUSD float64 `json:"min_usd"`
+	Points int     `json:"points"`
+}
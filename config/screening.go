@@ -0,0 +1,7 @@
+package config
+
+type ScreeningConfig struct {
+	ListPath               string  `json:"list_path"`
+	Threshold              float64 `json:"threshold"`
+	RefreshIntervalSeconds int     `json:"refresh_interval_seconds"`
+}
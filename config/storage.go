@@ -0,0 +1,10 @@
+package config
+
+type StorageConfig struct {
+	Driver        string `json:"driver"`
+	LocalPath     string `json:"local_path"`
+	PublicBaseURL string `json:"public_base_url"`
+	SigningKey    string `json:"signing_key"`
+	URLTTLSeconds int    `json:"url_ttl_seconds"`
+	MaxUploadMB   int    `json:"max_upload_mb"`
+}
@@ -0,0 +1,15 @@
+package config
+
+// TOSConfig is the first terms of service version, published by migrate while none
+// exists: customers can't be created without a version in effect. Leave Version empty
+// to publish the first version through the admin API instead.
+type TOSConfig struct {
+	Version        string `json:"version"`
+	Title          string `json:"title"`
+	DocumentURL    string `json:"document_url"`
+	DocumentSHA256 string `json:"document_sha256"`
+	// RequirePolicyID rejects new customers without tos_policy_id. While it is off,
+	// they are recorded as accepting the version in effect, so clients that predate
+	// versioned policies keep working.
+	RequirePolicyID bool `json:"require_policy_id"`
+}
@@ -0,0 +1,98 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fin-auth/cache"
+	"fin-auth/models"
+	"fin-auth/utils"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Corridor struct {
+	db    *gorm.DB
+	cache *cache.RedisCache
+}
+
+func NewCorridorRepository(db *gorm.DB, cache *cache.RedisCache) *Corridor {
+	return &Corridor{
+		db:    db,
+		cache: cache,
+	}
+}
+
+func (o *Corridor) GetDB(tx ...*gorm.DB) *gorm.DB {
+	db := o.db
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db
+}
+
+func (o *Corridor) List(ctx context.Context, activeOnly bool) ([]*models.Corridor, error) {
+	query := o.db.WithContext(ctx)
+	if activeOnly {
+		query = query.Where("active")
+	}
+
+	var corridors []*models.Corridor
+	if err := query.Order("source_country, destination_country, source_currency, destination_currency").Find(&corridors).Error; err != nil {
+		return nil, err
+	}
+	return corridors, nil
+}
+
+func (o *Corridor) FindByID(ctx context.Context, id int) (*models.Corridor, error) {
+	var corridor models.Corridor
+	err := o.db.WithContext(ctx).Where("id = ?", id).First(&corridor).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, utils.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &corridor, nil
+}
+
+func (o *Corridor) Create(ctx context.Context, corridor *models.Corridor) error {
+	err := o.db.WithContext(ctx).Create(corridor).Error
+	if err != nil && utils.IsDuplicateKeyError(err) {
+		return utils.ErrConflict
+	}
+	return err
+}
+
+func (o *Corridor) Update(ctx context.Context, corridor *models.Corridor) error {
+	err := o.db.WithContext(ctx).Save(corridor).Error
+	if err != nil && utils.IsDuplicateKeyError(err) {
+		return utils.ErrConflict
+	}
+	return err
+}
+
+// LatestChange returns when a corridor was last created or updated, zero without any.
+func (o *Corridor) LatestChange(ctx context.Context) (time.Time, error) {
+	var latest *time.Time
+	if err := o.db.WithContext(ctx).Model(&models.Corridor{}).Select("MAX(updated_at)").Scan(&latest).Error; err != nil {
+		return time.Time{}, err
+	}
+	if latest == nil {
+		return time.Time{}, nil
+	}
+	return *latest, nil
+}
+
+func (o *Corridor) ListCustomersAfter(ctx context.Context, afterId string, limit int) ([]*models.Customer, error) {
+	query := o.db.WithContext(ctx).Select("id", "client_id")
+	if afterId != "" {
+		query = query.Where("id > ?", afterId)
+	}
+
+	var customers []*models.Customer
+	if err := query.Order("id").Limit(limit).Find(&customers).Error; err != nil {
+		return nil, err
+	}
+	return customers, nil
+}
@@ -0,0 +1,103 @@
+package rest
+
+import (
+	"errors"
+	"fin-auth/domain"
+	"fin-auth/dto"
+	"fin-auth/utils"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type CorridorHandler struct {
+	Service  domain.CorridorService
+	Response domain.Response
+}
+
+func SetupCorridorRoutes(api *echo.Group, s domain.CorridorService) {
+	handler := &CorridorHandler{
+		Service:  s,
+		Response: domain.NewResponse(),
+	}
+	api.GET("/customers/:id/corridors", handler.customerCorridors)
+}
+
+// SetupCorridorAdminRoutes registers corridor management on the admin group. The
+// corridor-refresh worker carries changes over to every customer.
+func SetupCorridorAdminRoutes(admin *echo.Group, s domain.CorridorService) {
+	handler := &CorridorHandler{
+		Service:  s,
+		Response: domain.NewResponse(),
+	}
+	corridors := admin.Group("/corridors")
+	corridors.GET("", handler.list)
+	corridors.POST("", handler.create)
+	corridors.PUT("/:id", handler.update)
+}
+
+func (h *CorridorHandler) customerCorridors(c echo.Context) error {
+	clientId := c.Get("client_id").(string)
+	eligibility, err := h.Service.Eligibility(c.Request().Context(), clientId, c.Param("id"))
+	if err != nil {
+		return h.handleError(c, err)
+	}
+	return h.Response.SuccessOk(c, eligibility)
+}
+
+func (h *CorridorHandler) list(c echo.Context) error {
+	corridors, err := h.Service.List(c.Request().Context())
+	if err != nil {
+		return h.Response.InternalServerError(c, err)
+	}
+	return h.Response.SuccessOk(c, corridors)
+}
+
+func (h *CorridorHandler) create(c echo.Context) error {
+	var req dto.CorridorReq
+	if err := c.Bind(&req); err != nil {
+		return h.Response.InvalidData(c, nil)
+	}
+	v := req.Validate()
+	if v.Status {
+		return h.Response.ValidationFail(c, v, nil)
+	}
+
+	corridor, err := h.Service.Create(c.Request().Context(), &req)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+	return h.Response.SuccessOk(c, corridor)
+}
+
+func (h *CorridorHandler) update(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return h.Response.InvalidData(c, utils.StringPtr("Invalid corridor id"))
+	}
+	var req dto.CorridorReq
+	if err := c.Bind(&req); err != nil {
+		return h.Response.InvalidData(c, nil)
+	}
+	v := req.Validate()
+	if v.Status {
+		return h.Response.ValidationFail(c, v, nil)
+	}
+
+	corridor, err := h.Service.Update(c.Request().Context(), id, &req)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+	return h.Response.SuccessOk(c, corridor)
+}
+
+func (h *CorridorHandler) handleError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, utils.ErrNotFound):
+		return h.Response.NotFound(c, utils.StringPtr("Not found"))
+	case errors.Is(err, utils.ErrConflict):
+		return h.Response.ConflictError(c, utils.StringPtr(err.Error()), nil)
+	default:
+		return h.Response.InternalServerError(c, err)
+	}
+}
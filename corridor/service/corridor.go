@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fin-auth/domain"
+	"fin-auth/dto"
+	"fin-auth/models"
+	"fin-auth/utils"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const refreshBatchSize = 200
+
+type Corridor struct {
+	CorridorRepository domain.CorridorRepository
+	CustomerRepository domain.CustomerRepository
+
+	mu                sync.Mutex
+	lastRefreshChange time.Time
+}
+
+func NewCorridorService(repo domain.CorridorRepository, customerRepo domain.CustomerRepository) *Corridor {
+	return &Corridor{
+		CorridorRepository: repo,
+		CustomerRepository: customerRepo,
+	}
+}
+
+func (s *Corridor) List(ctx context.Context) ([]*models.Corridor, error) {
+	return s.CorridorRepository.List(ctx, false)
+}
+
+func (s *Corridor) Create(ctx context.Context, req *dto.CorridorReq) (*models.Corridor, error) {
+	corridor := &models.Corridor{}
+	req.Apply(corridor)
+	if err := s.CorridorRepository.Create(ctx, corridor); err != nil {
+		return nil, wrapRouteConflict(corridor, err)
+	}
+	return corridor, nil
+}
+
+func (s *Corridor) Update(ctx context.Context, id int, req *dto.CorridorReq) (*models.Corridor, error) {
+	corridor, err := s.CorridorRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	req.Apply(corridor)
+	if err := s.CorridorRepository.Update(ctx, corridor); err != nil {
+		return nil, wrapRouteConflict(corridor, err)
+	}
+	return corridor, nil
+}
+
+func (s *Corridor) Eligibility(ctx context.Context, clientId, customerId string) ([]*dto.CorridorEligibility, error) {
+	cached, err := s.CustomerRepository.FindByID(ctx, clientId, customerId)
+	if err != nil {
+		return nil, err
+	}
+	corridors, err := s.CorridorRepository.List(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*dto.CorridorEligibility, 0, len(corridors))
+	for _, corridor := range corridors {
+		reasons := evaluate(corridor, cached.Customer, cached.Person)
+		result = append(result, &dto.CorridorEligibility{
+			Corridor: corridor,
+			Eligible: len(reasons) == 0,
+			Reasons:  reasons,
+		})
+	}
+	return result, nil
+}
+
+// Refresh stores the ids of the corridors the customer qualifies for and enables USD
+// when one of them moves US dollars.
+func (s *Corridor) Refresh(ctx context.Context, clientId, customerId string) error {
+	eligibility, err := s.Eligibility(ctx, clientId, customerId)
+	if err != nil {
+		return err
+	}
+
+	ids := pq.Int64Array{}
+	usd := false
+	for _, e := range eligibility {
+		if !e.Eligible {
+			continue
+		}
+		ids = append(ids, int64(e.Corridor.ID))
+		if e.Corridor.SourceCurrency == utils.CURRENCY_USD || e.Corridor.DestinationCurrency == utils.CURRENCY_USD {
+			usd = true
+		}
+	}
+
+	customer := &models.Customer{
+		ID:                    customerId,
+		ClientID:              &clientId,
+		AvailableCorridorsIDs: utils.UniqueInt64Array(ids),
+		USDEnable:             &usd,
+	}
+	if customer.AvailableCorridorsIDs == nil {
+		customer.AvailableCorridorsIDs = pq.Int64Array{}
+	}
+	return s.CustomerRepository.UpdateCorridors(ctx, customer)
+}
+
+func (s *Corridor) RefreshAll(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Taken before the run, so a corridor changed while it runs is refreshed again.
+	latest, err := s.CorridorRepository.LatestChange(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if !latest.After(s.lastRefreshChange) {
+		return 0, nil
+	}
+	refreshed, err := s.refreshAll(ctx)
+	if err != nil {
+		return refreshed, err
+	}
+	s.lastRefreshChange = latest
+	return refreshed, nil
+}
+
+func (s *Corridor) refreshAll(ctx context.Context) (int, error) {
+	refreshed := 0
+	afterId := ""
+	for {
+		customers, err := s.CorridorRepository.ListCustomersAfter(ctx, afterId, refreshBatchSize)
+		if err != nil {
+			return refreshed, err
+		}
+		if len(customers) == 0 {
+			return refreshed, nil
+		}
+		for _, customer := range customers {
+			afterId = customer.ID
+			if err := s.Refresh(ctx, utils.SafeString(customer.ClientID), customer.ID); err != nil {
+				log.Printf("[corridor] failed to refresh corridors of customer %s: %v", customer.ID, err)
+				continue
+			}
+			refreshed++
+		}
+	}
+}
+
+// evaluate returns why the customer does not qualify for the corridor, nothing when
+// it does.
+func evaluate(corridor *models.Corridor, customer *models.Customer, person *models.Person) []string {
+	reasons := []string{}
+	if customer.ErasedAt != nil {
+		return append(reasons, utils.CORRIDOR_REASON_ERASED)
+	}
+	if utils.SafeString(customer.KYCStatus) != utils.KYC_STATUS_APPROVED {
+		reasons = append(reasons, utils.CORRIDOR_REASON_KYC_NOT_APPROVED)
+	}
+
+	residence := ""
+	if person != nil {
+		residence = utils.SafeString(person.CountryOfResidence)
+	}
+	eligibleResidences := []string(corridor.EligibleResidences)
+	if len(eligibleResidences) == 0 {
+		eligibleResidences = []string{corridor.SourceCountry}
+	}
+	if !utils.StringInSlice(residence, eligibleResidences) {
+		reasons = append(reasons, utils.CORRIDOR_REASON_RESIDENCE)
+	}
+
+	switch tier := utils.SafeString(customer.RiskTier); {
+	case tier == "":
+		reasons = append(reasons, utils.CORRIDOR_REASON_RISK_NOT_ASSESSED)
+	case riskRank(tier) > riskRank(corridor.MaxRiskTier):
+		reasons = append(reasons, utils.CORRIDOR_REASON_RISK_TIER_TOO_HIGH)
+	}
+	return reasons
+}
+
+// riskRank orders risk tiers; unknown tiers rank above HIGH.
+func riskRank(tier string) int {
+	for i, t := range utils.RiskTiers {
+		if t == tier {
+			return i
+		}
+	}
+	return len(utils.RiskTiers)
+}
+
+func wrapRouteConflict(corridor *models.Corridor, err error) error {
+	if errors.Is(err, utils.ErrConflict) {
+		return fmt.Errorf("corridor %s-%s %s/%s already exists: %w", corridor.SourceCountry, corridor.DestinationCountry, corridor.SourceCurrency, corridor.DestinationCurrency, utils.ErrConflict)
+	}
+	return err
+}
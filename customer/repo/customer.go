@@ -2,22 +2,27 @@ package repo
 
 import (
 	"context"
+	"errors"
 	"fin-auth/cache"
 	"fin-auth/domain"
 	"fin-auth/models"
+	"fin-auth/utils"
 
+	"github.com/lib/pq"
 	"gorm.io/gorm"
 )
 
 type Customer struct {
-	db    *gorm.DB
-	cache *cache.RedisCache
+	db     *gorm.DB
+	cache  *cache.RedisCache
+	outbox domain.OutboxRepository
 }
 
-func NewCustomerRepository(db *gorm.DB, cache *cache.RedisCache) *Customer {
+func NewCustomerRepository(db *gorm.DB, cache *cache.RedisCache, outbox domain.OutboxRepository) *Customer {
 	return &Customer{
-		db:    db,
-		cache: cache,
+		db:     db,
+		cache:  cache,
+		outbox: outbox,
 	}
 }
 
@@ -36,23 +41,74 @@ func (o *Customer) Create(ctx context.Context, customer *models.Customer) (*mode
 	return customer, nil
 }
 
-func (o *Customer) CreateIndividualCustomer(ctx context.Context, customer *models.Customer, person *models.Person, address *models.Address, req interface{}) (*models.Customer, *models.Person, *models.Address, error) {
-	err := o.db.Transaction(func(tx *gorm.DB) error {
+func (o *Customer) CreateIndividualCustomer(ctx context.Context, customer *models.Customer, person *models.Person, address *models.Address, onboarding *domain.Onboarding, req interface{}) (*models.Customer, *models.Person, *models.Address, error) {
+	err := o.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if onboarding.Guard != nil {
+			if err := onboarding.Guard(tx); err != nil {
+				return err
+			}
+		}
+
 		if err := tx.Create(customer).Error; err != nil {
 			return err
 		}
 		person.CustomerID = &customer.ID
 		address.CustomerID = &customer.ID
 
+		if err := tx.Create(address).Error; err != nil {
+			return err
+		}
+
+		person.AddressID = &address.ID
 		if err := tx.Create(person).Error; err != nil {
 			return err
 		}
 
-		if err := tx.Create(address).Error; err != nil {
+		acceptance := onboarding.Acceptance
+		acceptance.CustomerID = customer.ID
+		if err := tx.Create(acceptance).Error; err != nil {
 			return err
 		}
 
-		return nil
+		if len(onboarding.Matches) > 0 {
+			for _, match := range onboarding.Matches {
+				match.CustomerID = customer.ID
+				match.PersonID = person.ID
+			}
+			if err := tx.Create(&onboarding.Matches).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(onboarding.ReviewFlags) > 0 {
+			for _, flag := range onboarding.ReviewFlags {
+				flag.CustomerID = customer.ID
+			}
+			if err := tx.Create(&onboarding.ReviewFlags).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(onboarding.Candidates) > 0 {
+			for _, candidate := range onboarding.Candidates {
+				candidate.CustomerID = customer.ID
+			}
+			if err := tx.Create(&onboarding.Candidates).Error; err != nil {
+				return err
+			}
+		}
+
+		// Events are copied to webhooks and the stream, so they carry no personal data.
+		event := map[string]interface{}{
+			"customer_id":       customer.ID,
+			"person_id":         person.ID,
+			"customer_type":     customer.CustomerType,
+			"customer_status":   customer.CustomerStatus,
+			"kyc_status":        customer.KYCStatus,
+			"kyc_status_reason": customer.KYCStatusReason,
+			"created_at":        customer.CreatedAt,
+		}
+		return o.outbox.Add(ctx, tx, utils.AGGREGATE_CUSTOMER, customer.ID, customer.ClientID, utils.WEBHOOK_EVENT_CUSTOMER_CREATED, event)
 	})
 
 	if err != nil {
@@ -84,12 +140,12 @@ func (o *Customer) ListByClientID(ctx context.Context, clientId string) ([]*doma
 		cached := &domain.CachedCustomer{Customer: &customers[i]}
 
 		var person models.Person
-		if err := o.db.Where("customer_id = ?", customers[i].ID).First(&person).Error; err == nil {
+		if err := o.db.Where("customer_id = ? AND role = ?", customers[i].ID, utils.PERSON_ROLE_PRIMARY).First(&person).Error; err == nil {
 			cached.Person = &person
 		}
 
 		var addr models.Address
-		if err := o.db.Where("customer_id = ?", customers[i].ID).First(&addr).Error; err == nil {
+		if err := primaryResidentialAddress(o.db, customers[i].ID).First(&addr).Error; err == nil {
 			cached.Address = &addr
 		}
 
@@ -98,3 +154,128 @@ func (o *Customer) ListByClientID(ctx context.Context, clientId string) ([]*doma
 
 	return result, nil
 }
+
+func (o *Customer) FindByID(ctx context.Context, clientId, customerId string) (*domain.CachedCustomer, error) {
+	var customer models.Customer
+	err := o.db.Where("id = ? AND client_id = ?", customerId, clientId).First(&customer).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, utils.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cached := &domain.CachedCustomer{Customer: &customer}
+
+	var person models.Person
+	if err := o.db.Where("customer_id = ? AND role = ?", customer.ID, utils.PERSON_ROLE_PRIMARY).First(&person).Error; err == nil {
+		cached.Person = &person
+	}
+
+	var addr models.Address
+	if err := primaryResidentialAddress(o.db, customer.ID).First(&addr).Error; err == nil {
+		cached.Address = &addr
+	}
+
+	return cached, nil
+}
+
+func (o *Customer) FindByKYCApplicantID(ctx context.Context, provider, applicantId string) (*models.Customer, error) {
+	var customer models.Customer
+	err := o.db.Where("kyc_provider = ? AND kyc_applicant_id = ?", provider, applicantId).First(&customer).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, utils.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &customer, nil
+}
+
+func (o *Customer) FindByBridgeCustomerID(ctx context.Context, bridgeCustomerId string) (*models.Customer, error) {
+	var customer models.Customer
+	err := o.db.WithContext(ctx).Where("bridge_customer_id = ?", bridgeCustomerId).First(&customer).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, utils.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &customer, nil
+}
+
+func (o *Customer) UpdateKYC(ctx context.Context, customer *models.Customer) error {
+	columns := []string{"kyc_status", "bridge_kyc_status", "kyc_provider", "kyc_applicant_id", "kyc_status_reason", "kyc_updated_at"}
+	// Corridors need an approved customer; they are only granted again by a refresh.
+	if utils.SafeString(customer.KYCStatus) != utils.KYC_STATUS_APPROVED {
+		customer.AvailableCorridorsIDs = pq.Int64Array{}
+		usd := false
+		customer.USDEnable = &usd
+		columns = append(columns, "available_corridors_ids", "usd_enable")
+	}
+
+	err := o.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(customer).
+			Select(columns).
+			Updates(customer).Error
+		if err != nil {
+			return err
+		}
+
+		event := map[string]interface{}{
+			"customer_id":       customer.ID,
+			"kyc_status":        customer.KYCStatus,
+			"bridge_kyc_status": customer.BridgeKYCStatus,
+			"kyc_status_reason": customer.KYCStatusReason,
+			"kyc_updated_at":    customer.KYCUpdatedAt,
+		}
+		return o.outbox.Add(ctx, tx, utils.AGGREGATE_CUSTOMER, customer.ID, customer.ClientID, utils.WEBHOOK_EVENT_CUSTOMER_KYC_STATUS_CHANGED, event)
+	})
+	if err != nil {
+		return err
+	}
+
+	if o.cache != nil && customer.ClientID != nil {
+		o.cache.InvalidateCustomer(ctx, *customer.ClientID, customer.ID)
+		o.cache.InvalidateCustomerList(ctx, *customer.ClientID)
+	}
+
+	return nil
+}
+
+func (o *Customer) UpdateRisk(ctx context.Context, customer *models.Customer) error {
+	err := o.db.Model(customer).
+		Select("risk_score", "risk_tier", "risk_factors", "risk_assessed_at", "verification_type").
+		Updates(customer).Error
+	if err != nil {
+		return err
+	}
+
+	if o.cache != nil && customer.ClientID != nil {
+		o.cache.InvalidateCustomer(ctx, *customer.ClientID, customer.ID)
+		o.cache.InvalidateCustomerList(ctx, *customer.ClientID)
+	}
+
+	return nil
+}
+
+func (o *Customer) UpdateCorridors(ctx context.Context, customer *models.Customer) error {
+	err := o.db.Model(customer).
+		Select("available_corridors_ids", "usd_enable").
+		Updates(customer).Error
+	if err != nil {
+		return err
+	}
+
+	if o.cache != nil && customer.ClientID != nil {
+		o.cache.InvalidateCustomer(ctx, *customer.ClientID, customer.ID)
+		o.cache.InvalidateCustomerList(ctx, *customer.ClientID)
+	}
+
+	return nil
+}
+
+// primaryResidentialAddress scopes a query to the address shown with the customer.
+func primaryResidentialAddress(db *gorm.DB, customerId string) *gorm.DB {
+	return db.Where("customer_id = ? AND type = ? AND is_primary AND valid_to IS NULL", customerId, utils.ADDRESS_TYPE_RESIDENTIAL)
+}
@@ -1,6 +1,7 @@
 package rest
 
 import (
+	"errors"
 	"fin-auth/domain"
 	"fin-auth/dto"
 	"fin-auth/models"
@@ -10,14 +11,16 @@ import (
 )
 
 type CustomerHandler struct {
-	Service  domain.CustomerService
-	Response domain.Response
+	Service   domain.CustomerService
+	PIIAccess domain.PIIAccessService
+	Response  domain.Response
 }
 
-func SetupCustomerRoutes(api *echo.Group, s domain.CustomerService) {
+func SetupCustomerRoutes(api *echo.Group, s domain.CustomerService, piiAccess domain.PIIAccessService) {
 	handler := &CustomerHandler{
-		Service:  s,
-		Response: domain.NewResponse(),
+		Service:   s,
+		PIIAccess: piiAccess,
+		Response:  domain.NewResponse(),
 	}
 	customer := api.Group("/customers")
 	customer.POST("/individual", handler.createIndividualCustomer)
@@ -31,9 +34,9 @@ func (h *CustomerHandler) createIndividualCustomer(c echo.Context) error {
 		return h.Response.InvalidData(c, nil)
 	}
 
-	errMessage := req.ValidateRequest()
-	if errMessage != nil {
-		return h.Response.InvalidData(c, errMessage)
+	v := req.Validate()
+	if v.Status {
+		return h.Response.ValidationFail(c, v, nil)
 	}
 	var basicInfo = req.BasicInfo
 	var address = req.Address
@@ -42,8 +45,23 @@ func (h *CustomerHandler) createIndividualCustomer(c echo.Context) error {
 	var addressModel *models.Address
 	addressModel = req.GetAddress(&address)
 
-	res, _, _, err := h.Service.CreateIndividualCustomer(c.Request().Context(), customer, person, addressModel, &req)
+	var acceptance = req.GetTOSAcceptance(c.Get("client_id").(string), c.RealIP(), c.Request().UserAgent())
 
+	res, _, _, err := h.Service.CreateIndividualCustomer(c.Request().Context(), customer, person, addressModel, acceptance, &req)
+
+	var fieldErrs utils.FieldErrors
+	if errors.As(err, &fieldErrs) {
+		v := utils.NewValidationError()
+		v.Response = utils.ErrorResponse{}
+		for path, codes := range fieldErrs {
+			v.Response.Add(path, codes)
+		}
+		return h.Response.ValidationFail(c, v, nil)
+	}
+	var duplicateErr *utils.DuplicateCustomerError
+	if errors.As(err, &duplicateErr) {
+		return h.Response.ConflictError(c, utils.StringPtr("Customer already exists"), map[string]interface{}{"fields": duplicateErr.Fields})
+	}
 	if err != nil {
 		return h.Response.InternalServerError(c, err)
 	}
@@ -51,10 +69,17 @@ func (h *CustomerHandler) createIndividualCustomer(c echo.Context) error {
 }
 
 func (h *CustomerHandler) listCustomers(c echo.Context) error {
+	unmasked := domain.Unmasked(c)
+
 	clientId := c.Get("client_id").(string)
 	customers, err := h.Service.GetCustomersByClientID(c.Request().Context(), clientId)
 	if err != nil {
 		return h.Response.InternalServerError(c, err)
 	}
-	return h.Response.SuccessOk(c, customers)
+
+	res, err := h.PIIAccess.PresentCustomers(c.Request().Context(), domain.NewPIIAccess(c), customers, unmasked)
+	if err != nil {
+		return h.Response.InternalServerError(c, err)
+	}
+	return h.Response.SuccessOk(c, res)
 }
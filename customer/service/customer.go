@@ -5,26 +5,45 @@ import (
 	"errors"
 	"fin-auth/cache"
 	"fin-auth/domain"
+	"fin-auth/dto"
 	"fin-auth/models"
+	"fin-auth/utils"
+	"fmt"
+
+	"gorm.io/gorm"
 )
 
 type Customer struct {
-	CustomerRepository domain.CustomerRepository
-	PersonService      domain.PersonService
-	AddressService     domain.AddressService
-	Cache              *cache.RedisCache
+	CustomerRepository  domain.CustomerRepository
+	PersonService       domain.PersonService
+	AddressService      domain.AddressService
+	JurisdictionService domain.JurisdictionService
+	KYCService          domain.KYCService
+	ScreeningService    domain.ScreeningService
+	RiskService         domain.RiskService
+	ReferenceService    domain.ReferenceService
+	DedupeService       domain.DedupeService
+	TOSService          domain.TOSService
+	Cache               *cache.RedisCache
 }
 
-func NewCustomerService(repo domain.CustomerRepository, personService domain.PersonService, addressService domain.AddressService, cache *cache.RedisCache) *Customer {
+func NewCustomerService(repo domain.CustomerRepository, personService domain.PersonService, addressService domain.AddressService, jurisdictionService domain.JurisdictionService, kycService domain.KYCService, screeningService domain.ScreeningService, riskService domain.RiskService, referenceService domain.ReferenceService, dedupeService domain.DedupeService, tosService domain.TOSService, cache *cache.RedisCache) *Customer {
 	return &Customer{
-		CustomerRepository: repo,
-		PersonService:      personService,
-		AddressService:     addressService,
-		Cache:              cache,
+		CustomerRepository:  repo,
+		PersonService:       personService,
+		AddressService:      addressService,
+		JurisdictionService: jurisdictionService,
+		KYCService:          kycService,
+		ScreeningService:    screeningService,
+		RiskService:         riskService,
+		ReferenceService:    referenceService,
+		DedupeService:       dedupeService,
+		TOSService:          tosService,
+		Cache:               cache,
 	}
 }
 
-func (s *Customer) CreateIndividualCustomer(ctx context.Context, customer *models.Customer, person *models.Person, address *models.Address, req interface{}) (*models.Customer, *models.Person, *models.Address, error) {
+func (s *Customer) CreateIndividualCustomer(ctx context.Context, customer *models.Customer, person *models.Person, address *models.Address, acceptance *models.TOSAcceptance, req interface{}) (*models.Customer, *models.Person, *models.Address, error) {
 	if customer == nil {
 		return nil, nil, nil, errors.New("Invalid customer data")
 	}
@@ -34,11 +53,170 @@ func (s *Customer) CreateIndividualCustomer(ctx context.Context, customer *model
 	if address == nil {
 		return nil, nil, nil, errors.New("Invalid address data")
 	}
-	createdCustomer, createdPerson, createdAddress, err := s.CustomerRepository.CreateIndividualCustomer(ctx, customer, person, address, req)
+	if acceptance == nil {
+		return nil, nil, nil, errors.New("Invalid terms of service acceptance")
+	}
+
+	policyId, err := s.TOSService.OnboardingPolicy(ctx, acceptance.PolicyID)
 	if err != nil {
 		return nil, nil, nil, err
 	}
-	return createdCustomer, createdPerson, createdAddress, nil
+	acceptance.PolicyID = policyId
+	customer.TOSPolicies = &policyId
+
+	if errs, err := s.resolveFinancialProfile(ctx, person); err != nil {
+		return nil, nil, nil, err
+	} else if len(errs) > 0 {
+		return nil, nil, nil, errs
+	}
+
+	hits, err := s.JurisdictionService.Evaluate(ctx, utils.SafeString(customer.ClientID), onboardingTargets(person, address))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var controlled []dto.JurisdictionHit
+	prohibited := utils.FieldErrors{}
+	for _, hit := range hits {
+		switch {
+		case hit.Category == utils.JURISDICTION_CONTROLLED:
+			controlled = append(controlled, hit)
+		case hit.Subdivision != nil:
+			prohibited[hit.Field] = append(prohibited[hit.Field], utils.RestrictedSubdivisionCode())
+		default:
+			prohibited[hit.Field] = append(prohibited[hit.Field], utils.RestrictedCountryCode())
+		}
+	}
+	if len(prohibited) > 0 {
+		return nil, nil, nil, prohibited
+	}
+
+	onboarding := &domain.Onboarding{Acceptance: acceptance}
+	// Controlled jurisdictions may onboard, but only through manual review.
+	if len(controlled) > 0 {
+		flag, err := dto.NewReviewFlag("", utils.REVIEW_SOURCE_JURISDICTION, utils.ControlledJurisdictionCode(), map[string]interface{}{"hits": controlled})
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		onboarding.ReviewFlags = append(onboarding.ReviewFlags, flag)
+	}
+
+	// Without a watchlist the customer can't be screened, and isn't created.
+	matches, sanctionsFlag, err := s.ScreeningService.Prepare(ctx, person)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to screen against the watchlist: %w", err)
+	}
+	if sanctionsFlag != nil {
+		onboarding.Matches = matches
+		onboarding.ReviewFlags = append(onboarding.ReviewFlags, sanctionsFlag)
+	}
+
+	s.RiskService.Apply(customer, person)
+
+	// Duplicates are checked inside the create transaction, under a lock on the
+	// person's values, so a concurrent onboarding with the same values can't slip past
+	// a reject rule.
+	onboarding.Guard = func(tx *gorm.DB) error {
+		duplicates, err := s.DedupeService.CheckLocked(ctx, tx, utils.SafeString(customer.ClientID), person)
+		if err != nil {
+			return err
+		}
+		if fields := rejectedFields(duplicates); len(fields) > 0 {
+			return &utils.DuplicateCustomerError{Fields: fields}
+		}
+		candidates, flag, err := s.DedupeService.Candidates(customer, duplicates)
+		if err != nil {
+			return err
+		}
+		flags := onboarding.ReviewFlags
+		if flag != nil {
+			flags = append(flags, flag)
+		}
+		onboarding.Candidates = candidates
+		onboarding.ReviewFlags = flags
+		startReview(customer, flags)
+		return nil
+	}
+
+	return s.CustomerRepository.CreateIndividualCustomer(ctx, customer, person, address, onboarding, req)
+}
+
+// resolveFinancialProfile checks the financial profile ids against the reference
+// catalogs and stores the occupation and purpose codes on the person.
+func (s *Customer) resolveFinancialProfile(ctx context.Context, person *models.Person) (utils.FieldErrors, error) {
+	errs := utils.FieldErrors{}
+	lookup := func(catalog string, id *int, invalidCode, field string) (*models.ReferenceItem, error) {
+		if id == nil {
+			return nil, nil
+		}
+		item, err := s.ReferenceService.Lookup(ctx, catalog, *id)
+		if errors.Is(err, utils.ErrNotFound) {
+			errs[field] = append(errs[field], invalidCode)
+			return nil, nil
+		}
+		return item, err
+	}
+
+	occupation, err := lookup(utils.REFERENCE_OCCUPATIONS, person.OccupationID, utils.InvalidOccupationId(), "financial_profile.occupation_id")
+	if err != nil {
+		return nil, err
+	}
+	sourceOfFunds, err := lookup(utils.REFERENCE_SOURCES_OF_FUNDS, person.SourceOfFundsID, utils.InvalidSourceOfFundId(), "financial_profile.source_of_fund_id")
+	if err != nil {
+		return nil, err
+	}
+	purpose, err := lookup(utils.REFERENCE_PURPOSES, person.PurposeID, utils.InvalidPurposeId(), "financial_profile.purpose_id")
+	if err != nil {
+		return nil, err
+	}
+
+	if occupation != nil {
+		person.Occupation = &occupation.Code
+	}
+	if sourceOfFunds != nil {
+		person.SourceOfFunds = &sourceOfFunds.Code
+	}
+	if purpose != nil {
+		person.Purpose = &purpose.Code
+	}
+	return errs, nil
+}
+
+// startReview creates a flagged customer in MANUAL_REVIEW, with the first flag's code
+// as the reason, instead of moving it there after creation.
+func startReview(customer *models.Customer, flags []*models.ReviewFlag) {
+	if len(flags) == 0 {
+		return
+	}
+	now := utils.CurrentTime()
+	customer.KYCStatus = utils.StringPtr(utils.KYC_STATUS_MANUAL_REVIEW)
+	customer.KYCStatusReason = utils.StringPtr(flags[0].Code)
+	customer.KYCUpdatedAt = &now
+}
+
+// rejectedFields returns the fields of duplicate matches under a reject rule.
+func rejectedFields(matches []dto.DuplicateMatch) []string {
+	var fields []string
+	for _, match := range matches {
+		if match.Action == utils.DEDUPE_ACTION_REJECT && !utils.InArrayString(match.Field, fields) {
+			fields = append(fields, match.Field)
+		}
+	}
+	return fields
+}
+
+// onboardingTargets lists the nationality, residence and address jurisdictions checked
+// against the partner's restrictions.
+func onboardingTargets(person *models.Person, address *models.Address) []dto.JurisdictionTarget {
+	return []dto.JurisdictionTarget{
+		{Field: "basic_info.nationality", Country: utils.SafeString(person.Nationality)},
+		{Field: "basic_info.country_of_residence", Country: utils.SafeString(person.CountryOfResidence)},
+		{
+			Field:            "address.country",
+			SubdivisionField: "address.state",
+			Country:          utils.SafeString(address.Country),
+			Subdivision:      utils.SafeString(address.StateCode),
+		},
+	}
 }
 
 func (s *Customer) GetCustomersByClientID(ctx context.Context, clientId string) ([]*domain.CachedCustomer, error) {
@@ -0,0 +1,91 @@
+package database
+
+import (
+	"fin-auth/utils"
+	"fmt"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// BackfillAddresses brings addresses stored before they had types and history in
+// line: the latest current address of each customer becomes its primary, validity
+// starts at creation and persons point at their primary residential address. It is
+// safe to run on every migration.
+func BackfillAddresses(db *gorm.DB) error {
+	res := db.Exec(`
+		UPDATE addresses a SET is_primary = true
+		WHERE a.id IN (SELECT DISTINCT ON (customer_id) id FROM addresses WHERE valid_to IS NULL ORDER BY customer_id, id DESC)
+		AND NOT EXISTS (
+			SELECT 1 FROM addresses p
+			WHERE p.customer_id = a.customer_id AND p.type = a.type AND p.is_primary AND p.valid_to IS NULL
+		)`)
+	if res.Error != nil {
+		return res.Error
+	}
+	primaries := res.RowsAffected
+
+	// valid_from was filled with the migration time when the column was added.
+	if err := db.Exec(`UPDATE addresses SET valid_from = created_at WHERE valid_from > created_at`).Error; err != nil {
+		return err
+	}
+
+	res = db.Exec(`
+		UPDATE persons p SET address_id = a.id
+		FROM addresses a
+		WHERE a.customer_id = p.customer_id AND a.type = ? AND a.is_primary AND a.valid_to IS NULL
+		AND p.role = ? AND p.address_id IS DISTINCT FROM a.id`, utils.ADDRESS_TYPE_RESIDENTIAL, utils.PERSON_ROLE_PRIMARY)
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if primaries > 0 || res.RowsAffected > 0 {
+		log.Printf("Backfilled %d primary addresses and %d person address links", primaries, res.RowsAffected)
+	}
+	return nil
+}
+
+// BackfillFinancialProfileCodes copies the occupation, source of funds and purpose codes
+// onto persons from the ids they reference. Risk rules match on the codes.
+func BackfillFinancialProfileCodes(db *gorm.DB) error {
+	catalogs := []struct {
+		table, idColumn, codeColumn string
+	}{
+		{utils.REFERENCE_OCCUPATIONS, "occupation_id", "occupation"},
+		{utils.REFERENCE_SOURCES_OF_FUNDS, "source_of_funds_id", "source_of_funds"},
+		{utils.REFERENCE_PURPOSES, "purpose_id", "purpose"},
+	}
+
+	var total int64
+	for _, catalog := range catalogs {
+		res := db.Exec(fmt.Sprintf(`
+			UPDATE persons p SET %[3]s = r.code
+			FROM %[1]s r
+			WHERE r.id = p.%[2]s AND p.%[3]s IS DISTINCT FROM r.code`, catalog.table, catalog.idColumn, catalog.codeColumn))
+		if res.Error != nil {
+			return res.Error
+		}
+		total += res.RowsAffected
+	}
+	if total > 0 {
+		log.Printf("Backfilled %d financial profile codes", total)
+	}
+	return nil
+}
+
+// BackfillTOSPolicies clears the policy ids customers were given before policies were
+// stored, which refer to nothing. Those customers show up as having to accept the
+// current version.
+func BackfillTOSPolicies(db *gorm.DB) error {
+	res := db.Exec(`
+		UPDATE customers c SET tos_policies = NULL
+		WHERE c.tos_policies IS NOT NULL
+		AND NOT EXISTS (SELECT 1 FROM tos_policies p WHERE p.id = c.tos_policies)`)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected > 0 {
+		log.Printf("Cleared %d unknown terms of service policy ids", res.RowsAffected)
+	}
+	return nil
+}
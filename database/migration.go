@@ -18,6 +18,25 @@ func AutoMigrate(db *gorm.DB) error {
 		&models.Customer{},
 		&models.Person{},
 		&models.Address{},
+		&models.KYCWebhook{},
+		&models.WebhookSubscription{},
+		&models.WebhookDelivery{},
+		&models.OutboxEvent{},
+		&models.Document{},
+		&models.ReviewFlag{},
+		&models.JurisdictionRestriction{},
+		&models.ScreeningMatch{},
+		&models.Occupation{},
+		&models.SourceOfFunds{},
+		&models.Purpose{},
+		&models.DuplicateCandidate{},
+		&models.IdempotencyRecord{},
+		&models.PIIAccessLog{},
+		&models.DataSubjectRequest{},
+		&models.RetentionRun{},
+		&models.TOSPolicy{},
+		&models.TOSAcceptance{},
+		&models.Corridor{},
 	)
 
 	if err != nil {
@@ -38,5 +57,24 @@ func GetAllModels() []interface{} {
 		&models.Customer{},
 		&models.Person{},
 		&models.Address{},
+		&models.KYCWebhook{},
+		&models.WebhookSubscription{},
+		&models.WebhookDelivery{},
+		&models.OutboxEvent{},
+		&models.Document{},
+		&models.ReviewFlag{},
+		&models.JurisdictionRestriction{},
+		&models.ScreeningMatch{},
+		&models.Occupation{},
+		&models.SourceOfFunds{},
+		&models.Purpose{},
+		&models.DuplicateCandidate{},
+		&models.IdempotencyRecord{},
+		&models.PIIAccessLog{},
+		&models.DataSubjectRequest{},
+		&models.RetentionRun{},
+		&models.TOSPolicy{},
+		&models.TOSAcceptance{},
+		&models.Corridor{},
 	}
 }
@@ -0,0 +1,200 @@
+package database
+
+import (
+	"encoding/json"
+	"fin-auth/models"
+	"fin-auth/utils"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// bridgeRestrictionSeed is the Bridge restricted-jurisdiction list the service shipped
+// with before restrictions moved to the database.
+var bridgeRestrictionSeed = []struct {
+	country     string
+	subdivision string
+	category    string
+	reason      string
+}{
+	{"PRK", "", utils.JURISDICTION_PROHIBITED, "North Korea"},
+	{"SYR", "", utils.JURISDICTION_PROHIBITED, "Syria"},
+	{"IRN", "", utils.JURISDICTION_PROHIBITED, "Iran, Islamic Republic of"},
+	{"CUB", "", utils.JURISDICTION_PROHIBITED, "Cuba"},
+	{"ERI", "", utils.JURISDICTION_CONTROLLED, "Eritrea"},
+	{"SOM", "", utils.JURISDICTION_CONTROLLED, "Somalia"},
+	{"ZWE", "", utils.JURISDICTION_CONTROLLED, "Zimbabwe"},
+	{"NIC", "", utils.JURISDICTION_CONTROLLED, "Nicaragua"},
+	{"MKD", "", utils.JURISDICTION_CONTROLLED, "North Macedonia"},
+	{"SVN", "", utils.JURISDICTION_CONTROLLED, "Slovenia"},
+	{"ALB", "", utils.JURISDICTION_CONTROLLED, "Albania"},
+	{"MMR", "", utils.JURISDICTION_CONTROLLED, "Myanmar"},
+	{"BGD", "", utils.JURISDICTION_CONTROLLED, "Bangladesh"},
+	{"PAK", "", utils.JURISDICTION_CONTROLLED, "Pakistan"},
+	{"UKR", "", utils.JURISDICTION_CONTROLLED, "Ukraine"},
+	{"NPL", "", utils.JURISDICTION_CONTROLLED, "Nepal"},
+	{"PSE", "", utils.JURISDICTION_CONTROLLED, "Gaza Strip"},
+	{"CHN", "", utils.JURISDICTION_CONTROLLED, "China"},
+	{"QAT", "", utils.JURISDICTION_CONTROLLED, "Qatar"},
+	{"DZA", "", utils.JURISDICTION_CONTROLLED, "Algeria"},
+	{"KEN", "", utils.JURISDICTION_CONTROLLED, "Kenya"},
+	{"XKX", "", utils.JURISDICTION_CONTROLLED, "Kosovo"},
+	{"SSD", "", utils.JURISDICTION_CONTROLLED, "South Sudan"},
+	{"SDN", "", utils.JURISDICTION_CONTROLLED, "Sudan"},
+	{"MAR", "", utils.JURISDICTION_CONTROLLED, "Morocco"},
+	{"MLI", "", utils.JURISDICTION_CONTROLLED, "Mali"},
+	{"YEM", "", utils.JURISDICTION_CONTROLLED, "Yemen"},
+	{"NER", "", utils.JURISDICTION_CONTROLLED, "Niger"},
+	{"USA", "US-NY", utils.JURISDICTION_PROHIBITED, "New York"},
+	{"USA", "US-AK", utils.JURISDICTION_PROHIBITED, "Alaska"},
+}
+
+// SeedJurisdictionRestrictions inserts the default Bridge restrictions when the partner
+// has none yet, so a fresh database enforces the same rules as before.
+func SeedJurisdictionRestrictions(db *gorm.DB) error {
+	var count int64
+	err := db.Model(&models.JurisdictionRestriction{}).
+		Where("partner = ?", utils.JURISDICTION_PARTNER_BRIDGE).
+		Count(&count).Error
+	if err != nil || count > 0 {
+		return err
+	}
+
+	effectiveFrom := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	restrictions := make([]models.JurisdictionRestriction, 0, len(bridgeRestrictionSeed))
+	for _, seed := range bridgeRestrictionSeed {
+		restriction := models.JurisdictionRestriction{
+			Partner:       utils.JURISDICTION_PARTNER_BRIDGE,
+			Country:       seed.country,
+			Category:      seed.category,
+			Reason:        utils.StringPtr(seed.reason),
+			EffectiveFrom: effectiveFrom,
+		}
+		if seed.subdivision != "" {
+			restriction.Subdivision = utils.StringPtr(seed.subdivision)
+		}
+		restrictions = append(restrictions, restriction)
+	}
+
+	if err := db.Create(&restrictions).Error; err != nil {
+		return err
+	}
+	log.Printf("Seeded %d jurisdiction restrictions", len(restrictions))
+	return nil
+}
+
+type referenceSeed struct {
+	code   string
+	labels map[string]string
+}
+
+var occupationSeed = []referenceSeed{
+	{"EMPLOYED_PRIVATE", map[string]string{"en": "Private sector employee", "es": "Empleado del sector privado", "pt": "Funcionário do setor privado", "fr": "Salarié du secteur privé"}},
+	{"EMPLOYED_PUBLIC", map[string]string{"en": "Public sector employee", "es": "Empleado del sector público", "pt": "Funcionário público", "fr": "Agent du secteur public"}},
+	{"SELF_EMPLOYED", map[string]string{"en": "Self-employed", "es": "Trabajador autónomo", "pt": "Autônomo", "fr": "Travailleur indépendant"}},
+	{"BUSINESS_OWNER", map[string]string{"en": "Business owner", "es": "Propietario de empresa", "pt": "Empresário", "fr": "Chef d'entreprise"}},
+	{"STUDENT", map[string]string{"en": "Student", "es": "Estudiante", "pt": "Estudante", "fr": "Étudiant"}},
+	{"RETIRED", map[string]string{"en": "Retired", "es": "Jubilado", "pt": "Aposentado", "fr": "Retraité"}},
+	{"UNEMPLOYED", map[string]string{"en": "Unemployed", "es": "Desempleado", "pt": "Desempregado", "fr": "Sans emploi"}},
+	{"HOMEMAKER", map[string]string{"en": "Homemaker", "es": "Amo/a de casa", "pt": "Do lar", "fr": "Personne au foyer"}},
+	{"HEALTHCARE", map[string]string{"en": "Healthcare professional", "es": "Profesional de la salud", "pt": "Profissional de saúde", "fr": "Professionnel de santé"}},
+	{"LEGAL", map[string]string{"en": "Legal professional", "es": "Profesional del derecho", "pt": "Profissional jurídico", "fr": "Professionnel du droit"}},
+	{"FINANCE", map[string]string{"en": "Finance or accounting professional", "es": "Profesional de finanzas o contabilidad", "pt": "Profissional de finanças ou contabilidade", "fr": "Professionnel de la finance ou de la comptabilité"}},
+	{"TECHNOLOGY", map[string]string{"en": "Technology professional", "es": "Profesional de tecnología", "pt": "Profissional de tecnologia", "fr": "Professionnel des technologies"}},
+	{"CASH_INTENSIVE_BUSINESS", map[string]string{"en": "Cash-intensive business", "es": "Negocio con uso intensivo de efectivo", "pt": "Negócio com uso intensivo de dinheiro", "fr": "Activité à forte intensité d'espèces"}},
+	{"GAMBLING", map[string]string{"en": "Gambling or gaming", "es": "Juegos de azar", "pt": "Jogos de azar", "fr": "Jeux d'argent"}},
+	{"PRECIOUS_METALS_DEALER", map[string]string{"en": "Precious metals or stones dealer", "es": "Comerciante de metales o piedras preciosas", "pt": "Comerciante de metais ou pedras preciosas", "fr": "Négociant en métaux ou pierres précieux"}},
+	{"POLITICAL_OFFICIAL", map[string]string{"en": "Government or political official", "es": "Funcionario gubernamental o político", "pt": "Funcionário governamental ou político", "fr": "Responsable gouvernemental ou politique"}},
+	{"OTHER", map[string]string{"en": "Other", "es": "Otro", "pt": "Outro", "fr": "Autre"}},
+}
+
+var sourceOfFundsSeed = []referenceSeed{
+	{"SALARY", map[string]string{"en": "Salary", "es": "Salario", "pt": "Salário", "fr": "Salaire"}},
+	{"BUSINESS_INCOME", map[string]string{"en": "Business income", "es": "Ingresos empresariales", "pt": "Receita empresarial", "fr": "Revenus d'entreprise"}},
+	{"SAVINGS", map[string]string{"en": "Savings", "es": "Ahorros", "pt": "Poupança", "fr": "Épargne"}},
+	{"INVESTMENTS", map[string]string{"en": "Investment returns", "es": "Rendimientos de inversiones", "pt": "Rendimentos de investimentos", "fr": "Revenus de placements"}},
+	{"PENSION", map[string]string{"en": "Pension", "es": "Pensión", "pt": "Pensão", "fr": "Pension de retraite"}},
+	{"INHERITANCE", map[string]string{"en": "Inheritance", "es": "Herencia", "pt": "Herança", "fr": "Héritage"}},
+	{"GIFT", map[string]string{"en": "Gift", "es": "Donación", "pt": "Doação", "fr": "Don"}},
+	{"SALE_OF_PROPERTY", map[string]string{"en": "Sale of property", "es": "Venta de inmueble", "pt": "Venda de imóvel", "fr": "Vente d'un bien immobilier"}},
+	{"LOAN", map[string]string{"en": "Loan", "es": "Préstamo", "pt": "Empréstimo", "fr": "Prêt"}},
+	{"CRYPTO_ASSETS", map[string]string{"en": "Crypto assets", "es": "Criptoactivos", "pt": "Criptoativos", "fr": "Crypto-actifs"}},
+	{"GAMBLING_WINNINGS", map[string]string{"en": "Gambling winnings", "es": "Ganancias de juegos de azar", "pt": "Ganhos em jogos de azar", "fr": "Gains de jeux"}},
+	{"OTHER", map[string]string{"en": "Other", "es": "Otro", "pt": "Outro", "fr": "Autre"}},
+}
+
+var purposeSeed = []referenceSeed{
+	{"PERSONAL_PAYMENTS", map[string]string{"en": "Personal payments", "es": "Pagos personales", "pt": "Pagamentos pessoais", "fr": "Paiements personnels"}},
+	{"FAMILY_SUPPORT", map[string]string{"en": "Family support", "es": "Ayuda familiar", "pt": "Ajuda familiar", "fr": "Soutien familial"}},
+	{"SAVINGS", map[string]string{"en": "Savings", "es": "Ahorro", "pt": "Poupança", "fr": "Épargne"}},
+	{"INVESTMENT", map[string]string{"en": "Investment", "es": "Inversión", "pt": "Investimento", "fr": "Investissement"}},
+	{"PAYROLL", map[string]string{"en": "Receiving salary", "es": "Cobro de salario", "pt": "Recebimento de salário", "fr": "Réception du salaire"}},
+	{"GOODS_AND_SERVICES", map[string]string{"en": "Purchase of goods and services", "es": "Compra de bienes y servicios", "pt": "Compra de bens e serviços", "fr": "Achat de biens et services"}},
+	{"TUITION", map[string]string{"en": "Education and tuition", "es": "Educación y matrícula", "pt": "Educação e mensalidades", "fr": "Frais de scolarité"}},
+	{"CRYPTO_TRADING", map[string]string{"en": "Crypto trading", "es": "Compraventa de criptoactivos", "pt": "Negociação de criptoativos", "fr": "Négoce de crypto-actifs"}},
+	{"OTHER", map[string]string{"en": "Other", "es": "Otro", "pt": "Outro", "fr": "Autre"}},
+}
+
+// SeedReferenceData fills the occupation, source of funds and purpose catalogs when
+// they are empty. Ids follow the seed order on a fresh database.
+func SeedReferenceData(db *gorm.DB) error {
+	catalogs := []struct {
+		table string
+		seeds []referenceSeed
+	}{
+		{utils.REFERENCE_OCCUPATIONS, occupationSeed},
+		{utils.REFERENCE_SOURCES_OF_FUNDS, sourceOfFundsSeed},
+		{utils.REFERENCE_PURPOSES, purposeSeed},
+	}
+
+	for _, catalog := range catalogs {
+		var count int64
+		if err := db.Table(catalog.table).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			continue
+		}
+
+		items := make([]models.ReferenceItem, 0, len(catalog.seeds))
+		for i, seed := range catalog.seeds {
+			labels, err := json.Marshal(seed.labels)
+			if err != nil {
+				return err
+			}
+			items = append(items, models.ReferenceItem{
+				Code:      seed.code,
+				Labels:    labels,
+				SortOrder: i + 1,
+				Active:    true,
+			})
+		}
+
+		if err := db.Table(catalog.table).Create(&items).Error; err != nil {
+			return err
+		}
+		log.Printf("Seeded %d %s", len(items), catalog.table)
+	}
+	return nil
+}
+
+// SeedTOSPolicy publishes the first terms of service version when none exists. Without
+// a policy customers can't be created, so a missing one is logged.
+func SeedTOSPolicy(db *gorm.DB, policy *models.TOSPolicy) error {
+	var count int64
+	if err := db.Model(&models.TOSPolicy{}).Count(&count).Error; err != nil || count > 0 {
+		return err
+	}
+	if policy == nil {
+		log.Println("No terms of service policy is published: customers can't be created until one is, with POST /api/v1/admin/tos-policies or the tos section of the config")
+		return nil
+	}
+
+	policy.RequiresReacceptance = true
+	if err := db.Create(policy).Error; err != nil {
+		return err
+	}
+	log.Printf("Seeded terms of service policy %s", policy.Version)
+	return nil
+}
@@ -0,0 +1,110 @@
+package repo
+
+import (
+	"context"
+	"fin-auth/cache"
+	"fin-auth/models"
+	"fin-auth/pii"
+	"fin-auth/utils"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	maxDuplicateMatches = 20
+	maxCandidatesListed = 500
+)
+
+type Dedupe struct {
+	db    *gorm.DB
+	cache *cache.RedisCache
+}
+
+func NewDedupeRepository(db *gorm.DB, cache *cache.RedisCache) *Dedupe {
+	return &Dedupe{
+		db:    db,
+		cache: cache,
+	}
+}
+
+func (o *Dedupe) GetDB(tx ...*gorm.DB) *gorm.DB {
+	db := o.db
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db
+}
+
+func (o *Dedupe) FindPersons(ctx context.Context, tx *gorm.DB, field, value, country, clientId string) ([]*models.Person, error) {
+	column, index, err := dedupeIndex(field, value, country)
+	if err != nil || index == "" {
+		return nil, err
+	}
+
+	query := o.GetDB(tx).WithContext(ctx).Preload("Customer").
+		Joins("JOIN customers ON customers.id = persons.customer_id").
+		Where(column+" = ? AND persons.role = ?", index, utils.PERSON_ROLE_PRIMARY)
+	if clientId != "" {
+		query = query.Where("customers.client_id = ?", clientId)
+	}
+
+	var persons []*models.Person
+	if err := query.Order("persons.id").Limit(maxDuplicateMatches).Find(&persons).Error; err != nil {
+		return nil, err
+	}
+	return persons, nil
+}
+
+// Lock takes a transaction-scoped advisory lock on a field value, so that concurrent
+// onboardings with the same value check for each other one at a time.
+func (o *Dedupe) Lock(ctx context.Context, tx *gorm.DB, field, value, country string) error {
+	_, index, err := dedupeIndex(field, value, country)
+	if err != nil || index == "" {
+		return err
+	}
+	return tx.WithContext(ctx).Exec("SELECT pg_advisory_xact_lock(hashtextextended(?, 0))", "dedupe:"+field+":"+index).Error
+}
+
+func (o *Dedupe) CreateCandidates(ctx context.Context, candidates []*models.DuplicateCandidate) error {
+	if len(candidates) == 0 {
+		return nil
+	}
+	return o.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&candidates).Error
+}
+
+// ListCandidates returns candidates in both directions: where the customer is the new
+// one and where it was matched by a later customer.
+func (o *Dedupe) ListCandidates(ctx context.Context, customerId string) ([]*models.DuplicateCandidate, error) {
+	var candidates []*models.DuplicateCandidate
+	query := o.db.Model(&models.DuplicateCandidate{})
+	if customerId != "" {
+		query = query.Where("customer_id = ? OR matched_customer_id = ?", customerId, customerId)
+	}
+	if err := query.Order("id DESC").Limit(maxCandidatesListed).Find(&candidates).Error; err != nil {
+		return nil, err
+	}
+	return candidates, nil
+}
+
+// dedupeIndex returns the blind index column and value a field is matched on: email,
+// phone and TIN are encrypted.
+func dedupeIndex(field, value, country string) (string, string, error) {
+	var column, index string
+	var err error
+	switch field {
+	case utils.DEDUPE_FIELD_EMAIL:
+		column = "persons.email_bidx"
+		index, err = pii.EmailIndex(value)
+	case utils.DEDUPE_FIELD_PHONE:
+		column = "persons.phone_bidx"
+		index, err = pii.PhoneIndex(value)
+	case utils.DEDUPE_FIELD_TIN:
+		column = "persons.tin_bidx"
+		index, err = pii.TINIndex(value, country)
+	default:
+		return "", "", fmt.Errorf("unknown dedupe field %q", field)
+	}
+	return column, index, err
+}
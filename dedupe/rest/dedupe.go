@@ -0,0 +1,57 @@
+package rest
+
+import (
+	"errors"
+	"fin-auth/domain"
+	"fin-auth/utils"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+type DedupeHandler struct {
+	Service  domain.DedupeService
+	Response domain.Response
+}
+
+func SetupDedupeRoutes(api *echo.Group, s domain.DedupeService) {
+	handler := &DedupeHandler{
+		Service:  s,
+		Response: domain.NewResponse(),
+	}
+	api.GET("/customers/:id/duplicates", handler.listCandidates)
+}
+
+// SetupDedupeAdminRoutes lists candidates across clients, with every customer id shown.
+func SetupDedupeAdminRoutes(admin *echo.Group, s domain.DedupeService) {
+	handler := &DedupeHandler{
+		Service:  s,
+		Response: domain.NewResponse(),
+	}
+	admin.GET("/duplicate-candidates", handler.listAllCandidates)
+}
+
+func (h *DedupeHandler) listCandidates(c echo.Context) error {
+	clientId := c.Get("client_id").(string)
+	candidates, err := h.Service.ListCandidates(c.Request().Context(), clientId, c.Param("id"))
+	if errors.Is(err, utils.ErrNotFound) {
+		return h.Response.NotFound(c, utils.StringPtr("Customer not found"))
+	}
+	if err != nil {
+		return h.Response.InternalServerError(c, err)
+	}
+	return h.Response.SuccessOk(c, candidates)
+}
+
+func (h *DedupeHandler) listAllCandidates(c echo.Context) error {
+	customerId := strings.TrimSpace(c.QueryParam("customer_id"))
+	if customerId != "" && !utils.IsValidUUID(customerId) {
+		return h.Response.InvalidData(c, utils.StringPtr("customer_id must be a UUID"))
+	}
+
+	candidates, err := h.Service.ListAllCandidates(c.Request().Context(), customerId)
+	if err != nil {
+		return h.Response.InternalServerError(c, err)
+	}
+	return h.Response.SuccessOk(c, candidates)
+}
@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fin-auth/config"
+	"fin-auth/domain"
+	"fin-auth/dto"
+	"fin-auth/models"
+	"fin-auth/utils"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+var defaultDedupeRules = []config.DedupeRule{
+	{Field: utils.DEDUPE_FIELD_EMAIL, Scope: utils.DEDUPE_SCOPE_CLIENT, Action: utils.DEDUPE_ACTION_REJECT},
+	{Field: utils.DEDUPE_FIELD_PHONE, Scope: utils.DEDUPE_SCOPE_CLIENT, Action: utils.DEDUPE_ACTION_REJECT},
+	{Field: utils.DEDUPE_FIELD_TIN, Scope: utils.DEDUPE_SCOPE_GLOBAL, Action: utils.DEDUPE_ACTION_FLAG},
+}
+
+type Dedupe struct {
+	DedupeRepository   domain.DedupeRepository
+	CustomerRepository domain.CustomerRepository
+	KYCService         domain.KYCService
+	Config             config.DedupeConfig
+}
+
+func NewDedupeService(repo domain.DedupeRepository, customerRepo domain.CustomerRepository, kycService domain.KYCService, cfg config.DedupeConfig) *Dedupe {
+	if len(cfg.Rules) == 0 {
+		cfg.Rules = defaultDedupeRules
+	}
+	return &Dedupe{
+		DedupeRepository:   repo,
+		CustomerRepository: customerRepo,
+		KYCService:         kycService,
+		Config:             cfg,
+	}
+}
+
+// Check reports each matched customer once per field. When several rules match the
+// same customer and field, reject wins over flag.
+func (s *Dedupe) Check(ctx context.Context, clientId string, person *models.Person) ([]dto.DuplicateMatch, error) {
+	return s.check(ctx, nil, clientId, person)
+}
+
+func (s *Dedupe) CheckLocked(ctx context.Context, tx *gorm.DB, clientId string, person *models.Person) ([]dto.DuplicateMatch, error) {
+	// Fields are locked in a fixed order so that two checks can't wait on each other.
+	var fields []string
+	for _, rule := range s.Config.Rules {
+		if !utils.InArrayString(rule.Field, fields) {
+			fields = append(fields, rule.Field)
+		}
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		value, country := dedupeValue(field, person)
+		if value == "" {
+			continue
+		}
+		if err := s.DedupeRepository.Lock(ctx, tx, field, value, country); err != nil {
+			return nil, err
+		}
+	}
+	return s.check(ctx, tx, clientId, person)
+}
+
+func (s *Dedupe) check(ctx context.Context, tx *gorm.DB, clientId string, person *models.Person) ([]dto.DuplicateMatch, error) {
+	var matches []dto.DuplicateMatch
+	index := map[string]int{}
+
+	for _, rule := range s.Config.Rules {
+		value, country := dedupeValue(rule.Field, person)
+		if value == "" {
+			continue
+		}
+		scopeClient := ""
+		if rule.Scope != utils.DEDUPE_SCOPE_GLOBAL {
+			scopeClient = clientId
+		}
+
+		persons, err := s.DedupeRepository.FindPersons(ctx, tx, rule.Field, value, country, scopeClient)
+		if err != nil {
+			return nil, err
+		}
+		for _, existing := range persons {
+			if existing.Customer == nil {
+				continue
+			}
+			key := existing.Customer.ID + "|" + rule.Field
+			if i, ok := index[key]; ok {
+				if rule.Action == utils.DEDUPE_ACTION_REJECT {
+					matches[i].Action = utils.DEDUPE_ACTION_REJECT
+				}
+				continue
+			}
+
+			scope := utils.DEDUPE_SCOPE_GLOBAL
+			if utils.SafeString(existing.Customer.ClientID) == clientId {
+				scope = utils.DEDUPE_SCOPE_CLIENT
+			}
+			index[key] = len(matches)
+			matches = append(matches, dto.DuplicateMatch{
+				Field:      rule.Field,
+				Scope:      scope,
+				Action:     rule.Action,
+				CustomerID: existing.Customer.ID,
+				ClientID:   existing.Customer.ClientID,
+			})
+		}
+	}
+	return matches, nil
+}
+
+func (s *Dedupe) Link(ctx context.Context, customer *models.Customer, matches []dto.DuplicateMatch) error {
+	if len(matches) == 0 {
+		return nil
+	}
+
+	candidates, flag, err := s.Candidates(customer, matches)
+	if err != nil {
+		return err
+	}
+	if err := s.DedupeRepository.CreateCandidates(ctx, candidates); err != nil {
+		return err
+	}
+	var details map[string]interface{}
+	if err := json.Unmarshal(flag.Details, &details); err != nil {
+		return err
+	}
+	return s.KYCService.FlagForReview(ctx, customer, flag.Source, flag.Code, details)
+}
+
+func (s *Dedupe) Candidates(customer *models.Customer, matches []dto.DuplicateMatch) ([]*models.DuplicateCandidate, *models.ReviewFlag, error) {
+	if len(matches) == 0 {
+		return nil, nil, nil
+	}
+
+	candidates := make([]*models.DuplicateCandidate, 0, len(matches))
+	var fields []string
+	for _, match := range matches {
+		candidates = append(candidates, &models.DuplicateCandidate{
+			CustomerID:        customer.ID,
+			MatchedCustomerID: match.CustomerID,
+			ClientID:          customer.ClientID,
+			MatchedClientID:   match.ClientID,
+			Field:             match.Field,
+			Scope:             match.Scope,
+		})
+		if !utils.InArrayString(match.Field, fields) {
+			fields = append(fields, match.Field)
+		}
+	}
+
+	// Other clients' customer ids stay out of the flag, which the client can read.
+	details := map[string]interface{}{
+		"fields":     fields,
+		"candidates": len(candidates),
+	}
+	flag, err := dto.NewReviewFlag(customer.ID, utils.REVIEW_SOURCE_DEDUPE, utils.DuplicateCustomerCode(), details)
+	if err != nil {
+		return nil, nil, err
+	}
+	return candidates, flag, nil
+}
+
+func (s *Dedupe) ListCandidates(ctx context.Context, clientId, customerId string) ([]*dto.DuplicateCandidateRes, error) {
+	if _, err := s.CustomerRepository.FindByID(ctx, clientId, customerId); err != nil {
+		return nil, err
+	}
+
+	candidates, err := s.DedupeRepository.ListCandidates(ctx, customerId)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*dto.DuplicateCandidateRes, 0, len(candidates))
+	for _, candidate := range candidates {
+		otherId, otherClient := candidate.MatchedCustomerID, candidate.MatchedClientID
+		if candidate.MatchedCustomerID == customerId {
+			otherId, otherClient = candidate.CustomerID, candidate.ClientID
+		}
+
+		item := &dto.DuplicateCandidateRes{
+			ID:         candidate.ID,
+			Field:      candidate.Field,
+			Scope:      candidate.Scope,
+			SameClient: utils.SafeString(otherClient) == clientId,
+			CreatedAt:  candidate.CreatedAt,
+		}
+		if item.SameClient {
+			item.OtherCustomerID = &otherId
+		}
+		res = append(res, item)
+	}
+	return res, nil
+}
+
+func (s *Dedupe) ListAllCandidates(ctx context.Context, customerId string) ([]*models.DuplicateCandidate, error) {
+	return s.DedupeRepository.ListCandidates(ctx, customerId)
+}
+
+// dedupeValue returns the normalized value compared for a field, and the TIN country.
+func dedupeValue(field string, person *models.Person) (string, string) {
+	switch field {
+	case utils.DEDUPE_FIELD_EMAIL:
+		return strings.ToLower(strings.TrimSpace(utils.SafeString(person.Email))), ""
+	case utils.DEDUPE_FIELD_PHONE:
+		phone, err := utils.ValidateAndNormalizePhone(strings.TrimSpace(utils.SafeString(person.Phone)), "")
+		if err != nil {
+			return "", ""
+		}
+		return phone, ""
+	case utils.DEDUPE_FIELD_TIN:
+		tin := utils.NormalizeTIN(utils.SafeString(person.TIN))
+		country := utils.SafeString(person.CountryOfResidence)
+		if tin == "" || country == "" {
+			return "", ""
+		}
+		return tin, country
+	}
+	return "", ""
+}
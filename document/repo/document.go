@@ -0,0 +1,78 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fin-auth/cache"
+	"fin-auth/models"
+	"fin-auth/utils"
+
+	"gorm.io/gorm"
+)
+
+type Document struct {
+	db    *gorm.DB
+	cache *cache.RedisCache
+}
+
+func NewDocumentRepository(db *gorm.DB, cache *cache.RedisCache) *Document {
+	return &Document{
+		db:    db,
+		cache: cache,
+	}
+}
+
+func (o *Document) GetDB(tx ...*gorm.DB) *gorm.DB {
+	db := o.db
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db
+}
+
+func (o *Document) Create(ctx context.Context, document *models.Document) error {
+	return o.db.Create(document).Error
+}
+
+func (o *Document) ListByCustomer(ctx context.Context, clientId, customerId string) ([]*models.Document, error) {
+	var documents []*models.Document
+	err := o.db.Where("client_id = ? AND customer_id = ?", clientId, customerId).
+		Order("created_at DESC").
+		Find(&documents).Error
+	if err != nil {
+		return nil, err
+	}
+	return documents, nil
+}
+
+func (o *Document) FindByID(ctx context.Context, clientId, customerId, documentId string) (*models.Document, error) {
+	var document models.Document
+	err := o.db.Where("id = ? AND client_id = ? AND customer_id = ?", documentId, clientId, customerId).First(&document).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, utils.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &document, nil
+}
+
+// FindByIDUnscoped looks a document up without a client scope; only use it after the
+// caller has been authorised by other means, such as a signed URL.
+func (o *Document) FindByIDUnscoped(ctx context.Context, documentId string) (*models.Document, error) {
+	var document models.Document
+	err := o.db.Where("id = ?", documentId).First(&document).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, utils.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &document, nil
+}
+
+func (o *Document) ExistsByHash(ctx context.Context, customerId, hash string) (bool, error) {
+	var count int64
+	err := o.db.Model(&models.Document{}).Where("customer_id = ? AND hash = ?", customerId, hash).Count(&count).Error
+	return count > 0, err
+}
@@ -0,0 +1,116 @@
+package rest
+
+import (
+	"errors"
+	"fin-auth/domain"
+	"fin-auth/dto"
+	"fin-auth/utils"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type DocumentHandler struct {
+	Service  domain.DocumentService
+	Response domain.Response
+}
+
+func SetupDocumentRoutes(api *echo.Group, s domain.DocumentService) {
+	handler := &DocumentHandler{
+		Service:  s,
+		Response: domain.NewResponse(),
+	}
+	documents := api.Group("/customers/:id/documents")
+	documents.POST("", handler.upload)
+	documents.GET("", handler.list)
+	documents.GET("/:documentId", handler.get)
+}
+
+// SetupDocumentDownloadRoutes registers the signed-URL download endpoint, which is
+// authorised by the URL signature rather than a bearer token.
+func SetupDocumentDownloadRoutes(api *echo.Group, s domain.DocumentService) {
+	handler := &DocumentHandler{
+		Service:  s,
+		Response: domain.NewResponse(),
+	}
+	api.GET("/documents/:documentId/content", handler.download)
+}
+
+func (h *DocumentHandler) upload(c echo.Context) error {
+	var req dto.UploadDocumentReq
+	if err := c.Bind(&req); err != nil {
+		return h.Response.InvalidData(c, nil)
+	}
+
+	v := req.Validate()
+	if !utils.HasFile("file", c) {
+		v.Response.Add("file", utils.ErrorMessage("file"))
+		v.Status = true
+	}
+	if v.Status {
+		return h.Response.ValidationFail(c, v, nil)
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		return h.Response.InvalidData(c, nil)
+	}
+
+	clientId := c.Get("client_id").(string)
+	res, err := h.Service.Upload(c.Request().Context(), clientId, c.Param("id"), &req, file)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"success": true,
+		"message": "Document uploaded successfully",
+		"data":    res,
+	})
+}
+
+func (h *DocumentHandler) list(c echo.Context) error {
+	clientId := c.Get("client_id").(string)
+	documents, err := h.Service.List(c.Request().Context(), clientId, c.Param("id"))
+	if err != nil {
+		return h.handleError(c, err)
+	}
+	return h.Response.SuccessOk(c, documents)
+}
+
+func (h *DocumentHandler) get(c echo.Context) error {
+	clientId := c.Get("client_id").(string)
+	document, err := h.Service.Get(c.Request().Context(), clientId, c.Param("id"), c.Param("documentId"))
+	if err != nil {
+		return h.handleError(c, err)
+	}
+	return h.Response.SuccessOk(c, document)
+}
+
+func (h *DocumentHandler) download(c echo.Context) error {
+	document, rc, err := h.Service.OpenSigned(c.Request().Context(), c.Param("documentId"), c.QueryParam("expires"), c.QueryParam("signature"))
+	if err != nil {
+		return h.handleError(c, err)
+	}
+	defer rc.Close()
+
+	c.Response().Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", document.FileName))
+	c.Response().Header().Set("Cache-Control", "private, no-store")
+	return c.Stream(http.StatusOK, document.MimeType, rc)
+}
+
+func (h *DocumentHandler) handleError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, utils.ErrNotFound):
+		return h.Response.NotFound(c, nil)
+	case errors.Is(err, utils.ErrForbidden):
+		return h.Response.ForbiddenResponse(c, nil, utils.StringPtr("Invalid or expired download link"))
+	case errors.Is(err, utils.ErrConflict):
+		return h.Response.ConflictError(c, utils.StringPtr("Document already uploaded"), nil)
+	case utils.GetErrCode(err) != "":
+		return h.Response.CustomValidationFail(c, map[string][]string{"file": {utils.GetErrCode(err)}}, "Validation failed!", utils.GetStatusCode(err))
+	default:
+		return h.Response.InternalServerError(c, err)
+	}
+}
@@ -0,0 +1,284 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fin-auth/cache"
+	"fin-auth/config"
+	"fin-auth/domain"
+	"fin-auth/dto"
+	"fin-auth/models"
+	"fin-auth/utils"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultMaxUploadMB = 10
+	defaultURLTTL      = 5 * time.Minute
+	minImageSide       = 300
+	maxImageSide       = 10000
+)
+
+var allowedDocumentMimeTypes = []string{"image/jpeg", "image/png", "application/pdf"}
+
+// documentExtensions names stored files after the detected type, never the client's
+// file name.
+var documentExtensions = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"application/pdf": ".pdf",
+}
+
+type Document struct {
+	DocumentRepository domain.DocumentRepository
+	CustomerRepository domain.CustomerRepository
+	Storage            domain.DocumentStorage
+	KYCService         domain.KYCService
+	Config             config.StorageConfig
+	Cache              *cache.RedisCache
+}
+
+func NewDocumentService(repo domain.DocumentRepository, customerRepo domain.CustomerRepository, storage domain.DocumentStorage, kycService domain.KYCService, cfg config.StorageConfig, cache *cache.RedisCache) *Document {
+	return &Document{
+		DocumentRepository: repo,
+		CustomerRepository: customerRepo,
+		Storage:            storage,
+		KYCService:         kycService,
+		Config:             cfg,
+		Cache:              cache,
+	}
+}
+
+func (s *Document) Upload(ctx context.Context, clientId, customerId string, req *dto.UploadDocumentReq, file *multipart.FileHeader) (*dto.DocumentRes, error) {
+	cached, err := s.CustomerRepository.FindByID(ctx, clientId, customerId)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := s.readUpload(file)
+	if err != nil {
+		return nil, err
+	}
+
+	mimeType, width, height, err := inspectDocument(data, file.Filename)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := utils.GenerateHash(string(data))
+	exists, err := s.DocumentRepository.ExistsByHash(ctx, customerId, hash)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, utils.ErrConflict
+	}
+
+	document := &models.Document{
+		CustomerID: customerId,
+		ClientID:   clientId,
+		Type:       req.Type,
+		Side:       req.Side,
+		FileName:   filepath.Base(file.Filename),
+		MimeType:   mimeType,
+		Size:       int64(len(data)),
+		Width:      width,
+		Height:     height,
+		Hash:       hash,
+		Status:     utils.DOCUMENT_STATUS_UPLOADED,
+	}
+	if req.Country != "" {
+		document.Country = utils.StringPtr(req.Country)
+	}
+
+	// The ID is assigned up front so the storage key can embed it.
+	document.ID = uuid.New().String()
+	document.StorageKey = fmt.Sprintf("%s/%s%s", customerId, document.ID, documentExtensions[mimeType])
+
+	if err := s.Storage.Put(ctx, document.StorageKey, bytes.NewReader(data)); err != nil {
+		return nil, err
+	}
+	if err := s.DocumentRepository.Create(ctx, document); err != nil {
+		s.Storage.Delete(ctx, document.StorageKey)
+		return nil, err
+	}
+
+	res := s.withSignedURL(document)
+	if document.Type == utils.DOCUMENT_TYPE_PROOF_OF_ADDRESS {
+		res.CountryCheck, err = s.checkProofOfAddress(ctx, cached, document)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// checkProofOfAddress compares the proof-of-address country with the country of residence,
+// the address country and the country derived from the phone number. Any mismatch sends the
+// customer to manual review with the mismatches as the structured reason.
+func (s *Document) checkProofOfAddress(ctx context.Context, cached *domain.CachedCustomer, document *models.Document) (*dto.CountryCheckRes, error) {
+	poaCountry := utils.SafeString(document.Country)
+	check := &dto.CountryCheckRes{Country: poaCountry, Passed: true}
+	if poaCountry == "" {
+		return check, nil
+	}
+
+	compare := func(field, actual string) {
+		actual = strings.ToUpper(strings.TrimSpace(actual))
+		if actual != "" && actual != poaCountry {
+			check.Mismatches = append(check.Mismatches, dto.CountryMismatch{
+				Field:    field,
+				Expected: poaCountry,
+				Actual:   actual,
+			})
+		}
+	}
+	if cached.Person != nil {
+		compare("person.country_of_residence", utils.SafeString(cached.Person.CountryOfResidence))
+		compare("person.phone", utils.ExtractCountryCodeFromPhone(utils.SafeString(cached.Person.Phone)))
+	}
+	if cached.Address != nil {
+		compare("address.country", utils.SafeString(cached.Address.Country))
+	}
+
+	if len(check.Mismatches) == 0 {
+		return check, nil
+	}
+	check.Passed = false
+
+	details := map[string]interface{}{
+		"message":     utils.ErrCountryMismatchPOA.Error(),
+		"document_id": document.ID,
+		"poa_country": poaCountry,
+		"mismatches":  check.Mismatches,
+	}
+	err := s.KYCService.FlagForReview(ctx, cached.Customer, utils.REVIEW_SOURCE_PROOF_OF_ADDRESS, utils.CountryMismatchCode(), details)
+	if err != nil {
+		return nil, err
+	}
+	return check, nil
+}
+
+func (s *Document) List(ctx context.Context, clientId, customerId string) ([]*dto.DocumentRes, error) {
+	documents, err := s.DocumentRepository.ListByCustomer(ctx, clientId, customerId)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*dto.DocumentRes, 0, len(documents))
+	for _, document := range documents {
+		result = append(result, s.withSignedURL(document))
+	}
+	return result, nil
+}
+
+func (s *Document) Get(ctx context.Context, clientId, customerId, documentId string) (*dto.DocumentRes, error) {
+	document, err := s.DocumentRepository.FindByID(ctx, clientId, customerId, documentId)
+	if err != nil {
+		return nil, err
+	}
+	return s.withSignedURL(document), nil
+}
+
+// OpenSigned serves a document for a URL produced by withSignedURL.
+func (s *Document) OpenSigned(ctx context.Context, documentId, expires, signature string) (*models.Document, io.ReadCloser, error) {
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || utils.CurrentTime().Unix() > expiresAt {
+		return nil, nil, utils.ErrForbidden
+	}
+	if !utils.VerifyHMACSHA256(s.Config.SigningKey, []byte(documentId+"."+expires), signature) {
+		return nil, nil, utils.ErrForbidden
+	}
+
+	document, err := s.DocumentRepository.FindByIDUnscoped(ctx, documentId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rc, err := s.Storage.Open(ctx, document.StorageKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return document, rc, nil
+}
+
+func (s *Document) withSignedURL(document *models.Document) *dto.DocumentRes {
+	ttl := defaultURLTTL
+	if s.Config.URLTTLSeconds > 0 {
+		ttl = time.Duration(s.Config.URLTTLSeconds) * time.Second
+	}
+	expiresAt := utils.CurrentTime().Add(ttl)
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+
+	query := url.Values{}
+	query.Set("expires", expires)
+	query.Set("signature", utils.SignHMACSHA256(s.Config.SigningKey, []byte(document.ID+"."+expires)))
+
+	return &dto.DocumentRes{
+		Document:     document,
+		URL:          fmt.Sprintf("%s/api/v1/documents/%s/content?%s", strings.TrimRight(s.Config.PublicBaseURL, "/"), document.ID, query.Encode()),
+		URLExpiresAt: expiresAt,
+	}
+}
+
+func (s *Document) readUpload(file *multipart.FileHeader) ([]byte, error) {
+	maxMB := s.Config.MaxUploadMB
+	if maxMB <= 0 {
+		maxMB = defaultMaxUploadMB
+	}
+	maxBytes := int64(maxMB) << 20
+	if file.Size > maxBytes {
+		return nil, utils.WrapError(utils.ErrUnprocessableEntity, http.StatusUnprocessableEntity, utils.FileTooLargeCode())
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	data, err := io.ReadAll(io.LimitReader(src, maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxBytes {
+		return nil, utils.WrapError(utils.ErrUnprocessableEntity, http.StatusUnprocessableEntity, utils.FileTooLargeCode())
+	}
+	return data, nil
+}
+
+// inspectDocument checks that the sniffed content type is allowed and agrees with the
+// file extension, and that images have usable dimensions.
+func inspectDocument(data []byte, filename string) (string, *int, *int, error) {
+	invalidMime := utils.WrapError(utils.ErrUnprocessableEntity, http.StatusUnprocessableEntity, utils.InvalidMimeTypeCode())
+
+	sniffed := http.DetectContentType(data)
+	if !utils.InArrayString(sniffed, allowedDocumentMimeTypes) || sniffed != utils.DetectMimeType(filename) {
+		return "", nil, nil, invalidMime
+	}
+	if sniffed == "application/pdf" {
+		return sniffed, nil, nil, nil
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return "", nil, nil, invalidMime
+	}
+	if cfg.Width < minImageSide || cfg.Height < minImageSide || cfg.Width > maxImageSide || cfg.Height > maxImageSide {
+		return "", nil, nil, utils.WrapError(utils.ErrUnprocessableEntity, http.StatusUnprocessableEntity, utils.InvalidDimensionsCode())
+	}
+	return sniffed, &cfg.Width, &cfg.Height, nil
+}
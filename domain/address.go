@@ -2,13 +2,32 @@ package domain
 
 import (
 	"context"
+	"fin-auth/dto"
 	"fin-auth/models"
+	"time"
 )
 
 type AddressService interface {
 	Create(ctx context.Context, address *models.Address) (*models.Address, error)
+	// List returns the current addresses, or every version when history is set.
+	List(ctx context.Context, clientId, customerId string, history bool) ([]*models.Address, error)
+	Get(ctx context.Context, clientId, customerId string, addressId int) (*models.Address, error)
+	Add(ctx context.Context, clientId, customerId string, req *dto.AddressReq) (*models.Address, error)
+	// Update closes the current version of the address and returns the new one.
+	Update(ctx context.Context, clientId, customerId string, addressId int, req *dto.AddressReq) (*models.Address, error)
+	Close(ctx context.Context, clientId, customerId string, addressId int) error
+	SetPrimary(ctx context.Context, clientId, customerId string, addressId int) (*models.Address, error)
 }
 
 type AddressRepository interface {
 	Create(ctx context.Context, address *models.Address) (*models.Address, error)
+	ListByCustomer(ctx context.Context, customerId string, history bool) ([]*models.Address, error)
+	FindByID(ctx context.Context, customerId string, addressId int) (*models.Address, error)
+	// Add stores a new address, taking the primary flag from the current primary of
+	// its type when it is set.
+	Add(ctx context.Context, address *models.Address) error
+	// Replace closes current as of next.ValidFrom and stores next as its successor.
+	Replace(ctx context.Context, current, next *models.Address) error
+	Close(ctx context.Context, address *models.Address, at time.Time) error
+	SetPrimary(ctx context.Context, address *models.Address) error
 }
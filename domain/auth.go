@@ -13,6 +13,7 @@ type AuthService interface {
 	Logout(ctx context.Context, token string) error
 	ListSessions(ctx context.Context, clientId string) ([]dto.SessionResponse, error)
 	RevokeSession(ctx context.Context, clientId, token string) error
+	UpdateClientScopes(ctx context.Context, clientId string, scopes []string) (*models.User, error)
 }
 
 type AuthRepository interface {
@@ -22,6 +23,7 @@ type AuthRepository interface {
 	CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error
 	FindValidRefreshToken(ctx context.Context, tokenStr string) (*models.RefreshToken, error)
 	FindValidAccessToken(ctx context.Context, tokenStr string) (*models.AccessToken, error)
+	UpdateClientScopes(ctx context.Context, clientId string, scopes []string) (*models.User, error)
 }
 
 type ClientWithSecrets struct {
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"context"
+	"fin-auth/dto"
+	"fin-auth/models"
+	"time"
+)
+
+type CorridorService interface {
+	List(ctx context.Context) ([]*models.Corridor, error)
+	Create(ctx context.Context, req *dto.CorridorReq) (*models.Corridor, error)
+	Update(ctx context.Context, id int, req *dto.CorridorReq) (*models.Corridor, error)
+	// Eligibility evaluates every active corridor for the customer.
+	Eligibility(ctx context.Context, clientId, customerId string) ([]*dto.CorridorEligibility, error)
+	// Refresh recomputes and stores the customer's available corridors and USD flag.
+	Refresh(ctx context.Context, clientId, customerId string) error
+	// RefreshAll refreshes every customer when a corridor was created or updated since
+	// the previous run.
+	RefreshAll(ctx context.Context) (int, error)
+}
+
+type CorridorRepository interface {
+	List(ctx context.Context, activeOnly bool) ([]*models.Corridor, error)
+	FindByID(ctx context.Context, id int) (*models.Corridor, error)
+	Create(ctx context.Context, corridor *models.Corridor) error
+	Update(ctx context.Context, corridor *models.Corridor) error
+	LatestChange(ctx context.Context) (time.Time, error)
+	// ListCustomersAfter pages through all customers by id.
+	ListCustomersAfter(ctx context.Context, afterId string, limit int) ([]*models.Customer, error)
+}
@@ -3,6 +3,8 @@ package domain
 import (
 	"context"
 	"fin-auth/models"
+
+	"gorm.io/gorm"
 )
 
 type CachedCustomer struct {
@@ -11,13 +13,31 @@ type CachedCustomer struct {
 	Address  *models.Address  `json:"address"`
 }
 
+// Onboarding is written in the same transaction as a new customer, so the customer is
+// never created without the review flags its onboarding checks raised.
+type Onboarding struct {
+	Acceptance  *models.TOSAcceptance
+	ReviewFlags []*models.ReviewFlag
+	Matches     []*models.ScreeningMatch
+	Candidates  []*models.DuplicateCandidate
+	// Guard runs first in the transaction, before anything is written; an error
+	// rolls the onboarding back.
+	Guard func(tx *gorm.DB) error
+}
+
 type CustomerService interface {
-	CreateIndividualCustomer(ctx context.Context, customer *models.Customer, person *models.Person, address *models.Address, req interface{}) (*models.Customer, *models.Person, *models.Address, error)
+	CreateIndividualCustomer(ctx context.Context, customer *models.Customer, person *models.Person, address *models.Address, acceptance *models.TOSAcceptance, req interface{}) (*models.Customer, *models.Person, *models.Address, error)
 	GetCustomersByClientID(ctx context.Context, clientId string) ([]*CachedCustomer, error)
 }
 
 type CustomerRepository interface {
 	Create(ctx context.Context, customer *models.Customer) (*models.Customer, error)
-	CreateIndividualCustomer(ctx context.Context, customer *models.Customer, person *models.Person, address *models.Address, req interface{}) (*models.Customer, *models.Person, *models.Address, error)
+	CreateIndividualCustomer(ctx context.Context, customer *models.Customer, person *models.Person, address *models.Address, onboarding *Onboarding, req interface{}) (*models.Customer, *models.Person, *models.Address, error)
 	ListByClientID(ctx context.Context, clientId string) ([]*CachedCustomer, error)
+	FindByID(ctx context.Context, clientId, customerId string) (*CachedCustomer, error)
+	FindByKYCApplicantID(ctx context.Context, provider, applicantId string) (*models.Customer, error)
+	FindByBridgeCustomerID(ctx context.Context, bridgeCustomerId string) (*models.Customer, error)
+	UpdateKYC(ctx context.Context, customer *models.Customer) error
+	UpdateRisk(ctx context.Context, customer *models.Customer) error
+	UpdateCorridors(ctx context.Context, customer *models.Customer) error
 }
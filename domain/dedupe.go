@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"context"
+	"fin-auth/dto"
+	"fin-auth/models"
+
+	"gorm.io/gorm"
+)
+
+type DedupeService interface {
+	// Check returns existing customers matching the person under the configured rules.
+	Check(ctx context.Context, clientId string, person *models.Person) ([]dto.DuplicateMatch, error)
+	// CheckLocked checks within tx, holding a lock on each of the person's values until
+	// tx ends, so a concurrent check for the same values waits for it.
+	CheckLocked(ctx context.Context, tx *gorm.DB, clientId string, person *models.Person) ([]dto.DuplicateMatch, error)
+	// Candidates turns matches into candidate records and the review flag stored with
+	// them; the customer id is left to the caller.
+	Candidates(customer *models.Customer, matches []dto.DuplicateMatch) ([]*models.DuplicateCandidate, *models.ReviewFlag, error)
+	// Link records the matches as candidates of the new customer and flags it for review.
+	Link(ctx context.Context, customer *models.Customer, matches []dto.DuplicateMatch) error
+	ListCandidates(ctx context.Context, clientId, customerId string) ([]*dto.DuplicateCandidateRes, error)
+	ListAllCandidates(ctx context.Context, customerId string) ([]*models.DuplicateCandidate, error)
+}
+
+type DedupeRepository interface {
+	// FindPersons returns persons whose normalized field equals value; country only applies
+	// to TINs.
+	// An empty clientId searches every client.
+	// A nil tx uses the repository's connection.
+	FindPersons(ctx context.Context, tx *gorm.DB, field, value, country, clientId string) ([]*models.Person, error)
+	Lock(ctx context.Context, tx *gorm.DB, field, value, country string) error
+	CreateCandidates(ctx context.Context, candidates []*models.DuplicateCandidate) error
+	ListCandidates(ctx context.Context, customerId string) ([]*models.DuplicateCandidate, error)
+}
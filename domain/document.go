@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"context"
+	"fin-auth/dto"
+	"fin-auth/models"
+	"io"
+	"mime/multipart"
+)
+
+// DocumentStorage is the pluggable backend holding uploaded document files.
+type DocumentStorage interface {
+	Put(ctx context.Context, key string, r io.Reader) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+type DocumentService interface {
+	Upload(ctx context.Context, clientId, customerId string, req *dto.UploadDocumentReq, file *multipart.FileHeader) (*dto.DocumentRes, error)
+	List(ctx context.Context, clientId, customerId string) ([]*dto.DocumentRes, error)
+	Get(ctx context.Context, clientId, customerId, documentId string) (*dto.DocumentRes, error)
+	OpenSigned(ctx context.Context, documentId, expires, signature string) (*models.Document, io.ReadCloser, error)
+}
+
+type DocumentRepository interface {
+	Create(ctx context.Context, document *models.Document) error
+	ListByCustomer(ctx context.Context, clientId, customerId string) ([]*models.Document, error)
+	FindByID(ctx context.Context, clientId, customerId, documentId string) (*models.Document, error)
+	FindByIDUnscoped(ctx context.Context, documentId string) (*models.Document, error)
+	ExistsByHash(ctx context.Context, customerId, hash string) (bool, error)
+}
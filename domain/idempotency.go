@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"context"
+	"fin-auth/models"
+	"time"
+)
+
+// IdempotencyStore keeps Idempotency-Key records in Redis, backed by Postgres for when
+// Redis is unavailable or has evicted the record.
+type IdempotencyStore interface {
+	// Get returns the completed, unexpired record for the key, or utils.ErrNotFound.
+	Get(ctx context.Context, clientId, key string) (*models.IdempotencyRecord, error)
+	// Lock reports whether the caller now owns the key until ttl passes or Unlock is called.
+	Lock(ctx context.Context, record *models.IdempotencyRecord, ttl time.Duration) (bool, error)
+	Save(ctx context.Context, record *models.IdempotencyRecord) error
+	Unlock(ctx context.Context, record *models.IdempotencyRecord) error
+}
@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"context"
+	"fin-auth/dto"
+	"fin-auth/models"
+)
+
+type JurisdictionService interface {
+	List(ctx context.Context, partner, country string) ([]*models.JurisdictionRestriction, error)
+	Create(ctx context.Context, req *dto.JurisdictionRestrictionReq) (*models.JurisdictionRestriction, error)
+	Update(ctx context.Context, id int, req *dto.JurisdictionRestrictionReq) (*models.JurisdictionRestriction, error)
+	Delete(ctx context.Context, id int) error
+	// Evaluate returns the restrictions in effect for the configured partner that match
+	// any of the targets, scoped to the client's program.
+	Evaluate(ctx context.Context, clientId string, targets []dto.JurisdictionTarget) ([]dto.JurisdictionHit, error)
+}
+
+type JurisdictionRepository interface {
+	List(ctx context.Context, partner, country string) ([]*models.JurisdictionRestriction, error)
+	FindByID(ctx context.Context, id int) (*models.JurisdictionRestriction, error)
+	Create(ctx context.Context, restriction *models.JurisdictionRestriction) error
+	Update(ctx context.Context, restriction *models.JurisdictionRestriction) error
+	Delete(ctx context.Context, id int) error
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"context"
+	"fin-auth/models"
+	"time"
+)
+
+type KYCApplicant struct {
+	ApplicantID string `json:"applicant_id"`
+	Status      string `json:"status"`
+}
+
+// KYCResult is a provider verdict already mapped onto our KYC_STATUS_* values.
+type KYCResult struct {
+	ApplicantID string    `json:"applicant_id"`
+	Status      string    `json:"status"`
+	Reason      string    `json:"reason,omitempty"`
+	CheckedAt   time.Time `json:"checked_at"`
+}
+
+type KYCWebhookEvent struct {
+	EventID     string    `json:"event_id"`
+	ApplicantID string    `json:"applicant_id"`
+	Status      string    `json:"status"`
+	Reason      string    `json:"reason,omitempty"`
+	OccurredAt  time.Time `json:"occurred_at"`
+}
+
+// KYCProvider is implemented by every external identity verification vendor.
+type KYCProvider interface {
+	Name() string
+	CreateApplicant(ctx context.Context, customer *models.Customer, person *models.Person) (*KYCApplicant, error)
+	SubmitApplicant(ctx context.Context, applicantId string) (*KYCResult, error)
+	FetchStatus(ctx context.Context, applicantId string) (*KYCResult, error)
+	ParseWebhook(ctx context.Context, body []byte) (*KYCWebhookEvent, error)
+}
+
+type KYCService interface {
+	StartVerification(ctx context.Context, clientId, customerId string) (*CachedCustomer, error)
+	RefreshStatus(ctx context.Context, clientId, customerId string) (*CachedCustomer, error)
+	ApplyResult(ctx context.Context, customer *models.Customer, result *KYCResult) error
+	TransitionStatus(ctx context.Context, customer *models.Customer, status, reason string) error
+	HandleWebhook(ctx context.Context, provider, timestamp, signature string, body []byte) (bool, error)
+	FlagForReview(ctx context.Context, customer *models.Customer, source, code string, details interface{}) error
+	ListReviewFlags(ctx context.Context, clientId, customerId string) ([]*models.ReviewFlag, error)
+}
+
+type KYCRepository interface {
+	FindWebhook(ctx context.Context, provider, eventId string) (*models.KYCWebhook, error)
+	CreateWebhook(ctx context.Context, webhook *models.KYCWebhook) error
+	UpdateWebhook(ctx context.Context, webhook *models.KYCWebhook) error
+	CreateReviewFlag(ctx context.Context, flag *models.ReviewFlag) error
+	ListReviewFlags(ctx context.Context, customerId string) ([]*models.ReviewFlag, error)
+}
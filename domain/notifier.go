@@ -0,0 +1,17 @@
+package domain
+
+import "context"
+
+// Notification is a message for a customer, addressed to an email or a phone.
+type Notification struct {
+	Channel string
+	To      string
+	Subject string
+	Body    string
+}
+
+// Notifier delivers notifications. Name is the notifier's config name.
+type Notifier interface {
+	Name() string
+	Send(ctx context.Context, n *Notification) error
+}
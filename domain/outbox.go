@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"context"
+	"fin-auth/models"
+
+	"gorm.io/gorm"
+)
+
+type OutboxRepository interface {
+	// Add records an event inside tx so it commits or rolls back with the domain change.
+	Add(ctx context.Context, tx *gorm.DB, aggregateType, aggregateId string, clientId *string, eventType string, payload interface{}) error
+}
+
+// OutboxSink receives committed outbox events from the dispatcher. Sinks that write to
+// Postgres should use tx so their writes commit together with the delivered mark.
+type OutboxSink interface {
+	Name() string
+	Dispatch(ctx context.Context, tx *gorm.DB, event *models.OutboxEvent) error
+}
@@ -2,13 +2,28 @@ package domain
 
 import (
 	"context"
+	"fin-auth/dto"
 	"fin-auth/models"
+	"time"
 )
 
 type PersonService interface {
 	Create(ctx context.Context, person *models.Person) (*models.Person, error)
+	List(ctx context.Context, clientId, customerId string) ([]*models.Person, error)
+	Get(ctx context.Context, clientId, customerId string, personId int) (*models.Person, error)
+	// Update applies a partial change. Changes to name, date of birth, TIN or
+	// nationality send the customer back through KYC.
+	Update(ctx context.Context, clientId, customerId string, personId int, req *dto.PersonPatchReq) (*models.Person, error)
+	// AddAssociated adds a non-primary person to a business customer.
+	AddAssociated(ctx context.Context, clientId, customerId string, req *dto.AssociatedPersonReq) (*models.Person, error)
+	Remove(ctx context.Context, clientId, customerId string, personId int) error
 }
 
 type PersonRepository interface {
 	Create(ctx context.Context, person *models.Person) (*models.Person, error)
+	ListByCustomer(ctx context.Context, customerId string) ([]*models.Person, error)
+	FindByID(ctx context.Context, customerId string, personId int) (*models.Person, error)
+	Update(ctx context.Context, person *models.Person) error
+	Delete(ctx context.Context, person *models.Person) error
+	MarkVerified(ctx context.Context, person *models.Person, channel, targetIndex string, at time.Time) error
 }
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"context"
+	"fin-auth/dto"
+	"fin-auth/models"
+	"fin-auth/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+type PIIAccessService interface {
+	// PresentCustomers builds the API view of customers. Unmasked views are only
+	// returned once the read has been logged.
+	PresentCustomers(ctx context.Context, access *models.PIIAccessLog, customers []*CachedCustomer, unmasked bool) ([]*dto.CustomerRes, error)
+	// RecordUnmaskedRead logs the request described by access once per customer it
+	// returned unmasked.
+	RecordUnmaskedRead(ctx context.Context, access *models.PIIAccessLog, customerIds []string) error
+	List(ctx context.Context, clientId, customerId string) ([]*models.PIIAccessLog, error)
+}
+
+type PIIAccessRepository interface {
+	Create(ctx context.Context, logs []*models.PIIAccessLog) error
+	List(ctx context.Context, clientId, customerId string) ([]*models.PIIAccessLog, error)
+}
+
+// UnmaskPermitted reports whether the caller's client holds the scope for unmasked PII.
+func UnmaskPermitted(c echo.Context) bool {
+	scopes, _ := c.Get("scopes").([]string)
+	return utils.StringInSlice(utils.SCOPE_PII_UNMASKED, scopes)
+}
+
+// Unmasked reports whether PII is returned unmasked for this request, as decided by
+// the unmask middleware.
+func Unmasked(c echo.Context) bool {
+	unmasked, _ := c.Get("unmasked").(bool)
+	return unmasked
+}
+
+// NewPIIAccess describes the current request for the PII access log.
+func NewPIIAccess(c echo.Context) *models.PIIAccessLog {
+	return &models.PIIAccessLog{
+		ClientID:  c.Get("client_id").(string),
+		Method:    c.Request().Method,
+		Route:     c.Path(),
+		IPAddress: c.RealIP(),
+		UserAgent: c.Request().UserAgent(),
+	}
+}
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"context"
+	"fin-auth/dto"
+	"fin-auth/models"
+	"time"
+)
+
+type PrivacyService interface {
+	// Export returns the JSON archive of the customer and the receipt recorded for it.
+	// An empty clientId is only used by operators and matches any client.
+	Export(ctx context.Context, clientId, customerId, requestedBy string) ([]byte, *dto.DataSubjectReceipt, error)
+	// Erase removes the customer's PII and keeps the records the law requires.
+	Erase(ctx context.Context, clientId, customerId, requestedBy string) (*dto.DataSubjectReceipt, error)
+	ListRequests(ctx context.Context, clientId, customerId string) ([]*models.DataSubjectRequest, error)
+}
+
+type PrivacyRepository interface {
+	FindCustomer(ctx context.Context, clientId, customerId string) (*models.Customer, error)
+	// LoadSubjectData fills the export with every record held about the customer,
+	// except document files.
+	LoadSubjectData(ctx context.Context, customer *models.Customer, export *dto.DataSubjectExport) error
+	ListDocuments(ctx context.Context, customerId string) ([]*models.Document, error)
+	Erase(ctx context.Context, customer *models.Customer, erasedAt time.Time) (*dto.ErasureResult, error)
+	CreateRequest(ctx context.Context, request *models.DataSubjectRequest) error
+	ListRequests(ctx context.Context, customerId string) ([]*models.DataSubjectRequest, error)
+}
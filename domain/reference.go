@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"context"
+	"fin-auth/dto"
+	"fin-auth/models"
+)
+
+type ReferenceService interface {
+	TINFormats(ctx context.Context, country string) (*dto.TINFormatsRes, error)
+	List(ctx context.Context, catalog, language string) (*dto.ReferenceListRes, error)
+	// Lookup returns the active catalog item with the given id, or utils.ErrNotFound.
+	Lookup(ctx context.Context, catalog string, id int) (*models.ReferenceItem, error)
+}
+
+type ReferenceRepository interface {
+	ListActive(ctx context.Context, catalog string) ([]*models.ReferenceItem, error)
+}
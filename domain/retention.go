@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"context"
+	"fin-auth/models"
+	"time"
+)
+
+type RetentionService interface {
+	// Run applies every enabled policy and records the run. A dry run only counts.
+	Run(ctx context.Context, dryRun bool) (*models.RetentionRun, error)
+	ListRuns(ctx context.Context) ([]*models.RetentionRun, error)
+}
+
+type RetentionRepository interface {
+	Count(ctx context.Context, entity string, cutoff time.Time) (int64, error)
+	// PurgeBatch deletes up to limit rows per table of the entity. Files of deleted
+	// documents are passed to removeFiles first and the rows stay if it fails.
+	PurgeBatch(ctx context.Context, entity string, cutoff time.Time, limit int, removeFiles func(keys []string) error) (purged int64, files int64, err error)
+	CreateRun(ctx context.Context, run *models.RetentionRun) error
+	UpdateRun(ctx context.Context, run *models.RetentionRun) error
+	ListRuns(ctx context.Context, limit int) ([]*models.RetentionRun, error)
+}
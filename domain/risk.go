@@ -0,0 +1,16 @@
+package domain
+
+import (
+	"context"
+	"fin-auth/dto"
+	"fin-auth/models"
+)
+
+type RiskService interface {
+	// Apply scores the customer and writes the result onto it without saving, forcing
+	// STANDARD verification for high-risk customers when configured.
+	Apply(customer *models.Customer, person *models.Person) *dto.RiskAssessment
+	// Reassess scores a stored customer again and saves the result.
+	Reassess(ctx context.Context, clientId, customerId string) (*dto.RiskAssessment, error)
+	GetAssessment(ctx context.Context, clientId, customerId string) (*dto.RiskAssessment, error)
+}
@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"context"
+	"fin-auth/models"
+)
+
+type ScreeningService interface {
+	// ScreenPerson matches the person's name against the watchlist, stores new matches
+	// and sends the customer to manual review when there are any.
+	ScreenPerson(ctx context.Context, customer *models.Customer, person *models.Person) ([]*models.ScreeningMatch, error)
+	// Prepare matches a person who is not stored yet and returns the matches and the
+	// review flag to store with the new customer.
+	Prepare(ctx context.Context, person *models.Person) ([]*models.ScreeningMatch, *models.ReviewFlag, error)
+	// RescreenAll screens every person again when the watchlist changed since the last run.
+	RescreenAll(ctx context.Context) (int, error)
+}
+
+type ScreeningRepository interface {
+	// CreateMatch stores a match and reports false when the customer already matched the entry.
+	CreateMatch(ctx context.Context, match *models.ScreeningMatch) (bool, error)
+	ListPersonsAfter(ctx context.Context, afterId, limit int) ([]*models.Person, error)
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"context"
+	"fin-auth/dto"
+	"fin-auth/models"
+	"time"
+)
+
+type TOSService interface {
+	Publish(ctx context.Context, req *dto.TOSPolicyReq) (*models.TOSPolicy, error)
+	ListPolicies(ctx context.Context) ([]*models.TOSPolicy, error)
+	// Current returns the latest version in effect.
+	Current(ctx context.Context) (*models.TOSPolicy, error)
+	// CheckAcceptable reports a field error unless policyId is the version in effect.
+	CheckAcceptable(ctx context.Context, policyId string) error
+	// OnboardingPolicy returns the version a new customer accepts, defaulting an empty
+	// policyId to the version in effect unless the config requires one.
+	OnboardingPolicy(ctx context.Context, policyId string) (string, error)
+	Accept(ctx context.Context, clientId, customerId string, acceptance *models.TOSAcceptance) (*models.TOSAcceptance, error)
+	ListAcceptances(ctx context.Context, clientId, customerId string) ([]*models.TOSAcceptance, error)
+	// ReacceptanceReport lists the client's customers who have not accepted the latest
+	// version requiring re-acceptance.
+	ReacceptanceReport(ctx context.Context, clientId string, limit, offset int) (*dto.TOSReacceptanceReport, error)
+}
+
+type TOSRepository interface {
+	CreatePolicy(ctx context.Context, policy *models.TOSPolicy) error
+	ListPolicies(ctx context.Context) ([]*models.TOSPolicy, error)
+	FindPolicy(ctx context.Context, id string) (*models.TOSPolicy, error)
+	// CurrentPolicy returns the latest version effective at now. With reacceptance set,
+	// only versions requiring re-acceptance are considered.
+	CurrentPolicy(ctx context.Context, now time.Time, reacceptance bool) (*models.TOSPolicy, error)
+	// CreateAcceptance stores the acceptance and points the customer at the policy.
+	CreateAcceptance(ctx context.Context, acceptance *models.TOSAcceptance) error
+	ListAcceptances(ctx context.Context, customerId string) ([]*models.TOSAcceptance, error)
+	ListPendingReacceptance(ctx context.Context, clientId string, policy *models.TOSPolicy, limit, offset int) ([]*dto.TOSReacceptanceEntry, int64, error)
+}
@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"context"
+	"fin-auth/dto"
+	"fin-auth/models"
+	"time"
+)
+
+// OTPChallenge is a pending one-time code. Only a keyed hash of the code is kept,
+// together with the blind index of the address it was sent to.
+type OTPChallenge struct {
+	CodeHash    string
+	TargetIndex string
+	Attempts    int
+	ExpiresAt   time.Time
+}
+
+type VerificationService interface {
+	// Send delivers a one-time code to the person's email or phone. It fails with
+	// utils.ErrOTPSendLimit once too many codes went to the address.
+	Send(ctx context.Context, clientId, customerId string, personId int, channel string) (*dto.OTPSendRes, error)
+	// Confirm checks a code and marks the address verified. It fails with
+	// utils.ErrOTPAttemptLimit once the code was guessed too often.
+	Confirm(ctx context.Context, clientId, customerId string, personId int, req *dto.OTPConfirmReq) (*models.Person, error)
+}
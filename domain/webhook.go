@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"context"
+	"fin-auth/dto"
+	"fin-auth/models"
+
+	"gorm.io/gorm"
+)
+
+type WebhookService interface {
+	CreateSubscription(ctx context.Context, clientId string, req *dto.CreateWebhookSubscriptionReq) (*dto.WebhookSubscriptionRes, error)
+	ListSubscriptions(ctx context.Context, clientId string) ([]*models.WebhookSubscription, error)
+	UpdateSubscription(ctx context.Context, clientId string, id int, req *dto.UpdateWebhookSubscriptionReq) (*models.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, clientId string, id int) error
+	ListDeliveries(ctx context.Context, clientId, status string, limit, offset int) ([]*models.WebhookDelivery, int64, error)
+	Redeliver(ctx context.Context, clientId string, id int) (*models.WebhookDelivery, error)
+}
+
+type WebhookRepository interface {
+	CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error
+	ListSubscriptions(ctx context.Context, clientId string) ([]*models.WebhookSubscription, error)
+	FindSubscription(ctx context.Context, clientId string, id int) (*models.WebhookSubscription, error)
+	UpdateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error
+	DeleteSubscription(ctx context.Context, clientId string, id int) error
+	// EnqueueEvent fans an event out to the client's matching subscriptions. Pass the
+	// caller's transaction so deliveries commit together with the domain change.
+	EnqueueEvent(ctx context.Context, tx *gorm.DB, clientId, eventType string, payload interface{}) error
+	ListDeliveries(ctx context.Context, clientId, status string, limit, offset int) ([]*models.WebhookDelivery, int64, error)
+	FindDelivery(ctx context.Context, clientId string, id int) (*models.WebhookDelivery, error)
+	ResetDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+}
@@ -0,0 +1,73 @@
+package dto
+
+import (
+	"fin-auth/models"
+	"fin-auth/utils"
+	"strings"
+	"time"
+)
+
+// AddressReq adds or changes a customer address. Type defaults to residential on add;
+// on change, type and is_primary are taken from the address being replaced. ValidFrom
+// is a date, today by default, and may not be in the future.
+type AddressReq struct {
+	AddressInfo
+	Type      string `json:"type"`
+	IsPrimary bool   `json:"is_primary"`
+	ValidFrom string `json:"valid_from"`
+
+	ValidFromTime time.Time `json:"-"`
+}
+
+func (r *AddressReq) Validate() utils.Validation {
+	v := utils.NewValidationError()
+	errs := map[string][]string{}
+
+	r.Type = strings.ToUpper(strings.TrimSpace(r.Type))
+	if r.Type != "" && !utils.StringInSlice(r.Type, utils.AddressTypes) {
+		errs["type"] = append(errs["type"], utils.InvalidValueCode())
+	}
+
+	// PO boxes are checked once the type is known, see CheckStreet.
+	r.AddressInfo.validate(false, errs, "")
+
+	r.ValidFromTime = time.Now().UTC()
+	if validFrom := strings.TrimSpace(r.ValidFrom); validFrom != "" {
+		parsed, err := time.Parse("2006-01-02", validFrom)
+		switch {
+		case err != nil:
+			errs["valid_from"] = append(errs["valid_from"], utils.InvalidDateCode())
+		case parsed.After(r.ValidFromTime):
+			errs["valid_from"] = append(errs["valid_from"], utils.FutureDateCode())
+		default:
+			r.ValidFromTime = parsed
+		}
+	}
+
+	if len(errs) > 0 {
+		v.Status = true
+		v.Response = utils.ErrorResponse{}
+		for path, codes := range errs {
+			v.Response.Add(path, codes)
+		}
+	}
+	return v
+}
+
+func (r *AddressReq) GetAddress(customerId string) *models.Address {
+	address := r.AddressInfo.toModel()
+	address.CustomerID = &customerId
+	address.Type = r.Type
+	address.IsPrimary = r.IsPrimary
+	address.ValidFrom = r.ValidFromTime
+	return address
+}
+
+// CheckStreet rejects PO boxes on residential addresses: only those prove where the
+// customer lives, mail may go to a PO box.
+func CheckStreet(address *models.Address) utils.FieldErrors {
+	if address.Type == utils.ADDRESS_TYPE_RESIDENTIAL && utils.IsPoBox(utils.SafeString(address.Street)) {
+		return utils.FieldErrors{"street": {utils.InvalidAddressPoBoxCode()}}
+	}
+	return nil
+}
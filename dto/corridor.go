@@ -0,0 +1,132 @@
+package dto
+
+import (
+	"fin-auth/models"
+	"fin-auth/utils"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// CorridorReq creates or replaces a corridor. Active defaults to true, MaxRiskTier to
+// HIGH, which lets every assessed tier through.
+type CorridorReq struct {
+	SourceCountry       string   `json:"source_country"`
+	DestinationCountry  string   `json:"destination_country"`
+	SourceCurrency      string   `json:"source_currency"`
+	DestinationCurrency string   `json:"destination_currency"`
+	Rails               []string `json:"rails"`
+	MinAmount           float64  `json:"min_amount"`
+	MaxAmount           float64  `json:"max_amount"`
+	DailyLimit          *float64 `json:"daily_limit"`
+	MonthlyLimit        *float64 `json:"monthly_limit"`
+	EligibleResidences  []string `json:"eligible_residences"`
+	MaxRiskTier         string   `json:"max_risk_tier"`
+	Active              *bool    `json:"active"`
+}
+
+// CorridorEligibility is a corridor with whether the customer qualifies for it and,
+// if not, why.
+type CorridorEligibility struct {
+	Corridor *models.Corridor `json:"corridor"`
+	Eligible bool             `json:"eligible"`
+	Reasons  []string         `json:"reasons"`
+}
+
+func (r *CorridorReq) Validate() utils.Validation {
+	v := utils.NewValidationError()
+	errs := map[string][]string{}
+
+	r.SourceCountry = utils.ValidateCountryCode(r.SourceCountry, "source_country", errs, "source_country", countryCodeRegex)
+	r.DestinationCountry = utils.ValidateCountryCode(r.DestinationCountry, "destination_country", errs, "destination_country", countryCodeRegex)
+	r.SourceCurrency = validateCurrency(r.SourceCurrency, errs, "source_currency")
+	r.DestinationCurrency = validateCurrency(r.DestinationCurrency, errs, "destination_currency")
+
+	if len(r.Rails) == 0 {
+		errs["rails"] = append(errs["rails"], utils.RequiredCode())
+	}
+	for i, rail := range r.Rails {
+		r.Rails[i] = strings.ToUpper(strings.TrimSpace(rail))
+		if !utils.StringInSlice(r.Rails[i], utils.CorridorRails) {
+			errs["rails"] = append(errs["rails"], utils.InvalidValueCode())
+			break
+		}
+	}
+
+	if r.MinAmount < 0 {
+		errs["min_amount"] = append(errs["min_amount"], utils.OutOfRangeCode())
+	}
+	if r.MaxAmount <= 0 {
+		errs["max_amount"] = append(errs["max_amount"], utils.NonPositiveCode())
+	} else if r.MaxAmount < r.MinAmount {
+		errs["max_amount"] = append(errs["max_amount"], utils.OutOfRangeCode())
+	}
+	if r.DailyLimit != nil && *r.DailyLimit < r.MaxAmount {
+		errs["daily_limit"] = append(errs["daily_limit"], utils.OutOfRangeCode())
+	}
+	if r.MonthlyLimit != nil && r.DailyLimit != nil && *r.MonthlyLimit < *r.DailyLimit {
+		errs["monthly_limit"] = append(errs["monthly_limit"], utils.OutOfRangeCode())
+	} else if r.MonthlyLimit != nil && *r.MonthlyLimit < r.MaxAmount {
+		errs["monthly_limit"] = append(errs["monthly_limit"], utils.OutOfRangeCode())
+	}
+
+	for i, country := range r.EligibleResidences {
+		r.EligibleResidences[i] = strings.ToUpper(strings.TrimSpace(country))
+		if !utils.IsISOAlpha3Format(r.EligibleResidences[i]) {
+			errs["eligible_residences"] = append(errs["eligible_residences"], utils.InvalidCountryCodeCode())
+			break
+		}
+	}
+
+	r.MaxRiskTier = strings.ToUpper(strings.TrimSpace(r.MaxRiskTier))
+	if r.MaxRiskTier == "" {
+		r.MaxRiskTier = utils.RISK_TIER_HIGH
+	} else if !utils.StringInSlice(r.MaxRiskTier, utils.RiskTiers) {
+		errs["max_risk_tier"] = append(errs["max_risk_tier"], utils.InvalidValueCode())
+	}
+
+	if len(errs) > 0 {
+		v.Status = true
+		v.Response = utils.ErrorResponse{}
+		for path, codes := range errs {
+			v.Response.Add(path, codes)
+		}
+	}
+	return v
+}
+
+// Apply writes the request onto the corridor, new or stored.
+func (r *CorridorReq) Apply(corridor *models.Corridor) {
+	corridor.SourceCountry = r.SourceCountry
+	corridor.DestinationCountry = r.DestinationCountry
+	corridor.SourceCurrency = r.SourceCurrency
+	corridor.DestinationCurrency = r.DestinationCurrency
+	corridor.Rails = pq.StringArray(uniqueStrings(r.Rails))
+	corridor.MinAmount = r.MinAmount
+	corridor.MaxAmount = r.MaxAmount
+	corridor.DailyLimit = r.DailyLimit
+	corridor.MonthlyLimit = r.MonthlyLimit
+	corridor.EligibleResidences = pq.StringArray(uniqueStrings(r.EligibleResidences))
+	corridor.MaxRiskTier = r.MaxRiskTier
+	corridor.Active = r.Active == nil || *r.Active
+}
+
+func validateCurrency(currency string, errs map[string][]string, fieldPath string) string {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" {
+		errs[fieldPath] = append(errs[fieldPath], utils.RequiredCode())
+	} else if !utils.IsValidCurrencyCode(currency) {
+		errs[fieldPath] = append(errs[fieldPath], utils.InvalidFormatCode())
+	}
+	return currency
+}
+
+func uniqueStrings(values []string) []string {
+	result := []string{}
+	for _, value := range values {
+		if !utils.InArrayString(value, result) {
+			result = append(result, value)
+		}
+	}
+	return result
+}
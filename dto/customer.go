@@ -3,14 +3,29 @@ package dto
 import (
 	"fin-auth/models"
 	"fin-auth/utils"
+	"regexp"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+const (
+	maxNameLength       = 100
+	minCustomerAge      = 18
+	maxCustomerAge      = 120
+	maxMonthlyVolumeUSD = 10000000
+)
+
+var (
+	nameRegex        = regexp.MustCompile(`^[\p{L}\p{M}][\p{L}\p{M}' .-]*$`)
+	countryCodeRegex = regexp.MustCompile(`^[A-Z]{3}$`)
+	postalCodeRegex  = regexp.MustCompile(`^[A-Z0-9][A-Z0-9 -]{1,10}[A-Z0-9]$`)
+)
+
 type CreateCustomerRequest struct {
 	VerificationType *string          `json:"verification_type"`
+	TOSPolicyID      string           `json:"tos_policy_id"`
 	BasicInfo        BasicInfo        `json:"basic_info" validate:"required"`
 	Address          AddressInfo      `json:"address" validate:"required"`
 	FinancialProfile FinancialProfile `json:"financial_profile" validate:"required"`
@@ -34,6 +49,11 @@ type AddressInfo struct {
 	State      string `json:"state" validate:"required"`
 	PostalCode string `json:"postal_code" validate:"required"`
 	Country    string `json:"country" validate:"required"`
+	StateCode  string `json:"-"`
+
+	NormalizedStreet     string `json:"-"`
+	NormalizedCity       string `json:"-"`
+	NormalizedPostalCode string `json:"-"`
 }
 
 type FinancialProfile struct {
@@ -52,7 +72,7 @@ func (r *CreateCustomerRequest) GetCustomer(basicInfo *BasicInfo, clientId *stri
 	return &models.Customer{
 		ClientID:         clientId,
 		CustomerType:     ptrString("individual"),
-		TOSPolicies:      ptrString(uuid.New().String()),
+		TOSPolicies:      ptrString(r.TOSPolicyID),
 		USDEnable:        nil,
 		KYCStatus:        ptrString("INCOMPLETE"),
 		VerificationType: *r.VerificationType,
@@ -72,6 +92,7 @@ func (r *CreateCustomerRequest) GetPerson(basicInfo *BasicInfo) *models.Person {
 	}
 	return &models.Person{
 		CustomerID:         ptrString(uuid.New().String()),
+		Role:               utils.PERSON_ROLE_PRIMARY,
 		FirstName:          ptrString(basicInfo.FirstName),
 		LastName:           ptrString(basicInfo.LastName),
 		DOB:                dob,
@@ -80,101 +101,155 @@ func (r *CreateCustomerRequest) GetPerson(basicInfo *BasicInfo) *models.Person {
 		CountryOfResidence: ptrString(basicInfo.CountryOfResidence),
 		Nationality:        ptrString(basicInfo.Nationality),
 		TIN:                ptrString(basicInfo.TIN),
+		OccupationID:       r.FinancialProfile.OccupationID,
+		SourceOfFundsID:    r.FinancialProfile.SourceOfFundID,
+		SOFDescription:     ptrString(r.FinancialProfile.SOFDescription),
+		PurposeID:          r.FinancialProfile.PurposeID,
+		MonthlyVolumeUSD:   &r.FinancialProfile.MonthlyVolumeUSD,
 	}
 }
 
-func (r *CreateCustomerRequest) GetAddress(addressInfo *AddressInfo) *models.Address {
-	return &models.Address{
-		Street:     ptrString(addressInfo.Street),
-		City:       ptrString(addressInfo.City),
-		State:      ptrString(addressInfo.State),
-		PostalCode: ptrString(addressInfo.PostalCode),
-		Country:    ptrString(addressInfo.Country),
-	}
+// GetTOSAcceptance records the acceptance of the terms of service version sent with
+// the request.
+func (r *CreateCustomerRequest) GetTOSAcceptance(clientId, ipAddress, userAgent string) *models.TOSAcceptance {
+	return NewTOSAcceptance(clientId, r.TOSPolicyID, ipAddress, userAgent)
 }
 
-// ptrString returns a pointer to the given string.
-func ptrString(s string) *string {
-	return &s
+func (r *CreateCustomerRequest) GetAddress(addressInfo *AddressInfo) *models.Address {
+	address := addressInfo.toModel()
+	address.Type = utils.ADDRESS_TYPE_RESIDENTIAL
+	address.IsPrimary = true
+	return address
 }
 
-func (req *CreateCustomerRequest) ValidateRequest() *string {
-	if *req.VerificationType != utils.VERIFICATION_TYPE_RELIANCE && *req.VerificationType != utils.VERIFICATION_TYPE_STANDARD {
-		return utils.StringPtr("Invalid verification type")
-	}
-
-	var basicInfo = req.BasicInfo
-
-	if !utils.IsValidName(basicInfo.FirstName) {
-		return utils.StringPtr("Invalid first name")
-	}
-
-	if !utils.IsValidName(basicInfo.LastName) {
-		return utils.StringPtr("Invalid last name")
-	}
+func (a *AddressInfo) toModel() *models.Address {
+	return &models.Address{
+		Street:     ptrString(a.Street),
+		City:       ptrString(a.City),
+		State:      ptrString(a.State),
+		StateCode:  stateCode(a.StateCode),
+		PostalCode: ptrString(a.PostalCode),
+		Country:    ptrString(a.Country),
 
-	if !utils.DateFieldValidation(basicInfo.DOB, "2006-01-02") {
-		return utils.StringPtr("Invalid date of birth")
+		NormalizedStreet:     ptrString(a.NormalizedStreet),
+		NormalizedCity:       ptrString(a.NormalizedCity),
+		NormalizedPostalCode: ptrString(a.NormalizedPostalCode),
 	}
+}
 
-	if !utils.IsEmailValid(basicInfo.Email) {
-		return utils.StringPtr("Invalid email address")
+func stateCode(code string) *string {
+	if code == "" {
+		return nil
 	}
+	return &code
+}
 
-	_, err := utils.ValidateAndNormalizePhone(basicInfo.Phone, "")
-	if err != nil {
-		return utils.StringPtr("Invalid phone number")
-	}
+// ptrString returns a pointer to the given string.
+func ptrString(s string) *string {
+	return &s
+}
 
-	if strings.TrimSpace(basicInfo.CountryOfResidence) == " " {
-		return utils.StringPtr("Invalid country of residence")
+// Validate checks every field of the request and reports all failures keyed by their
+// JSON path. Valid fields are written back in their normalized form.
+func (req *CreateCustomerRequest) Validate() utils.Validation {
+	v := utils.NewValidationError()
+	errs := map[string][]string{}
+
+	if req.VerificationType == nil || strings.TrimSpace(*req.VerificationType) == "" {
+		errs["verification_type"] = append(errs["verification_type"], utils.RequiredCode())
+	} else {
+		verificationType := strings.ToUpper(strings.TrimSpace(*req.VerificationType))
+		if verificationType != utils.VERIFICATION_TYPE_RELIANCE && verificationType != utils.VERIFICATION_TYPE_STANDARD {
+			errs["verification_type"] = append(errs["verification_type"], utils.InvalidValueCode())
+		}
+		req.VerificationType = &verificationType
+	}
+
+	// Whether an omitted version is allowed is up to the TOS config.
+	req.TOSPolicyID = strings.TrimSpace(req.TOSPolicyID)
+	if req.TOSPolicyID != "" {
+		validateTOSPolicyID(req.TOSPolicyID, errs, "tos_policy_id")
+	}
+
+	b := &req.BasicInfo
+	b.FirstName = utils.ValidateRequiredName(b.FirstName, "first_name", 1, maxNameLength, nameRegex, errs, "basic_info.first_name")
+	b.LastName = utils.ValidateRequiredName(b.LastName, "last_name", 1, maxNameLength, nameRegex, errs, "basic_info.last_name")
+	if strings.TrimSpace(b.DOB) == "" {
+		errs["basic_info.dob"] = append(errs["basic_info.dob"], utils.RequiredCode())
+	} else {
+		b.DOB = utils.ValidateOptionalDateOfBirth(b.DOB, minCustomerAge, maxCustomerAge, errs, "basic_info.dob")
+	}
+	b.Email = utils.ValidateEmail(b.Email, "email", errs, "basic_info.email")
+	b.Phone = utils.ValidatePhone(b.Phone, "", errs, "basic_info.phone")
+	b.CountryOfResidence = utils.ValidateCountryCode(b.CountryOfResidence, "country_of_residence", errs, "basic_info.country_of_residence", countryCodeRegex)
+	b.Nationality = utils.ValidateCountryCode(b.Nationality, "nationality", errs, "basic_info.nationality", countryCodeRegex)
+	b.TIN = utils.ValidateTIN(b.TIN, b.CountryOfResidence, errs, "basic_info.tin")
+
+	// The onboarding address is the primary residential one, so PO boxes are rejected.
+	req.Address.validate(true, errs, "address.")
+
+	f := &req.FinancialProfile
+	validateRequiredID(f.OccupationID, utils.InvalidOccupationId(), errs, "financial_profile.occupation_id")
+	validateRequiredID(f.SourceOfFundID, utils.InvalidSourceOfFundId(), errs, "financial_profile.source_of_fund_id")
+	validateRequiredID(f.PurposeID, utils.InvalidPurposeId(), errs, "financial_profile.purpose_id")
+	f.MonthlyVolumeUSD = utils.ValidateMonthlyVolumeUSD(f.MonthlyVolumeUSD, maxMonthlyVolumeUSD, errs, "financial_profile.monthly_volume_usd")
+	f.SOFDescription = utils.ValidateRequiredString(f.SOFDescription, "sof_description", 1, 500, errs, "financial_profile.sof_description")
+
+	req.MetaData.Reference = utils.ValidateRequiredString(req.MetaData.Reference, "reference", 1, 100, errs, "meta_data.reference")
+
+	if len(errs) > 0 {
+		v.Status = true
+		v.Response = utils.ErrorResponse{}
+		for path, codes := range errs {
+			v.Response.Add(path, codes)
+		}
 	}
+	return v
+}
 
-	if strings.TrimSpace(basicInfo.Nationality) == " " {
-		return utils.StringPtr("Invalid nationality")
-	}
-	if strings.TrimSpace(basicInfo.TIN) == " " {
-		return utils.StringPtr("Invalid TIN")
+// validate checks the address fields under prefix. Street, city and postal code keep
+// the raw input; the normalized forms are stored alongside.
+func (a *AddressInfo) validate(rejectPoBox bool, errs map[string][]string, prefix string) {
+	poBoxRegex := utils.PoBoxRegex
+	if !rejectPoBox {
+		poBoxRegex = nil
+	}
+	utils.ValidateRequiredStreet(a.Street, "street", 3, 255, poBoxRegex, errs, prefix+"street")
+	utils.ValidateRequiredCity(a.City, "city", 1, 100, errs, prefix+"city")
+	a.State = utils.ValidateRequiredString(a.State, "state", 1, 100, errs, prefix+"state")
+	utils.ValidateRequiredRegexField(a.PostalCode, "postal_code", postalCodeRegex, utils.InvalidPostalCodeCode(), errs, prefix+"postal_code")
+	a.Country = utils.ValidateCountryCode(a.Country, "country", errs, prefix+"country", countryCodeRegex)
+	a.NormalizedStreet = utils.NormalizeStreet(a.Street, a.Country)
+	a.NormalizedCity = utils.NormalizeAddressCity(a.City)
+	a.NormalizedPostalCode = utils.NormalizePostalCode(a.PostalCode)
+	if len(errs[prefix+"country"]) == 0 {
+		a.StateCode = validateSubdivision(a.State, a.Country, errs, prefix+"state")
+		if len(errs[prefix+"postal_code"]) == 0 && !utils.IsPostalCodeValid(a.Country, a.PostalCode) {
+			errs[prefix+"postal_code"] = append(errs[prefix+"postal_code"], utils.InvalidPostalCodeCode())
+		}
 	}
+}
 
-	var address = req.Address
-
-	if strings.TrimSpace(address.Street) == " " {
-		return utils.StringPtr("Invalid street")
-	}
-	if strings.TrimSpace(address.City) == " " {
-		return utils.StringPtr("Invalid city")
-	}
-	if strings.TrimSpace(address.State) == " " {
-		return utils.StringPtr("Invalid state")
+// validateSubdivision resolves the state to its ISO 3166-2 code for countries with
+// subdivision data and rejects unknown subdivisions.
+func validateSubdivision(state, country string, errs map[string][]string, fieldPath string) string {
+	if state == "" || !utils.HasSubdivisionData(country) {
+		return ""
 	}
-	if strings.TrimSpace(address.PostalCode) == " " {
-		return utils.StringPtr("Invalid postal code")
-	}
-	if strings.TrimSpace(address.Country) == " " {
-		return utils.StringPtr("Invalid country")
+	code, ok := utils.NormalizeSubdivision(country, state)
+	if !ok {
+		errs[fieldPath] = append(errs[fieldPath], utils.InvalidSubdivisionCode())
+		return ""
 	}
+	return code
+}
 
-	var financialProfile = req.FinancialProfile
-	if financialProfile.OccupationID == nil || *financialProfile.OccupationID <= 0 {
-		return utils.StringPtr("Invalid occupation ID")
+func validateRequiredID(id *int, invalidCode string, errs map[string][]string, fieldPath string) {
+	if id == nil {
+		errs[fieldPath] = append(errs[fieldPath], utils.RequiredCode())
+		return
 	}
-	if financialProfile.SourceOfFundID == nil || *financialProfile.SourceOfFundID <= 0 {
-		return utils.StringPtr("Invalid source of fund ID")
-	}
-	if financialProfile.PurposeID == nil || *financialProfile.PurposeID <= 0 {
-		return utils.StringPtr("Invalid purpose ID")
-	}
-	if financialProfile.MonthlyVolumeUSD <= 0 {
-		return utils.StringPtr("Invalid monthly volume in USD")
-	}
-	if strings.TrimSpace(financialProfile.SOFDescription) == " " {
-		return utils.StringPtr("Invalid source of fund description")
-	}
-
-	var metaData = req.MetaData
-	if strings.TrimSpace(metaData.Reference) == " " {
-		return utils.StringPtr("Invalid reference in meta data")
+	if *id <= 0 {
+		errs[fieldPath] = append(errs[fieldPath], invalidCode)
 	}
-	return nil
 }
@@ -0,0 +1,174 @@
+package dto
+
+import (
+	"fin-auth/models"
+	"fin-auth/utils"
+	"time"
+)
+
+// PersonRes is the person as returned by the API. Email, phone, DOB and TIN are masked
+// unless the caller asked for, and is allowed, unmasked PII.
+type PersonRes struct {
+	ID                 int        `json:"id"`
+	CustomerID         *string    `json:"customer_id"`
+	Role               string     `json:"role"`
+	FirstName          *string    `json:"first_name"`
+	LastName           *string    `json:"last_name"`
+	Email              *string    `json:"email"`
+	Phone              *string    `json:"phone"`
+	EmailVerifiedAt    *time.Time `json:"email_verified_at"`
+	PhoneVerifiedAt    *time.Time `json:"phone_verified_at"`
+	DOB                *string    `json:"dob"`
+	CountryOfResidence *string    `json:"country_of_residence"`
+	Nationality        *string    `json:"nationality"`
+	TIN                *string    `json:"tin"`
+	Occupation         *string    `json:"occupation"`
+	OccupationID       *int       `json:"occupation_id"`
+	SourceOfFundsID    *int       `json:"source_of_funds_id"`
+	SourceOfFunds      *string    `json:"source_of_funds"`
+	SOFDescription     *string    `json:"sof_description"`
+	PurposeID          *int       `json:"purpose_id"`
+	Purpose            *string    `json:"purpose"`
+	MonthlyVolumeUSD   *float64   `json:"monthly_volume_usd"`
+	AddressID          *int       `json:"address_id"`
+	OwnershipPercent   *float64   `json:"ownership_percent"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+// AddressRes is an address as returned by the API. Street, city and postal code are
+// masked, and the normalized forms left out, unless the caller asked for, and is
+// allowed, unmasked PII.
+type AddressRes struct {
+	ID                   int        `json:"id"`
+	CustomerID           *string    `json:"customer_id"`
+	Type                 string     `json:"type"`
+	IsPrimary            bool       `json:"is_primary"`
+	ValidFrom            time.Time  `json:"valid_from"`
+	ValidTo              *time.Time `json:"valid_to"`
+	PreviousID           *int       `json:"previous_id"`
+	Street               *string    `json:"street"`
+	City                 *string    `json:"city"`
+	State                *string    `json:"state"`
+	StateCode            *string    `json:"state_code"`
+	PostalCode           *string    `json:"postal_code"`
+	Country              *string    `json:"country"`
+	NormalizedStreet     *string    `json:"normalized_street,omitempty"`
+	NormalizedCity       *string    `json:"normalized_city,omitempty"`
+	NormalizedPostalCode *string    `json:"normalized_postal_code,omitempty"`
+	CreatedAt            time.Time  `json:"created_at"`
+	UpdatedAt            time.Time  `json:"updated_at"`
+}
+
+// CustomerRes is a customer with its person and address as returned by the API.
+type CustomerRes struct {
+	Customer *models.Customer `json:"customer"`
+	Person   *PersonRes       `json:"person"`
+	Address  *AddressRes      `json:"address"`
+	Masked   bool             `json:"masked"`
+}
+
+func NewCustomerRes(customer *models.Customer, person *models.Person, address *models.Address, unmasked bool) *CustomerRes {
+	return &CustomerRes{
+		Customer: customer,
+		Person:   NewPersonRes(person, unmasked),
+		Address:  NewAddressRes(address, unmasked),
+		Masked:   !unmasked,
+	}
+}
+
+func NewAddressRes(a *models.Address, unmasked bool) *AddressRes {
+	if a == nil {
+		return nil
+	}
+	res := &AddressRes{
+		ID:         a.ID,
+		CustomerID: a.CustomerID,
+		Type:       a.Type,
+		IsPrimary:  a.IsPrimary,
+		ValidFrom:  a.ValidFrom,
+		ValidTo:    a.ValidTo,
+		PreviousID: a.PreviousID,
+		Street:     a.Street,
+		City:       a.City,
+		State:      a.State,
+		StateCode:  a.StateCode,
+		PostalCode: a.PostalCode,
+		Country:    a.Country,
+		CreatedAt:  a.CreatedAt,
+		UpdatedAt:  a.UpdatedAt,
+	}
+	if unmasked {
+		res.NormalizedStreet = a.NormalizedStreet
+		res.NormalizedCity = a.NormalizedCity
+		res.NormalizedPostalCode = a.NormalizedPostalCode
+		return res
+	}
+
+	if a.Street != nil {
+		res.Street = utils.StringPtr(utils.MaskText(*a.Street))
+	}
+	if a.City != nil {
+		res.City = utils.StringPtr(utils.MaskText(*a.City))
+	}
+	if a.PostalCode != nil {
+		res.PostalCode = utils.StringPtr(utils.MaskText(*a.PostalCode))
+	}
+	return res
+}
+
+func NewPersonRes(p *models.Person, unmasked bool) *PersonRes {
+	if p == nil {
+		return nil
+	}
+	res := &PersonRes{
+		ID:                 p.ID,
+		CustomerID:         p.CustomerID,
+		Role:               p.Role,
+		FirstName:          p.FirstName,
+		LastName:           p.LastName,
+		Email:              p.Email,
+		Phone:              p.Phone,
+		EmailVerifiedAt:    p.EmailVerifiedAt,
+		PhoneVerifiedAt:    p.PhoneVerifiedAt,
+		CountryOfResidence: p.CountryOfResidence,
+		Nationality:        p.Nationality,
+		TIN:                p.TIN,
+		Occupation:         p.Occupation,
+		OccupationID:       p.OccupationID,
+		SourceOfFundsID:    p.SourceOfFundsID,
+		SourceOfFunds:      p.SourceOfFunds,
+		SOFDescription:     p.SOFDescription,
+		PurposeID:          p.PurposeID,
+		Purpose:            p.Purpose,
+		MonthlyVolumeUSD:   p.MonthlyVolumeUSD,
+		AddressID:          p.AddressID,
+		OwnershipPercent:   p.OwnershipPercent,
+		CreatedAt:          p.CreatedAt,
+		UpdatedAt:          p.UpdatedAt,
+	}
+	if p.DOB != nil {
+		res.DOB = utils.StringPtr(p.DOB.Format("2006-01-02"))
+	}
+	if unmasked {
+		return res
+	}
+
+	if p.Email != nil {
+		res.Email = utils.StringPtr(utils.MaskEmail(*p.Email))
+	}
+	if p.Phone != nil {
+		res.Phone = utils.StringPtr(utils.MaskPhone(*p.Phone))
+	}
+	if p.DOB != nil {
+		res.DOB = utils.StringPtr(utils.MaskDate(*p.DOB))
+	}
+	if p.TIN != nil {
+		country := ""
+		if p.CountryOfResidence != nil {
+			country = *p.CountryOfResidence
+		}
+		res.TIN = utils.StringPtr(utils.MaskTIN(*p.TIN, country))
+	}
+	return res
+}
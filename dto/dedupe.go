@@ -0,0 +1,23 @@
+package dto
+
+import "time"
+
+// DuplicateMatch is an existing customer sharing an identity field with a new one.
+type DuplicateMatch struct {
+	Field      string  `json:"field"`
+	Scope      string  `json:"scope"`
+	Action     string  `json:"action"`
+	CustomerID string  `json:"customer_id"`
+	ClientID   *string `json:"client_id"`
+}
+
+// DuplicateCandidateRes is a candidate seen from one customer. The other customer's id
+// is only shown when it belongs to the same client.
+type DuplicateCandidateRes struct {
+	ID              int       `json:"id"`
+	Field           string    `json:"field"`
+	Scope           string    `json:"scope"`
+	OtherCustomerID *string   `json:"other_customer_id"`
+	SameClient      bool      `json:"same_client"`
+	CreatedAt       time.Time `json:"created_at"`
+}
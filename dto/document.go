@@ -0,0 +1,72 @@
+package dto
+
+import (
+	"fin-auth/models"
+	"fin-auth/utils"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type UploadDocumentReq struct {
+	Type    string `form:"type"`
+	Side    string `form:"side"`
+	Country string `form:"country"`
+}
+
+type DocumentRes struct {
+	*models.Document
+	URL          string           `json:"url"`
+	URLExpiresAt time.Time        `json:"url_expires_at"`
+	CountryCheck *CountryCheckRes `json:"country_check,omitempty"`
+}
+
+// CountryCheckRes reports how a proof-of-address country compared with the customer's profile.
+type CountryCheckRes struct {
+	Country    string            `json:"country"`
+	Passed     bool              `json:"passed"`
+	Mismatches []CountryMismatch `json:"mismatches,omitempty"`
+}
+
+type CountryMismatch struct {
+	Field    string `json:"field"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+}
+
+func (r *UploadDocumentReq) Validate() utils.Validation {
+	v := utils.NewValidationError()
+	errs := utils.ErrorResponse{}
+
+	r.Type = strings.ToUpper(strings.TrimSpace(r.Type))
+	r.Side = strings.ToUpper(strings.TrimSpace(r.Side))
+	r.Country = strings.ToUpper(strings.TrimSpace(r.Country))
+
+	if !utils.InArrayString(r.Type, utils.DocumentTypes) {
+		errs.Add("type", fmt.Sprintf(utils.InvalidChoiceFmt, "type", utils.DocumentTypes))
+		v.Status = true
+	}
+
+	if r.Side == "" {
+		r.Side = utils.DOCUMENT_SIDE_FRONT
+	}
+	switch {
+	case r.Side != utils.DOCUMENT_SIDE_FRONT && r.Side != utils.DOCUMENT_SIDE_BACK:
+		errs.Add("side", fmt.Sprintf(utils.InvalidChoiceFmt, "side", []string{utils.DOCUMENT_SIDE_FRONT, utils.DOCUMENT_SIDE_BACK}))
+		v.Status = true
+	case r.Side == utils.DOCUMENT_SIDE_BACK && (r.Type == utils.DOCUMENT_TYPE_PASSPORT || r.Type == utils.DOCUMENT_TYPE_PROOF_OF_ADDRESS):
+		errs.Add("side", utils.NotAllowed())
+		v.Status = true
+	}
+
+	if r.Country == "" && r.Type == utils.DOCUMENT_TYPE_PROOF_OF_ADDRESS {
+		errs.Add("country", utils.RequiredCode())
+		v.Status = true
+	} else if r.Country != "" && !utils.IsISOAlpha3Format(r.Country) {
+		errs.Add("country", utils.InvalidCountryCodeCode())
+		v.Status = true
+	}
+
+	v.Response = errs
+	return v
+}
@@ -0,0 +1,115 @@
+package dto
+
+import (
+	"fin-auth/utils"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type JurisdictionRestrictionReq struct {
+	Partner       string  `json:"partner"`
+	Program       *string `json:"program"`
+	Country       string  `json:"country"`
+	Subdivision   *string `json:"subdivision"`
+	Category      string  `json:"category"`
+	Reason        *string `json:"reason"`
+	EffectiveFrom string  `json:"effective_from"`
+	EffectiveTo   *string `json:"effective_to"`
+
+	EffectiveFromTime time.Time  `json:"-"`
+	EffectiveToTime   *time.Time `json:"-"`
+}
+
+// JurisdictionTarget is one onboarding field to check against the restrictions.
+// SubdivisionField names the field reported for subdivision-level hits.
+type JurisdictionTarget struct {
+	Field            string
+	SubdivisionField string
+	Country          string
+	Subdivision      string
+}
+
+type JurisdictionHit struct {
+	Field         string  `json:"field"`
+	Country       string  `json:"country"`
+	Subdivision   *string `json:"subdivision,omitempty"`
+	Category      string  `json:"category"`
+	RestrictionID int     `json:"restriction_id"`
+}
+
+var jurisdictionCategories = []string{utils.JURISDICTION_PROHIBITED, utils.JURISDICTION_CONTROLLED}
+
+func (r *JurisdictionRestrictionReq) Validate() utils.Validation {
+	v := utils.NewValidationError()
+	errs := utils.ErrorResponse{}
+
+	r.Partner = strings.ToLower(strings.TrimSpace(r.Partner))
+	r.Country = strings.ToUpper(strings.TrimSpace(r.Country))
+	r.Category = strings.ToUpper(strings.TrimSpace(r.Category))
+
+	if !utils.StringFiledValidation(r.Partner, 1, 50) {
+		errs.Add("partner", utils.ErrorMessage("partner"))
+		v.Status = true
+	}
+
+	if r.Program != nil && !utils.StringFiledValidation(*r.Program, 1, 100) {
+		errs.Add("program", utils.ErrorMessage("program"))
+		v.Status = true
+	}
+
+	if !utils.IsISOAlpha3Format(r.Country) {
+		errs.Add("country", utils.InvalidCountryCodeCode())
+		v.Status = true
+	}
+
+	if r.Subdivision != nil {
+		code, ok := utils.NormalizeSubdivision(r.Country, *r.Subdivision)
+		if !ok {
+			errs.Add("subdivision", utils.InvalidSubdivisionCode())
+			v.Status = true
+		}
+		r.Subdivision = &code
+	}
+
+	if !utils.InArrayString(r.Category, jurisdictionCategories) {
+		errs.Add("category", fmt.Sprintf(utils.InvalidChoiceFmt, "category", jurisdictionCategories))
+		v.Status = true
+	}
+
+	if r.EffectiveFrom == "" {
+		r.EffectiveFromTime = utils.CurrentTime()
+	} else if t, ok := parseTimestamp(r.EffectiveFrom); ok {
+		r.EffectiveFromTime = t
+	} else {
+		errs.Add("effective_from", utils.InvalidDateCode())
+		v.Status = true
+	}
+
+	if r.EffectiveTo != nil {
+		t, ok := parseTimestamp(*r.EffectiveTo)
+		switch {
+		case !ok:
+			errs.Add("effective_to", utils.InvalidDateCode())
+			v.Status = true
+		case !t.After(r.EffectiveFromTime):
+			errs.Add("effective_to", utils.InvalidValueCode())
+			v.Status = true
+		default:
+			r.EffectiveToTime = &t
+		}
+	}
+
+	v.Response = errs
+	return v
+}
+
+// parseTimestamp accepts RFC 3339, which is how restrictions are rendered, as well as
+// the repo-wide DateTimeLayout.
+func parseTimestamp(value string) (time.Time, bool) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), true
+	}
+	ok, t := utils.IsValidateDateTime(value)
+	return t, ok
+}
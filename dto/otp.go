@@ -0,0 +1,74 @@
+package dto
+
+import (
+	"fin-auth/utils"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var otpCodeRegex = regexp.MustCompile(`^[0-9]{4,10}$`)
+
+type OTPSendReq struct {
+	Channel string `json:"channel"`
+}
+
+func (r *OTPSendReq) Validate() utils.Validation {
+	v := utils.NewValidationError()
+	errs := map[string][]string{}
+
+	r.Channel = validateOTPChannel(r.Channel, errs, "channel")
+
+	if len(errs) > 0 {
+		v.Status = true
+		v.Response = utils.ErrorResponse{}
+		for path, codes := range errs {
+			v.Response.Add(path, codes)
+		}
+	}
+	return v
+}
+
+type OTPConfirmReq struct {
+	Channel string `json:"channel"`
+	Code    string `json:"code"`
+}
+
+func (r *OTPConfirmReq) Validate() utils.Validation {
+	v := utils.NewValidationError()
+	errs := map[string][]string{}
+
+	r.Channel = validateOTPChannel(r.Channel, errs, "channel")
+	r.Code = strings.TrimSpace(r.Code)
+	if r.Code == "" {
+		errs["code"] = append(errs["code"], utils.RequiredCode())
+	} else if !otpCodeRegex.MatchString(r.Code) {
+		errs["code"] = append(errs["code"], utils.InvalidOTPCode())
+	}
+
+	if len(errs) > 0 {
+		v.Status = true
+		v.Response = utils.ErrorResponse{}
+		for path, codes := range errs {
+			v.Response.Add(path, codes)
+		}
+	}
+	return v
+}
+
+// OTPSendRes tells where a code went, masked, and until when it can be used.
+type OTPSendRes struct {
+	Channel     string    `json:"channel"`
+	Destination string    `json:"destination"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+func validateOTPChannel(channel string, errs map[string][]string, fieldPath string) string {
+	channel = strings.ToLower(strings.TrimSpace(channel))
+	if channel == "" {
+		errs[fieldPath] = append(errs[fieldPath], utils.RequiredCode())
+	} else if !utils.StringInSlice(channel, utils.OTPChannels) {
+		errs[fieldPath] = append(errs[fieldPath], utils.InvalidValueCode())
+	}
+	return channel
+}
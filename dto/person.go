@@ -0,0 +1,250 @@
+package dto
+
+import (
+	"fin-auth/models"
+	"fin-auth/utils"
+	"strings"
+	"time"
+)
+
+// PersonPatchReq changes some fields of a person. Omitted fields keep their value;
+// fields that are sent may not be cleared.
+type PersonPatchReq struct {
+	FirstName          *string  `json:"first_name"`
+	LastName           *string  `json:"last_name"`
+	DOB                *string  `json:"dob"`
+	Email              *string  `json:"email"`
+	Phone              *string  `json:"phone"`
+	CountryOfResidence *string  `json:"country_of_residence"`
+	Nationality        *string  `json:"nationality"`
+	TIN                *string  `json:"tin"`
+	OccupationID       *int     `json:"occupation_id"`
+	SourceOfFundID     *int     `json:"source_of_fund_id"`
+	PurposeID          *int     `json:"purpose_id"`
+	MonthlyVolumeUSD   *float64 `json:"monthly_volume_usd"`
+	SOFDescription     *string  `json:"sof_description"`
+	OwnershipPercent   *float64 `json:"ownership_percent"`
+
+	DOBTime *time.Time `json:"-"`
+}
+
+// Validate checks the fields that were sent. The TIN is only normalized here: its rules
+// depend on the country of residence, which may come from the stored person, see Apply.
+func (r *PersonPatchReq) Validate() utils.Validation {
+	v := utils.NewValidationError()
+	errs := map[string][]string{}
+
+	if r.FirstName != nil {
+		*r.FirstName = utils.ValidateRequiredName(*r.FirstName, "first_name", 1, maxNameLength, nameRegex, errs, "first_name")
+	}
+	if r.LastName != nil {
+		*r.LastName = utils.ValidateRequiredName(*r.LastName, "last_name", 1, maxNameLength, nameRegex, errs, "last_name")
+	}
+	if r.DOB != nil {
+		r.DOBTime = validateDOB(*r.DOB, errs, "dob")
+	}
+	if r.Email != nil {
+		*r.Email = utils.ValidateEmail(*r.Email, "email", errs, "email")
+	}
+	if r.Phone != nil {
+		*r.Phone = utils.ValidatePhone(*r.Phone, "", errs, "phone")
+	}
+	if r.CountryOfResidence != nil {
+		*r.CountryOfResidence = utils.ValidateCountryCode(*r.CountryOfResidence, "country_of_residence", errs, "country_of_residence", countryCodeRegex)
+	}
+	if r.Nationality != nil {
+		*r.Nationality = utils.ValidateCountryCode(*r.Nationality, "nationality", errs, "nationality", countryCodeRegex)
+	}
+	if r.TIN != nil {
+		if strings.TrimSpace(*r.TIN) == "" {
+			errs["tin"] = append(errs["tin"], utils.RequiredCode())
+		}
+		*r.TIN = utils.NormalizeTIN(*r.TIN)
+	}
+	if r.OccupationID != nil {
+		validateRequiredID(r.OccupationID, utils.InvalidOccupationId(), errs, "occupation_id")
+	}
+	if r.SourceOfFundID != nil {
+		validateRequiredID(r.SourceOfFundID, utils.InvalidSourceOfFundId(), errs, "source_of_fund_id")
+	}
+	if r.PurposeID != nil {
+		validateRequiredID(r.PurposeID, utils.InvalidPurposeId(), errs, "purpose_id")
+	}
+	if r.MonthlyVolumeUSD != nil {
+		*r.MonthlyVolumeUSD = utils.ValidateMonthlyVolumeUSD(*r.MonthlyVolumeUSD, maxMonthlyVolumeUSD, errs, "monthly_volume_usd")
+	}
+	if r.SOFDescription != nil {
+		*r.SOFDescription = utils.ValidateRequiredString(*r.SOFDescription, "sof_description", 1, 500, errs, "sof_description")
+	}
+	if r.OwnershipPercent != nil {
+		validateOwnership(r.OwnershipPercent, false, errs, "ownership_percent")
+	}
+
+	if len(errs) > 0 {
+		v.Status = true
+		v.Response = utils.ErrorResponse{}
+		for path, codes := range errs {
+			v.Response.Add(path, codes)
+		}
+	}
+	return v
+}
+
+// Apply writes the request onto the person and returns the names of the fields whose
+// value changed. A changed email or phone loses its verification. When the TIN or the
+// country of residence changes, the TIN is checked again against the resulting country.
+func (r *PersonPatchReq) Apply(p *models.Person) ([]string, utils.FieldErrors) {
+	var changed []string
+	setString := func(field string, dst **string, value *string) {
+		if value != nil && utils.SafeString(*dst) != *value {
+			*dst = utils.StringPtr(*value)
+			changed = append(changed, field)
+		}
+	}
+	setInt := func(field string, dst **int, value *int) {
+		if value != nil && (*dst == nil || **dst != *value) {
+			*dst = value
+			changed = append(changed, field)
+		}
+	}
+	setFloat := func(field string, dst **float64, value *float64) {
+		if value != nil && (*dst == nil || **dst != *value) {
+			*dst = value
+			changed = append(changed, field)
+		}
+	}
+
+	setString("first_name", &p.FirstName, r.FirstName)
+	setString("last_name", &p.LastName, r.LastName)
+	if r.DOBTime != nil && (p.DOB == nil || !p.DOB.Equal(*r.DOBTime)) {
+		p.DOB = r.DOBTime
+		changed = append(changed, "dob")
+	}
+	setString("email", &p.Email, r.Email)
+	setString("phone", &p.Phone, r.Phone)
+	setString("country_of_residence", &p.CountryOfResidence, r.CountryOfResidence)
+	setString("nationality", &p.Nationality, r.Nationality)
+	setString("tin", &p.TIN, r.TIN)
+	setInt("occupation_id", &p.OccupationID, r.OccupationID)
+	setInt("source_of_fund_id", &p.SourceOfFundsID, r.SourceOfFundID)
+	setInt("purpose_id", &p.PurposeID, r.PurposeID)
+	setFloat("monthly_volume_usd", &p.MonthlyVolumeUSD, r.MonthlyVolumeUSD)
+	setString("sof_description", &p.SOFDescription, r.SOFDescription)
+	setFloat("ownership_percent", &p.OwnershipPercent, r.OwnershipPercent)
+
+	// A new address has to be verified again.
+	if utils.InArrayString("email", changed) {
+		p.EmailVerifiedAt = nil
+	}
+	if utils.InArrayString("phone", changed) {
+		p.PhoneVerifiedAt = nil
+	}
+	if utils.InArrayString("tin", changed) || utils.InArrayString("country_of_residence", changed) {
+		if code := utils.CheckTIN(utils.SafeString(p.TIN), utils.SafeString(p.CountryOfResidence)); code != "" {
+			return changed, utils.FieldErrors{"tin": {code}}
+		}
+	}
+	return changed, nil
+}
+
+// AssociatedPersonReq adds a beneficial owner, director, control person or authorized
+// signer to a business customer. Email and phone are optional for them.
+type AssociatedPersonReq struct {
+	Role               string   `json:"role"`
+	FirstName          string   `json:"first_name"`
+	LastName           string   `json:"last_name"`
+	DOB                string   `json:"dob"`
+	Email              string   `json:"email"`
+	Phone              string   `json:"phone"`
+	CountryOfResidence string   `json:"country_of_residence"`
+	Nationality        string   `json:"nationality"`
+	TIN                string   `json:"tin"`
+	OwnershipPercent   *float64 `json:"ownership_percent"`
+
+	DOBTime *time.Time `json:"-"`
+}
+
+func (r *AssociatedPersonReq) Validate() utils.Validation {
+	v := utils.NewValidationError()
+	errs := map[string][]string{}
+
+	r.Role = strings.ToUpper(strings.TrimSpace(r.Role))
+	if r.Role == "" {
+		errs["role"] = append(errs["role"], utils.RequiredCode())
+	} else if !utils.StringInSlice(r.Role, utils.AssociatedPersonRoles) {
+		errs["role"] = append(errs["role"], utils.InvalidValueCode())
+	}
+	r.FirstName = utils.ValidateRequiredName(r.FirstName, "first_name", 1, maxNameLength, nameRegex, errs, "first_name")
+	r.LastName = utils.ValidateRequiredName(r.LastName, "last_name", 1, maxNameLength, nameRegex, errs, "last_name")
+	r.DOBTime = validateDOB(r.DOB, errs, "dob")
+	if strings.TrimSpace(r.Email) != "" {
+		r.Email = utils.ValidateEmail(r.Email, "email", errs, "email")
+	}
+	if strings.TrimSpace(r.Phone) != "" {
+		r.Phone = utils.ValidatePhone(r.Phone, "", errs, "phone")
+	}
+	r.CountryOfResidence = utils.ValidateCountryCode(r.CountryOfResidence, "country_of_residence", errs, "country_of_residence", countryCodeRegex)
+	r.Nationality = utils.ValidateCountryCode(r.Nationality, "nationality", errs, "nationality", countryCodeRegex)
+	r.TIN = utils.ValidateTIN(r.TIN, r.CountryOfResidence, errs, "tin")
+	validateOwnership(r.OwnershipPercent, r.Role == utils.PERSON_ROLE_BENEFICIAL_OWNER, errs, "ownership_percent")
+
+	if len(errs) > 0 {
+		v.Status = true
+		v.Response = utils.ErrorResponse{}
+		for path, codes := range errs {
+			v.Response.Add(path, codes)
+		}
+	}
+	return v
+}
+
+func (r *AssociatedPersonReq) GetPerson(customerId string) *models.Person {
+	person := &models.Person{
+		CustomerID:         &customerId,
+		Role:               r.Role,
+		FirstName:          ptrString(r.FirstName),
+		LastName:           ptrString(r.LastName),
+		DOB:                r.DOBTime,
+		CountryOfResidence: ptrString(r.CountryOfResidence),
+		Nationality:        ptrString(r.Nationality),
+		TIN:                ptrString(r.TIN),
+		OwnershipPercent:   r.OwnershipPercent,
+	}
+	if r.Email != "" {
+		person.Email = ptrString(r.Email)
+	}
+	if r.Phone != "" {
+		person.Phone = ptrString(r.Phone)
+	}
+	return person
+}
+
+// validateDOB checks a required date of birth and returns it parsed when valid.
+func validateDOB(dob string, errs map[string][]string, fieldPath string) *time.Time {
+	if strings.TrimSpace(dob) == "" {
+		errs[fieldPath] = append(errs[fieldPath], utils.RequiredCode())
+		return nil
+	}
+	dob = utils.ValidateOptionalDateOfBirth(dob, minCustomerAge, maxCustomerAge, errs, fieldPath)
+	if len(errs[fieldPath]) > 0 {
+		return nil
+	}
+	parsed, err := time.Parse("2006-01-02", dob)
+	if err != nil {
+		return nil
+	}
+	return &parsed
+}
+
+// validateOwnership checks an ownership share, a percentage above 0 and at most 100.
+func validateOwnership(percent *float64, required bool, errs map[string][]string, fieldPath string) {
+	if percent == nil {
+		if required {
+			errs[fieldPath] = append(errs[fieldPath], utils.RequiredCode())
+		}
+		return
+	}
+	if *percent <= 0 || *percent > 100 {
+		errs[fieldPath] = append(errs[fieldPath], utils.OutOfRangeCode())
+	}
+}
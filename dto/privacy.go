@@ -0,0 +1,49 @@
+package dto
+
+import (
+	"fin-auth/models"
+	"time"
+)
+
+// DataSubjectExport is the archive of everything held about a customer.
+type DataSubjectExport struct {
+	RequestID           string                       `json:"request_id"`
+	ExportedAt          time.Time                    `json:"exported_at"`
+	Customer            *models.Customer             `json:"customer"`
+	Persons             []*models.Person             `json:"persons"`
+	Addresses           []*models.Address            `json:"addresses"`
+	Documents           []*ExportedDocument          `json:"documents"`
+	ReviewFlags         []*models.ReviewFlag         `json:"review_flags"`
+	ScreeningMatches    []*models.ScreeningMatch     `json:"screening_matches"`
+	DuplicateCandidates []*models.DuplicateCandidate `json:"duplicate_candidates"`
+	Events              []*models.OutboxEvent        `json:"events"`
+	PIIAccessLogs       []*models.PIIAccessLog       `json:"pii_access_logs"`
+	TOSAcceptances      []*models.TOSAcceptance      `json:"tos_acceptances"`
+	DataRequests        []*models.DataSubjectRequest `json:"data_requests"`
+}
+
+// ExportedDocument is a document's metadata with its file, base64 encoded in JSON.
+// Content is empty once the file has been erased.
+type ExportedDocument struct {
+	*models.Document
+	Content []byte `json:"content"`
+}
+
+// ErasureResult counts the rows and files touched by an erasure, keyed by table.
+type ErasureResult struct {
+	Counts          map[string]int64
+	IdempotencyKeys []string
+}
+
+// DataSubjectReceipt confirms a completed export or erasure.
+type DataSubjectReceipt struct {
+	RequestID     string           `json:"request_id"`
+	Type          string           `json:"type"`
+	CustomerID    string           `json:"customer_id"`
+	ClientID      *string          `json:"client_id"`
+	RequestedBy   string           `json:"requested_by"`
+	CompletedAt   time.Time        `json:"completed_at"`
+	ArchiveSHA256 string           `json:"archive_sha256,omitempty"`
+	Erased        map[string]int64 `json:"erased,omitempty"`
+	Retained      []string         `json:"retained,omitempty"`
+}
@@ -0,0 +1,24 @@
+package dto
+
+import "fin-auth/utils"
+
+type TINFormatsRes struct {
+	Country string          `json:"country"`
+	Formats []utils.TINRule `json:"formats"`
+}
+
+type ReferenceItemRes struct {
+	ID     int               `json:"id"`
+	Code   string            `json:"code"`
+	Label  string            `json:"label"`
+	Labels map[string]string `json:"labels"`
+}
+
+// ReferenceListRes is a catalog rendered in one language. ETag identifies the rendered
+// content so clients can revalidate with If-None-Match.
+type ReferenceListRes struct {
+	Catalog  string             `json:"catalog"`
+	Language string             `json:"language"`
+	Items    []ReferenceItemRes `json:"items"`
+	ETag     string             `json:"-"`
+}
@@ -34,3 +34,25 @@ func (r *RegisterClientReq) Validate() utils.Validation {
 	v.Response = errs
 	return v
 }
+
+// ClientScopesReq replaces a client's scopes. They apply from the next request, including
+// for tokens already issued.
+type ClientScopesReq struct {
+	Scopes []string `json:"scopes"`
+}
+
+func (r *ClientScopesReq) Validate() utils.Validation {
+	v := utils.NewValidationError()
+	errs := utils.ErrorResponse{}
+
+	for _, scope := range r.Scopes {
+		if !utils.StringInSlice(scope, utils.ClientScopes) {
+			errs.Add("scopes", utils.ErrorMessage("scopes"))
+			v.Status = true
+			break
+		}
+	}
+
+	v.Response = errs
+	return v
+}
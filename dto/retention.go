@@ -0,0 +1,15 @@
+package dto
+
+import "time"
+
+// RetentionResult is what one policy matched and purged during a run. Purged stays
+// zero on dry runs.
+type RetentionResult struct {
+	Entity     string    `json:"entity"`
+	RetainDays int       `json:"retain_days"`
+	Cutoff     time.Time `json:"cutoff"`
+	Matched    int64     `json:"matched"`
+	Purged     int64     `json:"purged"`
+	Files      int64     `json:"files"`
+	Error      *string   `json:"error,omitempty"`
+}
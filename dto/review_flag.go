@@ -0,0 +1,22 @@
+package dto
+
+import (
+	"fin-auth/models"
+	"fin-auth/utils"
+)
+
+// NewReviewFlag builds an open review flag. The customer id may be left empty when the
+// flag is stored together with a new customer.
+func NewReviewFlag(customerId, source, code string, details interface{}) (*models.ReviewFlag, error) {
+	data, err := utils.ToDatatypesJSON(details)
+	if err != nil {
+		return nil, err
+	}
+	return &models.ReviewFlag{
+		CustomerID: customerId,
+		Source:     source,
+		Code:       code,
+		Details:    data,
+		Status:     utils.REVIEW_FLAG_OPEN,
+	}, nil
+}
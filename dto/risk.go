@@ -0,0 +1,19 @@
+package dto
+
+import "time"
+
+// RiskFactor is one rule that contributed points to a risk score.
+type RiskFactor struct {
+	Code   string      `json:"code"`
+	Points int         `json:"points"`
+	Value  interface{} `json:"value,omitempty"`
+}
+
+type RiskAssessment struct {
+	Score                  int          `json:"score"`
+	Tier                   string       `json:"tier"`
+	Factors                []RiskFactor `json:"factors"`
+	VerificationType       string       `json:"verification_type"`
+	VerificationTypeForced bool         `json:"verification_type_forced"`
+	AssessedAt             time.Time    `json:"assessed_at"`
+}
@@ -0,0 +1,142 @@
+package dto
+
+import (
+	"fin-auth/models"
+	"fin-auth/utils"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const maxUserAgentLength = 255
+
+var (
+	tosVersionRegex = regexp.MustCompile(`^[0-9A-Za-z][0-9A-Za-z.-]{0,19}$`)
+	sha256Regex     = regexp.MustCompile(`^[0-9A-F]{64}$`)
+)
+
+// TOSPolicyReq publishes a terms of service version. EffectiveAt defaults to now and may
+// lie in the future; the first version always requires acceptance.
+type TOSPolicyReq struct {
+	Version              string `json:"version"`
+	Title                string `json:"title"`
+	DocumentURL          string `json:"document_url"`
+	DocumentSHA256       string `json:"document_sha256"`
+	RequiresReacceptance bool   `json:"requires_reacceptance"`
+	EffectiveAt          string `json:"effective_at"`
+
+	EffectiveAtTime time.Time `json:"-"`
+}
+
+func (r *TOSPolicyReq) Validate() utils.Validation {
+	v := utils.NewValidationError()
+	errs := map[string][]string{}
+
+	r.Version = utils.ValidateRequiredStringWithRegex(r.Version, "version", 1, 20, tosVersionRegex, errs, "version")
+	r.Title = utils.ValidateRequiredString(r.Title, "title", 1, 200, errs, "title")
+	r.DocumentURL = strings.TrimSpace(r.DocumentURL)
+	if code := utils.ValidateURL(r.DocumentURL, "document_url"); code != "" {
+		errs["document_url"] = append(errs["document_url"], code)
+	} else if len(r.DocumentURL) > 500 {
+		errs["document_url"] = append(errs["document_url"], utils.InvalidLengthCode())
+	}
+	r.DocumentSHA256 = strings.ToLower(utils.ValidateRequiredRegexField(r.DocumentSHA256, "document_sha256", sha256Regex, utils.InvalidFormatCode(), errs, "document_sha256"))
+
+	if r.EffectiveAt == "" {
+		r.EffectiveAtTime = utils.CurrentTime()
+	} else if t, ok := parseTimestamp(r.EffectiveAt); ok {
+		r.EffectiveAtTime = t
+	} else {
+		errs["effective_at"] = append(errs["effective_at"], utils.InvalidDateCode())
+	}
+
+	if len(errs) > 0 {
+		v.Status = true
+		v.Response = utils.ErrorResponse{}
+		for path, codes := range errs {
+			v.Response.Add(path, codes)
+		}
+	}
+	return v
+}
+
+func (r *TOSPolicyReq) GetPolicy() *models.TOSPolicy {
+	return &models.TOSPolicy{
+		Version:              r.Version,
+		Title:                r.Title,
+		DocumentURL:          r.DocumentURL,
+		DocumentSHA256:       r.DocumentSHA256,
+		RequiresReacceptance: r.RequiresReacceptance,
+		EffectiveAt:          r.EffectiveAtTime,
+	}
+}
+
+// TOSAcceptanceReq records a customer accepting the terms of service in effect.
+type TOSAcceptanceReq struct {
+	TOSPolicyID string `json:"tos_policy_id"`
+}
+
+func (r *TOSAcceptanceReq) Validate() utils.Validation {
+	v := utils.NewValidationError()
+	errs := map[string][]string{}
+
+	validateTOSPolicyID(r.TOSPolicyID, errs, "tos_policy_id")
+
+	if len(errs) > 0 {
+		v.Status = true
+		v.Response = utils.ErrorResponse{}
+		for path, codes := range errs {
+			v.Response.Add(path, codes)
+		}
+	}
+	return v
+}
+
+// NewTOSAcceptance describes an acceptance made now through the current request.
+func NewTOSAcceptance(clientId, policyId, ipAddress, userAgent string) *models.TOSAcceptance {
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+	acceptance := &models.TOSAcceptance{
+		PolicyID:   policyId,
+		ClientID:   &clientId,
+		AcceptedAt: utils.CurrentTime(),
+	}
+	if ipAddress != "" {
+		acceptance.IPAddress = &ipAddress
+	}
+	if userAgent != "" {
+		acceptance.UserAgent = &userAgent
+	}
+	return acceptance
+}
+
+// TOSReacceptanceReport lists a page of the customers who have not accepted the policy,
+// or a later version, since it was published. Total counts all of them.
+type TOSReacceptanceReport struct {
+	Policy      *models.TOSPolicy       `json:"policy"`
+	Total       int                     `json:"total"`
+	CurrentPage int                     `json:"current_page"`
+	PerPage     int                     `json:"per_page"`
+	TotalPages  int                     `json:"total_pages"`
+	Customers   []*TOSReacceptanceEntry `json:"customers"`
+}
+
+// TOSReacceptanceEntry is a customer who must re-accept, with the version it accepted
+// last, if any.
+type TOSReacceptanceEntry struct {
+	CustomerID       string     `json:"customer_id"`
+	AcceptedPolicyID *string    `json:"accepted_policy_id"`
+	AcceptedVersion  *string    `json:"accepted_version"`
+	AcceptedAt       *time.Time `json:"accepted_at"`
+}
+
+func validateTOSPolicyID(id string, errs map[string][]string, fieldPath string) {
+	if strings.TrimSpace(id) == "" {
+		errs[fieldPath] = append(errs[fieldPath], utils.RequiredCode())
+		return
+	}
+	if !utils.IsValidUUID(id) {
+		errs[fieldPath] = append(errs[fieldPath], utils.InvalidFormatCode())
+	}
+}
@@ -0,0 +1,83 @@
+package dto
+
+import (
+	"fin-auth/models"
+	"fin-auth/utils"
+	"fmt"
+)
+
+type CreateWebhookSubscriptionReq struct {
+	URL         string   `json:"url"`
+	EventTypes  []string `json:"event_types"`
+	Description string   `json:"description"`
+}
+
+type UpdateWebhookSubscriptionReq struct {
+	URL         *string  `json:"url"`
+	EventTypes  []string `json:"event_types"`
+	Description *string  `json:"description"`
+	IsActive    *bool    `json:"is_active"`
+}
+
+type WebhookSubscriptionRes struct {
+	*models.WebhookSubscription
+	Secret string `json:"secret,omitempty"`
+}
+
+func (r *CreateWebhookSubscriptionReq) Validate() utils.Validation {
+	v := utils.NewValidationError()
+	errs := utils.ErrorResponse{}
+
+	if !utils.IsValidWebhookURL(r.URL) {
+		errs.Add("url", utils.ErrorMessage("url"))
+		v.Status = true
+	}
+
+	if !validEventTypes(r.EventTypes) {
+		errs.Add("event_types", fmt.Sprintf(utils.InvalidChoiceFmt, "event_types", utils.WebhookEventTypes))
+		v.Status = true
+	}
+
+	if !utils.StringMaxValidation(r.Description, 255) {
+		errs.Add("description", utils.ErrorMessage("description"))
+		v.Status = true
+	}
+
+	v.Response = errs
+	return v
+}
+
+func (r *UpdateWebhookSubscriptionReq) Validate() utils.Validation {
+	v := utils.NewValidationError()
+	errs := utils.ErrorResponse{}
+
+	if r.URL != nil && !utils.IsValidWebhookURL(*r.URL) {
+		errs.Add("url", utils.ErrorMessage("url"))
+		v.Status = true
+	}
+
+	if r.EventTypes != nil && !validEventTypes(r.EventTypes) {
+		errs.Add("event_types", fmt.Sprintf(utils.InvalidChoiceFmt, "event_types", utils.WebhookEventTypes))
+		v.Status = true
+	}
+
+	if r.Description != nil && !utils.StringMaxValidation(*r.Description, 255) {
+		errs.Add("description", utils.ErrorMessage("description"))
+		v.Status = true
+	}
+
+	v.Response = errs
+	return v
+}
+
+func validEventTypes(eventTypes []string) bool {
+	if len(eventTypes) == 0 {
+		return false
+	}
+	for _, t := range eventTypes {
+		if !utils.InArrayString(t, utils.WebhookEventTypes) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,126 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fin-auth/cache"
+	"fin-auth/models"
+	"fin-auth/utils"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Idempotency struct {
+	db    *gorm.DB
+	cache *cache.RedisCache
+}
+
+func NewIdempotencyRepository(db *gorm.DB, cache *cache.RedisCache) *Idempotency {
+	return &Idempotency{
+		db:    db,
+		cache: cache,
+	}
+}
+
+func (o *Idempotency) GetDB(tx ...*gorm.DB) *gorm.DB {
+	db := o.db
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db
+}
+
+func (o *Idempotency) Get(ctx context.Context, clientId, key string) (*models.IdempotencyRecord, error) {
+	if o.cache != nil {
+		if record, err := o.cache.GetCachedIdempotencyRecord(ctx, clientId, key); err == nil {
+			return record, nil
+		}
+	}
+
+	var record models.IdempotencyRecord
+	err := o.db.Where("client_id = ? AND key = ? AND status = ? AND expires_at > ?",
+		clientId, key, utils.IDEMPOTENCY_STATUS_COMPLETED, utils.CurrentTime()).
+		First(&record).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, utils.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// Lock uses a Redis SETNX when Redis is reachable. Otherwise the Postgres row is the
+// lock: it is inserted as PROCESSING, or taken over when its lock or record expired.
+func (o *Idempotency) Lock(ctx context.Context, record *models.IdempotencyRecord, ttl time.Duration) (bool, error) {
+	if o.cache != nil {
+		acquired, err := o.cache.AcquireIdempotencyLock(ctx, record.ClientID, record.Key, ttl)
+		if err == nil {
+			return acquired, nil
+		}
+		log.Printf("[idempotency] redis lock unavailable, falling back to postgres: %v", err)
+	}
+
+	now := utils.CurrentTime()
+	lockedUntil := now.Add(ttl)
+	record.Status = utils.IDEMPOTENCY_STATUS_PROCESSING
+	record.LockedUntil = &lockedUntil
+
+	result := o.db.Clauses(clause.OnConflict{DoNothing: true}).Create(record)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected > 0 {
+		return true, nil
+	}
+
+	result = o.db.Model(&models.IdempotencyRecord{}).
+		Where("client_id = ? AND key = ?", record.ClientID, record.Key).
+		Where("(status = ? AND locked_until < ?) OR expires_at < ?", utils.IDEMPOTENCY_STATUS_PROCESSING, now, now).
+		Updates(map[string]interface{}{
+			"method":          record.Method,
+			"path":            record.Path,
+			"fingerprint":     record.Fingerprint,
+			"status":          record.Status,
+			"response_status": 0,
+			"content_type":    "",
+			"response_body":   nil,
+			"locked_until":    lockedUntil,
+			"expires_at":      record.ExpiresAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (o *Idempotency) Save(ctx context.Context, record *models.IdempotencyRecord) error {
+	record.Status = utils.IDEMPOTENCY_STATUS_COMPLETED
+	record.LockedUntil = nil
+
+	err := o.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "client_id"}, {Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"method", "path", "fingerprint", "status", "response_status", "content_type", "response_body", "locked_until", "expires_at", "updated_at"}),
+	}).Create(record).Error
+	if err != nil {
+		return err
+	}
+
+	if o.cache != nil {
+		if ttl := time.Until(record.ExpiresAt); ttl > 0 {
+			o.cache.CacheIdempotencyRecord(ctx, record, ttl)
+		}
+	}
+	return nil
+}
+
+func (o *Idempotency) Unlock(ctx context.Context, record *models.IdempotencyRecord) error {
+	if o.cache != nil {
+		o.cache.ReleaseIdempotencyLock(ctx, record.ClientID, record.Key)
+	}
+	return o.db.Where("client_id = ? AND key = ? AND status = ?", record.ClientID, record.Key, utils.IDEMPOTENCY_STATUS_PROCESSING).
+		Delete(&models.IdempotencyRecord{}).Error
+}
@@ -0,0 +1,79 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fin-auth/cache"
+	"fin-auth/models"
+	"fin-auth/utils"
+
+	"gorm.io/gorm"
+)
+
+type Jurisdiction struct {
+	db    *gorm.DB
+	cache *cache.RedisCache
+}
+
+func NewJurisdictionRepository(db *gorm.DB, cache *cache.RedisCache) *Jurisdiction {
+	return &Jurisdiction{
+		db:    db,
+		cache: cache,
+	}
+}
+
+func (o *Jurisdiction) GetDB(tx ...*gorm.DB) *gorm.DB {
+	db := o.db
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db
+}
+
+func (o *Jurisdiction) List(ctx context.Context, partner, country string) ([]*models.JurisdictionRestriction, error) {
+	var restrictions []*models.JurisdictionRestriction
+	query := o.db.Model(&models.JurisdictionRestriction{})
+	if partner != "" {
+		query = query.Where("partner = ?", partner)
+	}
+	if country != "" {
+		query = query.Where("country = ?", country)
+	}
+	if err := query.Order("partner, country, id").Find(&restrictions).Error; err != nil {
+		return nil, err
+	}
+	return restrictions, nil
+}
+
+func (o *Jurisdiction) FindByID(ctx context.Context, id int) (*models.JurisdictionRestriction, error) {
+	var restriction models.JurisdictionRestriction
+	err := o.db.Where("id = ?", id).First(&restriction).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, utils.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &restriction, nil
+}
+
+func (o *Jurisdiction) Create(ctx context.Context, restriction *models.JurisdictionRestriction) error {
+	return o.db.Create(restriction).Error
+}
+
+func (o *Jurisdiction) Update(ctx context.Context, restriction *models.JurisdictionRestriction) error {
+	return o.db.Model(restriction).
+		Select("partner", "program", "country", "subdivision", "category", "reason", "effective_from", "effective_to").
+		Updates(restriction).Error
+}
+
+func (o *Jurisdiction) Delete(ctx context.Context, id int) error {
+	result := o.db.Where("id = ?", id).Delete(&models.JurisdictionRestriction{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return utils.ErrNotFound
+	}
+	return nil
+}
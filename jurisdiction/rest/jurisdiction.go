@@ -0,0 +1,101 @@
+package rest
+
+import (
+	"errors"
+	"fin-auth/domain"
+	"fin-auth/dto"
+	"fin-auth/utils"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+type JurisdictionHandler struct {
+	Service  domain.JurisdictionService
+	Response domain.Response
+}
+
+// SetupJurisdictionAdminRoutes registers restriction management on the admin group.
+func SetupJurisdictionAdminRoutes(admin *echo.Group, s domain.JurisdictionService) {
+	handler := &JurisdictionHandler{
+		Service:  s,
+		Response: domain.NewResponse(),
+	}
+	restrictions := admin.Group("/jurisdiction-restrictions")
+	restrictions.GET("", handler.list)
+	restrictions.POST("", handler.create)
+	restrictions.PUT("/:id", handler.update)
+	restrictions.DELETE("/:id", handler.delete)
+}
+
+func (h *JurisdictionHandler) list(c echo.Context) error {
+	partner := strings.ToLower(strings.TrimSpace(c.QueryParam("partner")))
+	country := strings.ToUpper(strings.TrimSpace(c.QueryParam("country")))
+
+	restrictions, err := h.Service.List(c.Request().Context(), partner, country)
+	if err != nil {
+		return h.Response.InternalServerError(c, err)
+	}
+	return h.Response.SuccessOk(c, restrictions)
+}
+
+func (h *JurisdictionHandler) create(c echo.Context) error {
+	var req dto.JurisdictionRestrictionReq
+	if err := c.Bind(&req); err != nil {
+		return h.Response.InvalidData(c, nil)
+	}
+
+	v := req.Validate()
+	if v.Status {
+		return h.Response.ValidationFail(c, v, nil)
+	}
+
+	restriction, err := h.Service.Create(c.Request().Context(), &req)
+	if err != nil {
+		return h.Response.InternalServerError(c, err)
+	}
+	return h.Response.SuccessOk(c, restriction)
+}
+
+func (h *JurisdictionHandler) update(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return h.Response.InvalidData(c, nil)
+	}
+
+	var req dto.JurisdictionRestrictionReq
+	if err := c.Bind(&req); err != nil {
+		return h.Response.InvalidData(c, nil)
+	}
+
+	v := req.Validate()
+	if v.Status {
+		return h.Response.ValidationFail(c, v, nil)
+	}
+
+	restriction, err := h.Service.Update(c.Request().Context(), id, &req)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+	return h.Response.SuccessOk(c, restriction)
+}
+
+func (h *JurisdictionHandler) delete(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return h.Response.InvalidData(c, nil)
+	}
+
+	if err := h.Service.Delete(c.Request().Context(), id); err != nil {
+		return h.handleError(c, err)
+	}
+	return h.Response.SuccessMessage(c, "Jurisdiction restriction deleted successfully")
+}
+
+func (h *JurisdictionHandler) handleError(c echo.Context, err error) error {
+	if errors.Is(err, utils.ErrNotFound) {
+		return h.Response.NotFound(c, nil)
+	}
+	return h.Response.InternalServerError(c, err)
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"fin-auth/cache"
+	"fin-auth/config"
+	"fin-auth/domain"
+	"fin-auth/dto"
+	"fin-auth/models"
+	"fin-auth/utils"
+	"time"
+)
+
+const defaultRestrictionCacheTTL = 5 * time.Minute
+
+type Jurisdiction struct {
+	JurisdictionRepository domain.JurisdictionRepository
+	Config                 config.JurisdictionConfig
+	Cache                  *cache.RedisCache
+}
+
+func NewJurisdictionService(repo domain.JurisdictionRepository, cfg config.JurisdictionConfig, cache *cache.RedisCache) *Jurisdiction {
+	if cfg.Partner == "" {
+		cfg.Partner = utils.JURISDICTION_PARTNER_BRIDGE
+	}
+	return &Jurisdiction{
+		JurisdictionRepository: repo,
+		Config:                 cfg,
+		Cache:                  cache,
+	}
+}
+
+func (s *Jurisdiction) List(ctx context.Context, partner, country string) ([]*models.JurisdictionRestriction, error) {
+	return s.JurisdictionRepository.List(ctx, partner, country)
+}
+
+func (s *Jurisdiction) Create(ctx context.Context, req *dto.JurisdictionRestrictionReq) (*models.JurisdictionRestriction, error) {
+	restriction := &models.JurisdictionRestriction{}
+	applyRestrictionReq(restriction, req)
+	if err := s.JurisdictionRepository.Create(ctx, restriction); err != nil {
+		return nil, err
+	}
+	s.invalidate(ctx, restriction.Partner)
+	return restriction, nil
+}
+
+func (s *Jurisdiction) Update(ctx context.Context, id int, req *dto.JurisdictionRestrictionReq) (*models.JurisdictionRestriction, error) {
+	restriction, err := s.JurisdictionRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	previousPartner := restriction.Partner
+
+	applyRestrictionReq(restriction, req)
+	if err := s.JurisdictionRepository.Update(ctx, restriction); err != nil {
+		return nil, err
+	}
+	s.invalidate(ctx, previousPartner, restriction.Partner)
+	return restriction, nil
+}
+
+func (s *Jurisdiction) Delete(ctx context.Context, id int) error {
+	restriction, err := s.JurisdictionRepository.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.JurisdictionRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.invalidate(ctx, restriction.Partner)
+	return nil
+}
+
+func (s *Jurisdiction) Evaluate(ctx context.Context, clientId string, targets []dto.JurisdictionTarget) ([]dto.JurisdictionHit, error) {
+	restrictions, err := s.restrictionsFor(ctx, s.Config.Partner)
+	if err != nil {
+		return nil, err
+	}
+
+	now := utils.CurrentTime()
+	var hits []dto.JurisdictionHit
+	for _, target := range targets {
+		if target.Country == "" {
+			continue
+		}
+		for _, r := range restrictions {
+			if !r.IsEffective(now) || r.Country != target.Country {
+				continue
+			}
+			if r.Program != nil && *r.Program != clientId {
+				continue
+			}
+
+			field := target.Field
+			if r.Subdivision != nil {
+				if *r.Subdivision != target.Subdivision || target.SubdivisionField == "" {
+					continue
+				}
+				field = target.SubdivisionField
+			}
+			hits = append(hits, dto.JurisdictionHit{
+				Field:         field,
+				Country:       r.Country,
+				Subdivision:   r.Subdivision,
+				Category:      r.Category,
+				RestrictionID: r.ID,
+			})
+		}
+	}
+	return hits, nil
+}
+
+// restrictionsFor loads every restriction of a partner, effective or not, through the
+// cache. Effective dates are checked at evaluation time so cached entries stay valid.
+func (s *Jurisdiction) restrictionsFor(ctx context.Context, partner string) ([]*models.JurisdictionRestriction, error) {
+	if s.Cache != nil {
+		if restrictions, err := s.Cache.GetCachedJurisdictionRestrictions(ctx, partner); err == nil {
+			return restrictions, nil
+		}
+	}
+
+	restrictions, err := s.JurisdictionRepository.List(ctx, partner, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if s.Cache != nil {
+		ttl := defaultRestrictionCacheTTL
+		if s.Config.CacheTTLSeconds > 0 {
+			ttl = time.Duration(s.Config.CacheTTLSeconds) * time.Second
+		}
+		s.Cache.CacheJurisdictionRestrictions(ctx, partner, restrictions, ttl)
+	}
+	return restrictions, nil
+}
+
+func (s *Jurisdiction) invalidate(ctx context.Context, partners ...string) {
+	if s.Cache == nil {
+		return
+	}
+	for _, partner := range partners {
+		s.Cache.InvalidateJurisdictionRestrictions(ctx, partner)
+	}
+}
+
+func applyRestrictionReq(restriction *models.JurisdictionRestriction, req *dto.JurisdictionRestrictionReq) {
+	restriction.Partner = req.Partner
+	restriction.Program = req.Program
+	restriction.Country = req.Country
+	restriction.Subdivision = req.Subdivision
+	restriction.Category = req.Category
+	restriction.Reason = req.Reason
+	restriction.EffectiveFrom = req.EffectiveFromTime
+	restriction.EffectiveTo = req.EffectiveToTime
+}
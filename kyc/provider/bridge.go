@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fin-auth/domain"
+	"fin-auth/models"
+	"fin-auth/utils"
+	"strings"
+	"time"
+)
+
+// ErrWebhookOnly is returned by providers we only receive status webhooks from.
+var ErrWebhookOnly = errors.New("kyc provider only sends webhooks")
+
+// Bridge receives the KYC status Bridge holds for its own customer record. Bridge runs
+// that review itself, so there is nothing to create, submit or poll: its webhooks only
+// update Customer.BridgeKYCStatus, keyed by Customer.BridgeCustomerID.
+type Bridge struct{}
+
+type bridgeWebhookPayload struct {
+	EventID        string    `json:"event_id"`
+	EventCategory  string    `json:"event_category"`
+	EventObjectID  string    `json:"event_object_id"`
+	EventCreatedAt time.Time `json:"event_created_at"`
+	EventObject    struct {
+		KYCStatus string `json:"kyc_status"`
+	} `json:"event_object"`
+}
+
+func NewBridge() *Bridge {
+	return &Bridge{}
+}
+
+func (b *Bridge) Name() string {
+	return utils.KYC_PROVIDER_BRIDGE
+}
+
+func (b *Bridge) CreateApplicant(ctx context.Context, customer *models.Customer, person *models.Person) (*domain.KYCApplicant, error) {
+	return nil, ErrWebhookOnly
+}
+
+func (b *Bridge) SubmitApplicant(ctx context.Context, applicantId string) (*domain.KYCResult, error) {
+	return nil, ErrWebhookOnly
+}
+
+func (b *Bridge) FetchStatus(ctx context.Context, applicantId string) (*domain.KYCResult, error) {
+	return nil, ErrWebhookOnly
+}
+
+// ParseWebhook reads a customer event. The applicant ID is Bridge's customer ID and
+// the status is Bridge's own kyc_status, lower-cased as Bridge sends it.
+func (b *Bridge) ParseWebhook(ctx context.Context, body []byte) (*domain.KYCWebhookEvent, error) {
+	var payload bridgeWebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, utils.ErrBadRequest
+	}
+	status := strings.ToLower(strings.TrimSpace(payload.EventObject.KYCStatus))
+	if payload.EventID == "" || payload.EventObjectID == "" || payload.EventCategory != "customer" || status == "" {
+		return nil, utils.ErrBadRequest
+	}
+	if payload.EventCreatedAt.IsZero() {
+		payload.EventCreatedAt = utils.CurrentTime()
+	}
+
+	return &domain.KYCWebhookEvent{
+		EventID:     payload.EventID,
+		ApplicantID: payload.EventObjectID,
+		Status:      status,
+		OccurredAt:  payload.EventCreatedAt,
+	}, nil
+}
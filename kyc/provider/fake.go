@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fin-auth/domain"
+	"fin-auth/models"
+	"fin-auth/utils"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Fake is a deterministic in-process KYC provider for local runs and tests.
+// The final verdict is derived from the person's TIN:
+//   - ending in "0000" -> REJECTED
+//   - ending in "1111" -> MANUAL_REVIEW
+//   - anything else    -> APPROVED
+type Fake struct {
+	mu         sync.Mutex
+	applicants map[string]*fakeApplicant
+}
+
+type fakeApplicant struct {
+	status  string
+	verdict string
+	reason  string
+}
+
+type fakeWebhookPayload struct {
+	EventID     string    `json:"event_id"`
+	ApplicantID string    `json:"applicant_id"`
+	Status      string    `json:"status"`
+	Reason      string    `json:"reason"`
+	OccurredAt  time.Time `json:"occurred_at"`
+}
+
+func NewFake() *Fake {
+	return &Fake{applicants: make(map[string]*fakeApplicant)}
+}
+
+func (f *Fake) Name() string {
+	return utils.KYC_PROVIDER_FAKE
+}
+
+func (f *Fake) CreateApplicant(ctx context.Context, customer *models.Customer, person *models.Person) (*domain.KYCApplicant, error) {
+	if customer == nil || person == nil {
+		return nil, errors.New("customer and person are required")
+	}
+
+	applicantId := "fake_" + utils.GenerateHash(customer.ID)[:16]
+	verdict, reason := fakeVerdict(utils.SafeString(person.TIN))
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, exists := f.applicants[applicantId]; !exists {
+		f.applicants[applicantId] = &fakeApplicant{
+			status:  utils.KYC_STATUS_PENDING,
+			verdict: verdict,
+			reason:  reason,
+		}
+	}
+
+	return &domain.KYCApplicant{
+		ApplicantID: applicantId,
+		Status:      f.applicants[applicantId].status,
+	}, nil
+}
+
+func (f *Fake) SubmitApplicant(ctx context.Context, applicantId string) (*domain.KYCResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	applicant, ok := f.applicants[applicantId]
+	if !ok {
+		return nil, utils.ErrNotFound
+	}
+	if applicant.status == utils.KYC_STATUS_PENDING {
+		applicant.status = utils.KYC_STATUS_UNDER_REVIEW
+	}
+
+	return &domain.KYCResult{
+		ApplicantID: applicantId,
+		Status:      applicant.status,
+		CheckedAt:   utils.CurrentTime(),
+	}, nil
+}
+
+// FetchStatus resolves a submitted applicant to its verdict on the first poll.
+func (f *Fake) FetchStatus(ctx context.Context, applicantId string) (*domain.KYCResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	applicant, ok := f.applicants[applicantId]
+	if !ok {
+		return nil, utils.ErrNotFound
+	}
+
+	result := &domain.KYCResult{
+		ApplicantID: applicantId,
+		CheckedAt:   utils.CurrentTime(),
+	}
+	if applicant.status == utils.KYC_STATUS_UNDER_REVIEW {
+		applicant.status = applicant.verdict
+	}
+	result.Status = applicant.status
+	if applicant.status == applicant.verdict {
+		result.Reason = applicant.reason
+	}
+
+	return result, nil
+}
+
+func (f *Fake) ParseWebhook(ctx context.Context, body []byte) (*domain.KYCWebhookEvent, error) {
+	var payload fakeWebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, utils.ErrBadRequest
+	}
+	if payload.EventID == "" || payload.ApplicantID == "" || !isKnownStatus(payload.Status) {
+		return nil, utils.ErrBadRequest
+	}
+	if payload.OccurredAt.IsZero() {
+		payload.OccurredAt = utils.CurrentTime()
+	}
+
+	return &domain.KYCWebhookEvent{
+		EventID:     payload.EventID,
+		ApplicantID: payload.ApplicantID,
+		Status:      payload.Status,
+		Reason:      payload.Reason,
+		OccurredAt:  payload.OccurredAt,
+	}, nil
+}
+
+func fakeVerdict(tin string) (string, string) {
+	tin = strings.TrimSpace(tin)
+	switch {
+	case strings.HasSuffix(tin, "0000"):
+		return utils.KYC_STATUS_REJECTED, "document_forgery_suspected"
+	case strings.HasSuffix(tin, "1111"):
+		return utils.KYC_STATUS_MANUAL_REVIEW, "name_mismatch"
+	default:
+		return utils.KYC_STATUS_APPROVED, ""
+	}
+}
+
+func isKnownStatus(status string) bool {
+	return utils.InArrayString(status, []string{
+		utils.KYC_STATUS_PENDING,
+		utils.KYC_STATUS_UNDER_REVIEW,
+		utils.KYC_STATUS_MANUAL_REVIEW,
+		utils.KYC_STATUS_RESUBMISSION_REQUIRED,
+		utils.KYC_STATUS_APPROVED,
+		utils.KYC_STATUS_REJECTED,
+	})
+}
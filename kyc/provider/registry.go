@@ -0,0 +1,26 @@
+package provider
+
+import (
+	"fin-auth/domain"
+	"fmt"
+)
+
+type Registry struct {
+	providers map[string]domain.KYCProvider
+}
+
+func NewRegistry(providers ...domain.KYCProvider) *Registry {
+	r := &Registry{providers: make(map[string]domain.KYCProvider)}
+	for _, p := range providers {
+		r.providers[p.Name()] = p
+	}
+	return r
+}
+
+func (r *Registry) Get(name string) (domain.KYCProvider, error) {
+	p, ok := r.providers[name]
+	if !ok {
+		return nil, fmt.Errorf("kyc provider %q is not registered", name)
+	}
+	return p, nil
+}
@@ -0,0 +1,67 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fin-auth/cache"
+	"fin-auth/models"
+	"fin-auth/utils"
+
+	"gorm.io/gorm"
+)
+
+type KYC struct {
+	db    *gorm.DB
+	cache *cache.RedisCache
+}
+
+func NewKYCRepository(db *gorm.DB, cache *cache.RedisCache) *KYC {
+	return &KYC{
+		db:    db,
+		cache: cache,
+	}
+}
+
+func (o *KYC) GetDB(tx ...*gorm.DB) *gorm.DB {
+	db := o.db
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db
+}
+
+func (o *KYC) FindWebhook(ctx context.Context, provider, eventId string) (*models.KYCWebhook, error) {
+	var webhook models.KYCWebhook
+	err := o.db.Where("provider = ? AND event_id = ?", provider, eventId).First(&webhook).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, utils.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (o *KYC) CreateWebhook(ctx context.Context, webhook *models.KYCWebhook) error {
+	err := o.db.Create(webhook).Error
+	if err != nil && utils.IsDuplicateKeyError(err) {
+		return utils.ErrConflict
+	}
+	return err
+}
+
+func (o *KYC) UpdateWebhook(ctx context.Context, webhook *models.KYCWebhook) error {
+	return o.db.Model(webhook).Select("applicant_id", "status", "error", "processed_at").Updates(webhook).Error
+}
+
+func (o *KYC) CreateReviewFlag(ctx context.Context, flag *models.ReviewFlag) error {
+	return o.db.Create(flag).Error
+}
+
+func (o *KYC) ListReviewFlags(ctx context.Context, customerId string) ([]*models.ReviewFlag, error) {
+	var flags []*models.ReviewFlag
+	if err := o.db.Where("customer_id = ?", customerId).Order("created_at DESC").Find(&flags).Error; err != nil {
+		return nil, err
+	}
+	return flags, nil
+}
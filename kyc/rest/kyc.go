@@ -0,0 +1,117 @@
+package rest
+
+import (
+	"errors"
+	"fin-auth/domain"
+	"fin-auth/utils"
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+const maxWebhookBodyBytes = 1 << 20
+
+type KYCHandler struct {
+	Service   domain.KYCService
+	PIIAccess domain.PIIAccessService
+	Response  domain.Response
+}
+
+func SetupKYCRoutes(api *echo.Group, s domain.KYCService, piiAccess domain.PIIAccessService) {
+	handler := &KYCHandler{
+		Service:   s,
+		PIIAccess: piiAccess,
+		Response:  domain.NewResponse(),
+	}
+	kyc := api.Group("/customers/:id/kyc")
+	kyc.POST("", handler.startVerification)
+	kyc.GET("", handler.refreshStatus)
+	kyc.GET("/review-flags", handler.listReviewFlags)
+}
+
+// SetupKYCWebhookRoutes registers provider callbacks. They carry no bearer token and are
+// authenticated by the provider's HMAC signature instead.
+func SetupKYCWebhookRoutes(api *echo.Group, s domain.KYCService) {
+	handler := &KYCHandler{
+		Service:  s,
+		Response: domain.NewResponse(),
+	}
+	api.POST("/webhooks/kyc/:provider", handler.receiveWebhook)
+}
+
+func (h *KYCHandler) startVerification(c echo.Context) error {
+	unmasked := domain.Unmasked(c)
+
+	clientId := c.Get("client_id").(string)
+	customer, err := h.Service.StartVerification(c.Request().Context(), clientId, c.Param("id"))
+	if err != nil {
+		return h.handleError(c, err)
+	}
+	return h.present(c, customer, unmasked)
+}
+
+func (h *KYCHandler) refreshStatus(c echo.Context) error {
+	unmasked := domain.Unmasked(c)
+
+	clientId := c.Get("client_id").(string)
+	customer, err := h.Service.RefreshStatus(c.Request().Context(), clientId, c.Param("id"))
+	if err != nil {
+		return h.handleError(c, err)
+	}
+	return h.present(c, customer, unmasked)
+}
+
+func (h *KYCHandler) listReviewFlags(c echo.Context) error {
+	clientId := c.Get("client_id").(string)
+	flags, err := h.Service.ListReviewFlags(c.Request().Context(), clientId, c.Param("id"))
+	if err != nil {
+		return h.handleError(c, err)
+	}
+	return h.Response.SuccessOk(c, flags)
+}
+
+func (h *KYCHandler) receiveWebhook(c echo.Context) error {
+	body, err := io.ReadAll(http.MaxBytesReader(c.Response(), c.Request().Body, maxWebhookBodyBytes))
+	if err != nil {
+		return h.Response.InvalidData(c, nil)
+	}
+
+	timestamp := c.Request().Header.Get("X-Webhook-Timestamp")
+	signature := c.Request().Header.Get("X-Webhook-Signature")
+
+	duplicate, err := h.Service.HandleWebhook(c.Request().Context(), c.Param("provider"), timestamp, signature, body)
+	switch {
+	case errors.Is(err, utils.ErrUnauthenticated):
+		return h.Response.UnauthorizedResponse(c, utils.StringPtr("Invalid webhook signature"))
+	case errors.Is(err, utils.ErrBadRequest):
+		return h.Response.InvalidData(c, utils.StringPtr("Invalid webhook payload"))
+	case err != nil:
+		return h.handleError(c, err)
+	}
+
+	if duplicate {
+		return h.Response.SuccessMessage(c, "Event already processed")
+	}
+	return h.Response.Ok(c)
+}
+
+func (h *KYCHandler) present(c echo.Context, customer *domain.CachedCustomer, unmasked bool) error {
+	res, err := h.PIIAccess.PresentCustomers(c.Request().Context(), domain.NewPIIAccess(c), []*domain.CachedCustomer{customer}, unmasked)
+	if err != nil {
+		return h.Response.InternalServerError(c, err)
+	}
+	return h.Response.SuccessOk(c, res[0])
+}
+
+func (h *KYCHandler) handleError(c echo.Context, err error) error {
+	var transitionErr *utils.InvalidTransitionError
+	switch {
+	case errors.Is(err, utils.ErrNotFound):
+		return h.Response.NotFound(c, utils.StringPtr("Customer not found"))
+	case errors.As(err, &transitionErr), errors.Is(err, utils.ErrConflict):
+		return h.Response.ConflictError(c, utils.StringPtr(err.Error()), nil)
+	default:
+		return h.Response.InternalServerError(c, err)
+	}
+}
@@ -0,0 +1,360 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fin-auth/cache"
+	"fin-auth/config"
+	"fin-auth/domain"
+	"fin-auth/dto"
+	"fin-auth/kyc/provider"
+	"fin-auth/models"
+	"fin-auth/utils"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultWebhookTolerance = 5 * time.Minute
+	webhookDedupeTTL        = 72 * time.Hour
+)
+
+// kycTransitions lists the statuses each KYC status may move to.
+var kycTransitions = map[string][]string{
+	utils.KYC_STATUS_INCOMPLETE: {
+		utils.KYC_STATUS_PENDING,
+		utils.KYC_STATUS_MANUAL_REVIEW,
+	},
+	utils.KYC_STATUS_PENDING: {
+		utils.KYC_STATUS_UNDER_REVIEW,
+		utils.KYC_STATUS_INCOMPLETE,
+		utils.KYC_STATUS_MANUAL_REVIEW,
+	},
+	utils.KYC_STATUS_UNDER_REVIEW: {
+		utils.KYC_STATUS_APPROVED,
+		utils.KYC_STATUS_REJECTED,
+		utils.KYC_STATUS_MANUAL_REVIEW,
+		utils.KYC_STATUS_RESUBMISSION_REQUIRED,
+	},
+	utils.KYC_STATUS_MANUAL_REVIEW: {
+		utils.KYC_STATUS_APPROVED,
+		utils.KYC_STATUS_REJECTED,
+		utils.KYC_STATUS_RESUBMISSION_REQUIRED,
+	},
+	utils.KYC_STATUS_RESUBMISSION_REQUIRED: {
+		utils.KYC_STATUS_PENDING,
+		utils.KYC_STATUS_UNDER_REVIEW,
+		utils.KYC_STATUS_MANUAL_REVIEW,
+	},
+	utils.KYC_STATUS_APPROVED: {
+		utils.KYC_STATUS_MANUAL_REVIEW,
+		utils.KYC_STATUS_RESUBMISSION_REQUIRED,
+	},
+	utils.KYC_STATUS_REJECTED: {},
+}
+
+type KYC struct {
+	CustomerRepository domain.CustomerRepository
+	KYCRepository      domain.KYCRepository
+	Providers          *provider.Registry
+	CorridorService    domain.CorridorService
+	Config             config.KYCConfig
+	Cache              *cache.RedisCache
+}
+
+func NewKYCService(repo domain.CustomerRepository, kycRepo domain.KYCRepository, providers *provider.Registry, corridorService domain.CorridorService, cfg config.KYCConfig, cache *cache.RedisCache) *KYC {
+	if cfg.Provider == "" {
+		cfg.Provider = utils.KYC_PROVIDER_FAKE
+	}
+	return &KYC{
+		CustomerRepository: repo,
+		KYCRepository:      kycRepo,
+		Providers:          providers,
+		CorridorService:    corridorService,
+		Config:             cfg,
+		Cache:              cache,
+	}
+}
+
+func (s *KYC) StartVerification(ctx context.Context, clientId, customerId string) (*domain.CachedCustomer, error) {
+	cached, err := s.CustomerRepository.FindByID(ctx, clientId, customerId)
+	if err != nil {
+		return nil, err
+	}
+	if cached.Person == nil {
+		return nil, errors.New("customer has no person to verify")
+	}
+
+	customer := cached.Customer
+	p, err := s.providerFor(customer)
+	if err != nil {
+		return nil, err
+	}
+
+	if customer.KYCApplicantID == nil || utils.SafeString(customer.KYCStatus) == utils.KYC_STATUS_RESUBMISSION_REQUIRED {
+		applicant, err := p.CreateApplicant(ctx, customer, cached.Person)
+		if err != nil {
+			return nil, err
+		}
+		customer.KYCProvider = utils.StringPtr(p.Name())
+		customer.KYCApplicantID = utils.StringPtr(applicant.ApplicantID)
+		if err := s.TransitionStatus(ctx, customer, utils.KYC_STATUS_PENDING, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := p.SubmitApplicant(ctx, *customer.KYCApplicantID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ApplyResult(ctx, customer, result); err != nil {
+		return nil, err
+	}
+
+	return cached, nil
+}
+
+func (s *KYC) RefreshStatus(ctx context.Context, clientId, customerId string) (*domain.CachedCustomer, error) {
+	cached, err := s.CustomerRepository.FindByID(ctx, clientId, customerId)
+	if err != nil {
+		return nil, err
+	}
+
+	customer := cached.Customer
+	if customer.KYCApplicantID == nil {
+		return cached, nil
+	}
+
+	p, err := s.providerFor(customer)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := p.FetchStatus(ctx, *customer.KYCApplicantID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ApplyResult(ctx, customer, result); err != nil {
+		return nil, err
+	}
+
+	return cached, nil
+}
+
+func (s *KYC) ApplyResult(ctx context.Context, customer *models.Customer, result *domain.KYCResult) error {
+	if result == nil {
+		return errors.New("empty kyc result")
+	}
+	if customer.KYCApplicantID != nil && result.ApplicantID != "" && *customer.KYCApplicantID != result.ApplicantID {
+		return utils.ErrConflict
+	}
+	return s.TransitionStatus(ctx, customer, result.Status, result.Reason)
+}
+
+// TransitionStatus moves the customer's KYC status along kycTransitions and persists it.
+// Moving to the current status is a no-op.
+func (s *KYC) TransitionStatus(ctx context.Context, customer *models.Customer, status, reason string) error {
+	current := utils.SafeString(customer.KYCStatus)
+	if current == "" {
+		current = utils.KYC_STATUS_INCOMPLETE
+	}
+	if current == status {
+		return nil
+	}
+	if !utils.InArrayString(status, kycTransitions[current]) {
+		return &utils.InvalidTransitionError{FromStatus: current, ToStatus: status}
+	}
+
+	now := utils.CurrentTime()
+	customer.KYCStatus = utils.StringPtr(status)
+	customer.KYCStatusReason = nil
+	if reason != "" {
+		customer.KYCStatusReason = utils.StringPtr(reason)
+	}
+	customer.KYCUpdatedAt = &now
+
+	if err := s.CustomerRepository.UpdateKYC(ctx, customer); err != nil {
+		return err
+	}
+
+	// Corridors are cleared with any status but APPROVED in the same update, so only an
+	// approval depends on this refresh.
+	return s.CorridorService.Refresh(ctx, utils.SafeString(customer.ClientID), customer.ID)
+}
+
+// FlagForReview records a structured review reason and moves the customer to
+// MANUAL_REVIEW. Rejected customers keep their status; the flag is still recorded.
+func (s *KYC) FlagForReview(ctx context.Context, customer *models.Customer, source, code string, details interface{}) error {
+	flag, err := dto.NewReviewFlag(customer.ID, source, code, details)
+	if err != nil {
+		return err
+	}
+	if err := s.KYCRepository.CreateReviewFlag(ctx, flag); err != nil {
+		return err
+	}
+
+	if utils.SafeString(customer.KYCStatus) == utils.KYC_STATUS_REJECTED {
+		return nil
+	}
+	return s.TransitionStatus(ctx, customer, utils.KYC_STATUS_MANUAL_REVIEW, code)
+}
+
+func (s *KYC) ListReviewFlags(ctx context.Context, clientId, customerId string) ([]*models.ReviewFlag, error) {
+	if _, err := s.CustomerRepository.FindByID(ctx, clientId, customerId); err != nil {
+		return nil, err
+	}
+	return s.KYCRepository.ListReviewFlags(ctx, customerId)
+}
+
+// HandleWebhook verifies and applies an inbound provider webhook. The signature is an
+// HMAC-SHA256 of "<timestamp>.<body>". It reports true when the event was already processed.
+func (s *KYC) HandleWebhook(ctx context.Context, providerName, timestamp, signature string, body []byte) (bool, error) {
+	secret := s.Config.WebhookSecrets[providerName]
+	if secret == "" {
+		return false, utils.ErrNotFound
+	}
+	p, err := s.Providers.Get(providerName)
+	if err != nil {
+		return false, utils.ErrNotFound
+	}
+
+	if err := s.verifyWebhookTimestamp(timestamp); err != nil {
+		return false, err
+	}
+	signed := []byte(fmt.Sprintf("%s.%s", timestamp, body))
+	if !utils.VerifyHMACSHA256(secret, signed, signature) {
+		return false, utils.ErrUnauthenticated
+	}
+
+	event, err := p.ParseWebhook(ctx, body)
+	if err != nil {
+		return false, err
+	}
+
+	source := "kyc:" + providerName
+	if s.Cache != nil {
+		if seen, err := s.Cache.IsWebhookEventSeen(ctx, source, event.EventID); err == nil && seen {
+			return true, nil
+		}
+	}
+
+	webhook, err := s.KYCRepository.FindWebhook(ctx, providerName, event.EventID)
+	if err != nil && !errors.Is(err, utils.ErrNotFound) {
+		return false, err
+	}
+	if webhook != nil && webhook.ProcessedAt != nil {
+		return true, nil
+	}
+	if webhook == nil {
+		payload, err := utils.SafeBytesToDatatypesJSON(body)
+		if err != nil {
+			return false, utils.ErrBadRequest
+		}
+		webhook = &models.KYCWebhook{
+			Provider:   providerName,
+			EventID:    event.EventID,
+			Payload:    payload,
+			Signature:  signature,
+			ReceivedAt: utils.CurrentTime(),
+		}
+		if err := s.KYCRepository.CreateWebhook(ctx, webhook); err != nil {
+			if errors.Is(err, utils.ErrConflict) {
+				return true, nil
+			}
+			return false, err
+		}
+	}
+	webhook.ApplicantID = utils.StringPtr(event.ApplicantID)
+	webhook.Status = utils.StringPtr(event.Status)
+	webhook.Error = nil
+
+	if err := s.applyWebhookEvent(ctx, providerName, event); err != nil {
+		var transitionErr *utils.InvalidTransitionError
+		var reason string
+		switch {
+		case errors.As(err, &transitionErr):
+			reason = err.Error()
+		case errors.Is(err, utils.ErrNotFound):
+			reason = "unknown applicant"
+		default:
+			// Leave the event unprocessed so that the provider's retry applies it.
+			webhook.Error = utils.StringPtr(err.Error())
+			s.KYCRepository.UpdateWebhook(ctx, webhook)
+			return false, err
+		}
+		// Neither an out-of-order status nor an applicant we don't know can be applied
+		// on a retry: acknowledge the event and keep the reason on its record.
+		log.Printf("[kyc] ignored %s webhook %s: %s", providerName, event.EventID, reason)
+		webhook.Error = utils.StringPtr("ignored: " + reason)
+	}
+
+	now := utils.CurrentTime()
+	webhook.ProcessedAt = &now
+	if err := s.KYCRepository.UpdateWebhook(ctx, webhook); err != nil {
+		return false, err
+	}
+	// The event is only marked seen once applied, so a crash before this point lets
+	// the provider's retry through.
+	if s.Cache != nil {
+		s.Cache.MarkWebhookEventSeen(ctx, source, event.EventID, webhookDedupeTTL)
+	}
+	return false, nil
+}
+
+func (s *KYC) applyWebhookEvent(ctx context.Context, providerName string, event *domain.KYCWebhookEvent) error {
+	if providerName == utils.KYC_PROVIDER_BRIDGE {
+		return s.applyBridgeStatus(ctx, event)
+	}
+
+	customer, err := s.CustomerRepository.FindByKYCApplicantID(ctx, providerName, event.ApplicantID)
+	if err != nil {
+		return err
+	}
+	return s.ApplyResult(ctx, customer, &domain.KYCResult{
+		ApplicantID: event.ApplicantID,
+		Status:      event.Status,
+		Reason:      event.Reason,
+		CheckedAt:   event.OccurredAt,
+	})
+}
+
+// applyBridgeStatus records the status Bridge reports for its customer. It is Bridge's
+// own review, so it doesn't move our KYC status.
+func (s *KYC) applyBridgeStatus(ctx context.Context, event *domain.KYCWebhookEvent) error {
+	customer, err := s.CustomerRepository.FindByBridgeCustomerID(ctx, event.ApplicantID)
+	if err != nil {
+		return err
+	}
+	if utils.SafeString(customer.BridgeKYCStatus) == event.Status {
+		return nil
+	}
+	customer.BridgeKYCStatus = utils.StringPtr(event.Status)
+	return s.CustomerRepository.UpdateKYC(ctx, customer)
+}
+
+func (s *KYC) verifyWebhookTimestamp(timestamp string) error {
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return utils.ErrUnauthenticated
+	}
+	tolerance := defaultWebhookTolerance
+	if s.Config.WebhookToleranceSeconds > 0 {
+		tolerance = time.Duration(s.Config.WebhookToleranceSeconds) * time.Second
+	}
+	drift := math.Abs(float64(utils.CurrentTime().Unix() - ts))
+	if time.Duration(drift)*time.Second > tolerance {
+		return utils.ErrUnauthenticated
+	}
+	return nil
+}
+
+func (s *KYC) providerFor(customer *models.Customer) (domain.KYCProvider, error) {
+	name := s.Config.Provider
+	if customer.KYCProvider != nil && *customer.KYCProvider != "" {
+		name = *customer.KYCProvider
+	}
+	return s.Providers.Get(name)
+}
@@ -2,18 +2,32 @@ package models
 
 import "time"
 
+// Address is one version of a customer address. Changing an address closes the
+// current version with ValidTo and adds a new one pointing back through PreviousID, so
+// the rows of a customer form its address history. At most one current address per
+// type is primary.
 type Address struct {
-	ID         int       `json:"id" gorm:"column:id;primaryKey;autoIncrement"`
-	CustomerID *string   `json:"customer_id" gorm:"column:customer_id;type:uuid;references:ID;constraint:OnDelete:CASCADE"`
-	Customer   *Customer `json:"-" gorm:"foreignKey:CustomerID;references:ID"`
-	Street     *string   `json:"street" gorm:"column:street"`
-	City       *string   `json:"city" gorm:"column:city"`
-	State      *string   `json:"state" gorm:"column:state"`
-	StateCode  *string   `json:"state_code" gorm:"column:state_code"`
-	PostalCode *string   `json:"postal_code" gorm:"column:postal_code"`
-	Country    *string   `json:"country" gorm:"column:country"`
-	CreatedAt  time.Time `json:"created_at" gorm:"column:created_at;default:CURRENT_TIMESTAMP;autoCreateTime"`
-	UpdatedAt  time.Time `json:"updated_at" gorm:"column:updated_at;default:CURRENT_TIMESTAMP;autoUpdateTime"`
+	ID         int        `json:"id" gorm:"column:id;primaryKey;autoIncrement"`
+	CustomerID *string    `json:"customer_id" gorm:"column:customer_id;type:uuid;references:ID;constraint:OnDelete:CASCADE;uniqueIndex:idx_addresses_current_primary,where:is_primary AND valid_to IS NULL"`
+	Customer   *Customer  `json:"-" gorm:"foreignKey:CustomerID;references:ID"`
+	Type       string     `json:"type" gorm:"column:type;size:20;not null;default:'RESIDENTIAL';uniqueIndex:idx_addresses_current_primary"`
+	IsPrimary  bool       `json:"is_primary" gorm:"column:is_primary;not null;default:false"`
+	ValidFrom  time.Time  `json:"valid_from" gorm:"column:valid_from;not null;default:CURRENT_TIMESTAMP"`
+	ValidTo    *time.Time `json:"valid_to" gorm:"column:valid_to"`
+	PreviousID *int       `json:"previous_id" gorm:"column:previous_id;index"`
+	Street     *string    `json:"street" gorm:"column:street;type:text;serializer:pii"`
+	City       *string    `json:"city" gorm:"column:city;type:text;serializer:pii"`
+	State      *string    `json:"state" gorm:"column:state;type:text;serializer:pii"`
+	StateCode  *string    `json:"state_code" gorm:"column:state_code;type:text;serializer:pii"`
+	PostalCode *string    `json:"postal_code" gorm:"column:postal_code;type:text;serializer:pii"`
+	Country    *string    `json:"country" gorm:"column:country;type:text;serializer:pii"`
+
+	NormalizedStreet     *string `json:"normalized_street" gorm:"column:normalized_street;type:text;serializer:pii"`
+	NormalizedCity       *string `json:"normalized_city" gorm:"column:normalized_city;type:text;serializer:pii"`
+	NormalizedPostalCode *string `json:"normalized_postal_code" gorm:"column:normalized_postal_code;type:text;serializer:pii"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at;default:CURRENT_TIMESTAMP;autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"column:updated_at;default:CURRENT_TIMESTAMP;autoUpdateTime"`
 }
 
 func (Address) TableName() string {
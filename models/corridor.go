@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Corridor is a payment route customers may be enabled for. Customers residing in one
+// of EligibleResidences, or in SourceCountry when it is empty, qualify once approved
+// and at or below MaxRiskTier.
+type Corridor struct {
+	ID                  int            `json:"id" gorm:"column:id;primaryKey;autoIncrement"`
+	SourceCountry       string         `json:"source_country" gorm:"column:source_country;size:3;not null;uniqueIndex:idx_corridor_route"`
+	DestinationCountry  string         `json:"destination_country" gorm:"column:destination_country;size:3;not null;uniqueIndex:idx_corridor_route"`
+	SourceCurrency      string         `json:"source_currency" gorm:"column:source_currency;size:3;not null;uniqueIndex:idx_corridor_route"`
+	DestinationCurrency string         `json:"destination_currency" gorm:"column:destination_currency;size:3;not null;uniqueIndex:idx_corridor_route"`
+	Rails               pq.StringArray `json:"rails" gorm:"column:rails;type:text[]"`
+	MinAmount           float64        `json:"min_amount" gorm:"column:min_amount;not null;default:0"`
+	MaxAmount           float64        `json:"max_amount" gorm:"column:max_amount;not null"`
+	DailyLimit          *float64       `json:"daily_limit" gorm:"column:daily_limit"`
+	MonthlyLimit        *float64       `json:"monthly_limit" gorm:"column:monthly_limit"`
+	EligibleResidences  pq.StringArray `json:"eligible_residences" gorm:"column:eligible_residences;type:text[]"`
+	MaxRiskTier         string         `json:"max_risk_tier" gorm:"column:max_risk_tier;size:10;not null;default:'HIGH'"`
+	Active              bool           `json:"active" gorm:"column:active;not null;default:false;index"`
+	CreatedAt           time.Time      `json:"created_at" gorm:"column:created_at;default:CURRENT_TIMESTAMP;autoCreateTime"`
+	UpdatedAt           time.Time      `json:"updated_at" gorm:"column:updated_at;default:CURRENT_TIMESTAMP;autoUpdateTime"`
+}
+
+func (Corridor) TableName() string {
+	return "corridors"
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// DataSubjectRequest is a completed export or erasure of a customer's data. It holds
+// no PII and is kept after erasure as proof the request was honored.
+type DataSubjectRequest struct {
+	ID          string         `json:"id" gorm:"type:uuid;primaryKey"`
+	CustomerID  string         `json:"customer_id" gorm:"column:customer_id;type:uuid;not null;index"`
+	ClientID    *string        `json:"client_id" gorm:"column:client_id;size:100;index"`
+	Type        string         `json:"type" gorm:"column:type;size:20;not null"`
+	RequestedBy string         `json:"requested_by" gorm:"column:requested_by;size:100;not null"`
+	Receipt     datatypes.JSON `json:"receipt" gorm:"column:receipt;type:jsonb"`
+	CompletedAt time.Time      `json:"completed_at" gorm:"column:completed_at;not null"`
+	CreatedAt   time.Time      `json:"created_at" gorm:"column:created_at;default:CURRENT_TIMESTAMP;autoCreateTime"`
+}
+
+func (DataSubjectRequest) TableName() string {
+	return "data_subject_requests"
+}
+
+func (r *DataSubjectRequest) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == "" {
+		r.ID = uuid.New().String()
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Document struct {
+	ID         string    `json:"id" gorm:"type:uuid;primaryKey"`
+	CustomerID string    `json:"customer_id" gorm:"column:customer_id;type:uuid;not null;index"`
+	Customer   *Customer `json:"-" gorm:"foreignKey:CustomerID;references:ID;constraint:OnDelete:CASCADE"`
+	ClientID   string    `json:"client_id" gorm:"column:client_id;size:100;not null;index"`
+	Type       string    `json:"type" gorm:"column:type;size:30;not null"`
+	Side       string    `json:"side" gorm:"column:side;size:10;not null"`
+	Country    *string   `json:"country" gorm:"column:country;size:3"`
+	FileName   string    `json:"file_name" gorm:"column:file_name"`
+	MimeType   string    `json:"mime_type" gorm:"column:mime_type;size:100"`
+	Size       int64     `json:"size" gorm:"column:size"`
+	Width      *int      `json:"width" gorm:"column:width"`
+	Height     *int      `json:"height" gorm:"column:height"`
+	Hash       string    `json:"hash" gorm:"column:hash;size:64;not null;index"`
+	StorageKey string    `json:"-" gorm:"column:storage_key;not null"`
+	Status     string    `json:"status" gorm:"column:status;size:20;not null"`
+	CreatedAt  time.Time `json:"created_at" gorm:"column:created_at;default:CURRENT_TIMESTAMP;autoCreateTime"`
+	UpdatedAt  time.Time `json:"updated_at" gorm:"column:updated_at;default:CURRENT_TIMESTAMP;autoUpdateTime"`
+}
+
+func (Document) TableName() string {
+	return "documents"
+}
+
+func (d *Document) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == "" {
+		d.ID = uuid.New().String()
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// DuplicateCandidate links a new customer to an existing one sharing an identity field.
+type DuplicateCandidate struct {
+	ID                int       `json:"id" gorm:"column:id;primaryKey;autoIncrement"`
+	CustomerID        string    `json:"customer_id" gorm:"column:customer_id;type:uuid;not null;uniqueIndex:idx_duplicate_candidate"`
+	Customer          *Customer `json:"-" gorm:"foreignKey:CustomerID;references:ID;constraint:OnDelete:CASCADE"`
+	MatchedCustomerID string    `json:"matched_customer_id" gorm:"column:matched_customer_id;type:uuid;not null;uniqueIndex:idx_duplicate_candidate;index"`
+	MatchedCustomer   *Customer `json:"-" gorm:"foreignKey:MatchedCustomerID;references:ID;constraint:OnDelete:CASCADE"`
+	ClientID          *string   `json:"client_id" gorm:"column:client_id"`
+	MatchedClientID   *string   `json:"matched_client_id" gorm:"column:matched_client_id"`
+	Field             string    `json:"field" gorm:"column:field;size:20;not null;uniqueIndex:idx_duplicate_candidate"`
+	Scope             string    `json:"scope" gorm:"column:scope;size:20;not null"`
+	CreatedAt         time.Time `json:"created_at" gorm:"column:created_at;default:CURRENT_TIMESTAMP;autoCreateTime"`
+}
+
+func (DuplicateCandidate) TableName() string {
+	return "customer_duplicate_candidates"
+}
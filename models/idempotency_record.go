@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// IdempotencyRecord holds the request fingerprint and stored response for an
+// Idempotency-Key sent by a client.
+type IdempotencyRecord struct {
+	ID             int        `json:"id" gorm:"column:id;primaryKey;autoIncrement"`
+	ClientID       string     `json:"client_id" gorm:"column:client_id;size:100;not null;uniqueIndex:idx_idempotency_client_key"`
+	Key            string     `json:"key" gorm:"column:key;size:255;not null;uniqueIndex:idx_idempotency_client_key"`
+	Method         string     `json:"method" gorm:"column:method;size:10;not null"`
+	Path           string     `json:"path" gorm:"column:path;not null"`
+	Fingerprint    string     `json:"fingerprint" gorm:"column:fingerprint;size:64;not null"`
+	Status         string     `json:"status" gorm:"column:status;size:20;not null"`
+	ResponseStatus int        `json:"response_status" gorm:"column:response_status"`
+	ContentType    string     `json:"content_type" gorm:"column:content_type"`
+	ResponseBody   []byte     `json:"response_body" gorm:"column:response_body;type:bytea;serializer:pii"`
+	LockedUntil    *time.Time `json:"locked_until" gorm:"column:locked_until"`
+	ExpiresAt      time.Time  `json:"expires_at" gorm:"column:expires_at;not null;index"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"column:created_at;default:CURRENT_TIMESTAMP;autoCreateTime"`
+	UpdatedAt      time.Time  `json:"updated_at" gorm:"column:updated_at;default:CURRENT_TIMESTAMP;autoUpdateTime"`
+}
+
+func (IdempotencyRecord) TableName() string {
+	return "idempotency_keys"
+}
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// JurisdictionRestriction blocks or controls onboarding from a country or subdivision
+// for a partner. A nil Program applies to every program (client) under the partner.
+type JurisdictionRestriction struct {
+	ID            int        `json:"id" gorm:"column:id;primaryKey;autoIncrement"`
+	Partner       string     `json:"partner" gorm:"column:partner;size:50;not null;index:idx_jurisdiction_partner_country"`
+	Program       *string    `json:"program" gorm:"column:program;size:100"`
+	Country       string     `json:"country" gorm:"column:country;size:3;not null;index:idx_jurisdiction_partner_country"`
+	Subdivision   *string    `json:"subdivision" gorm:"column:subdivision;size:10"`
+	Category      string     `json:"category" gorm:"column:category;size:20;not null"`
+	Reason        *string    `json:"reason" gorm:"column:reason"`
+	EffectiveFrom time.Time  `json:"effective_from" gorm:"column:effective_from;not null"`
+	EffectiveTo   *time.Time `json:"effective_to" gorm:"column:effective_to"`
+	CreatedAt     time.Time  `json:"created_at" gorm:"column:created_at;default:CURRENT_TIMESTAMP;autoCreateTime"`
+	UpdatedAt     time.Time  `json:"updated_at" gorm:"column:updated_at;default:CURRENT_TIMESTAMP;autoUpdateTime"`
+}
+
+func (JurisdictionRestriction) TableName() string {
+	return "jurisdiction_restrictions"
+}
+
+// IsEffective reports whether the restriction applies at the given time.
+func (r *JurisdictionRestriction) IsEffective(at time.Time) bool {
+	if at.Before(r.EffectiveFrom) {
+		return false
+	}
+	return r.EffectiveTo == nil || at.Before(*r.EffectiveTo)
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+type KYCWebhook struct {
+	ID          int            `json:"id" gorm:"column:id;primaryKey;autoIncrement"`
+	Provider    string         `json:"provider" gorm:"column:provider;size:50;not null;uniqueIndex:idx_kyc_webhooks_provider_event"`
+	EventID     string         `json:"event_id" gorm:"column:event_id;size:255;not null;uniqueIndex:idx_kyc_webhooks_provider_event"`
+	ApplicantID *string        `json:"applicant_id" gorm:"column:applicant_id"`
+	Status      *string        `json:"status" gorm:"column:status"`
+	Payload     datatypes.JSON `json:"payload" gorm:"column:payload;type:jsonb"`
+	Signature   string         `json:"signature" gorm:"column:signature"`
+	Error       *string        `json:"error" gorm:"column:error"`
+	ReceivedAt  time.Time      `json:"received_at" gorm:"column:received_at"`
+	ProcessedAt *time.Time     `json:"processed_at" gorm:"column:processed_at"`
+	CreatedAt   time.Time      `json:"created_at" gorm:"column:created_at;default:CURRENT_TIMESTAMP;autoCreateTime"`
+	UpdatedAt   time.Time      `json:"updated_at" gorm:"column:updated_at;default:CURRENT_TIMESTAMP;autoUpdateTime"`
+}
+
+func (KYCWebhook) TableName() string {
+	return "kyc_webhooks"
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+type OutboxEvent struct {
+	ID            int64          `json:"id" gorm:"column:id;primaryKey;autoIncrement"`
+	AggregateType string         `json:"aggregate_type" gorm:"column:aggregate_type;size:50;not null"`
+	AggregateID   string         `json:"aggregate_id" gorm:"column:aggregate_id;size:100;not null;index"`
+	ClientID      *string        `json:"client_id" gorm:"column:client_id;size:100"`
+	EventType     string         `json:"event_type" gorm:"column:event_type;size:100;not null"`
+	Payload       datatypes.JSON `json:"payload" gorm:"column:payload;type:jsonb"`
+	Status        string         `json:"status" gorm:"column:status;size:20;not null;index:idx_outbox_events_due"`
+	Attempts      int            `json:"attempts" gorm:"column:attempts;default:0"`
+	LastError     *string        `json:"last_error" gorm:"column:last_error"`
+	AvailableAt   time.Time      `json:"available_at" gorm:"column:available_at;index:idx_outbox_events_due"`
+	DeliveredAt   *time.Time     `json:"delivered_at" gorm:"column:delivered_at"`
+	CreatedAt     time.Time      `json:"created_at" gorm:"column:created_at;default:CURRENT_TIMESTAMP;autoCreateTime"`
+}
+
+func (OutboxEvent) TableName() string {
+	return "outbox_events"
+}
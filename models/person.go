@@ -1,21 +1,35 @@
 package models
 
-import "time"
+import (
+	"fin-auth/pii"
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type Person struct {
 	ID                 int        `json:"id" gorm:"column:id;primaryKey;autoIncrement"`
 	CustomerID         *string    `json:"customer_id" gorm:"column:customer_id;type:uuid;references:ID;constraint:OnDelete:CASCADE"`
 	Customer           *Customer  `json:"-" gorm:"foreignKey:CustomerID;references:ID"`
+	Role               string     `json:"role" gorm:"column:role;size:30;not null;default:'PRIMARY';index"`
+	OwnershipPercent   *float64   `json:"ownership_percent" gorm:"column:ownership_percent"`
 	FirstName          *string    `json:"first_name" gorm:"column:first_name"`
 	LastName           *string    `json:"last_name" gorm:"column:last_name"`
-	Email              *string    `json:"email" gorm:"column:email"`
-	Phone              *string    `json:"phone" gorm:"column:phone"`
-	DOB                *time.Time `json:"dob" gorm:"column:dob"`
+	Email              *string    `json:"email" gorm:"column:email;type:text;serializer:pii"`
+	EmailIndex         *string    `json:"-" gorm:"column:email_bidx;size:64;index"`
+	Phone              *string    `json:"phone" gorm:"column:phone;type:text;serializer:pii"`
+	PhoneIndex         *string    `json:"-" gorm:"column:phone_bidx;size:64;index"`
+	EmailVerifiedAt    *time.Time `json:"email_verified_at" gorm:"column:email_verified_at"`
+	PhoneVerifiedAt    *time.Time `json:"phone_verified_at" gorm:"column:phone_verified_at"`
+	DOB                *time.Time `json:"dob" gorm:"column:dob;type:text;serializer:pii"`
 	CountryOfResidence *string    `json:"country_of_residence" gorm:"column:country_of_residence"`
 	Nationality        *string    `json:"nationality" gorm:"column:nationality"`
-	TIN                *string    `json:"tin" gorm:"column:tin"`
+	TIN                *string    `json:"tin" gorm:"column:tin;type:text;serializer:pii"`
+	TINIndex           *string    `json:"-" gorm:"column:tin_bidx;size:64;index"`
 	Occupation         *string    `json:"occupation" gorm:"column:occupation"`
 	SourceOfFundsID    *int       `json:"source_of_funds_id" gorm:"column:source_of_funds_id"`
+	SourceOfFunds      *string    `json:"source_of_funds" gorm:"column:source_of_funds"`
+	SOFDescription     *string    `json:"sof_description" gorm:"column:sof_description"`
 	PurposeID          *int       `json:"purpose_id" gorm:"column:purpose_id"`
 	Purpose            *string    `json:"purpose" gorm:"column:purpose"`
 	MonthlyVolumeUSD   *float64   `json:"monthly_volume_usd" gorm:"column:monthly_volume_usd"`
@@ -29,3 +43,36 @@ type Person struct {
 func (Person) TableName() string {
 	return "persons"
 }
+
+// BeforeSave refreshes the blind indexes used to look up the encrypted email, phone
+// and TIN.
+func (p *Person) BeforeSave(tx *gorm.DB) error {
+	email, err := pii.EmailIndex(derefString(p.Email))
+	if err != nil {
+		return err
+	}
+	phone, err := pii.PhoneIndex(derefString(p.Phone))
+	if err != nil {
+		return err
+	}
+	tin, err := pii.TINIndex(derefString(p.TIN), derefString(p.CountryOfResidence))
+	if err != nil {
+		return err
+	}
+	p.EmailIndex, p.PhoneIndex, p.TINIndex = optionalString(email), optionalString(phone), optionalString(tin)
+	return nil
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
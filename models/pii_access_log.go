@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// PIIAccessLog records one customer whose PII was returned unmasked to a client.
+type PIIAccessLog struct {
+	ID         int       `json:"id" gorm:"column:id;primaryKey;autoIncrement"`
+	ClientID   string    `json:"client_id" gorm:"column:client_id;size:100;not null;index"`
+	CustomerID string    `json:"customer_id" gorm:"column:customer_id;type:uuid;not null;index"`
+	Method     string    `json:"method" gorm:"column:method;size:10;not null"`
+	Route      string    `json:"route" gorm:"column:route;size:255;not null"`
+	IPAddress  string    `json:"ip_address" gorm:"column:ip_address;size:64"`
+	UserAgent  string    `json:"user_agent" gorm:"column:user_agent;size:255"`
+	CreatedAt  time.Time `json:"created_at" gorm:"column:created_at;default:CURRENT_TIMESTAMP;autoCreateTime;index"`
+}
+
+func (PIIAccessLog) TableName() string {
+	return "pii_access_logs"
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// ReferenceItem is one entry of a reference catalog. Labels maps a language code to
+// the display label, with "en" always present.
+type ReferenceItem struct {
+	ID        int            `json:"id" gorm:"column:id;primaryKey;autoIncrement"`
+	Code      string         `json:"code" gorm:"column:code;size:64;not null;uniqueIndex"`
+	Labels    datatypes.JSON `json:"labels" gorm:"column:labels;type:jsonb;not null"`
+	SortOrder int            `json:"sort_order" gorm:"column:sort_order;not null;default:0"`
+	Active    bool           `json:"active" gorm:"column:active;not null;default:true"`
+	CreatedAt time.Time      `json:"created_at" gorm:"column:created_at;default:CURRENT_TIMESTAMP;autoCreateTime"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"column:updated_at;default:CURRENT_TIMESTAMP;autoUpdateTime"`
+}
+
+type Occupation struct {
+	ReferenceItem
+}
+
+func (Occupation) TableName() string {
+	return "occupations"
+}
+
+type SourceOfFunds struct {
+	ReferenceItem
+}
+
+func (SourceOfFunds) TableName() string {
+	return "sources_of_funds"
+}
+
+type Purpose struct {
+	ReferenceItem
+}
+
+func (Purpose) TableName() string {
+	return "purposes"
+}
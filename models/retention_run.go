@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// RetentionRun records one pass of the retention policies, dry runs included.
+type RetentionRun struct {
+	ID         int            `json:"id" gorm:"column:id;primaryKey;autoIncrement"`
+	DryRun     bool           `json:"dry_run" gorm:"column:dry_run;not null"`
+	Status     string         `json:"status" gorm:"column:status;size:20;not null"`
+	Results    datatypes.JSON `json:"results" gorm:"column:results;type:jsonb"`
+	Error      *string        `json:"error" gorm:"column:error"`
+	StartedAt  time.Time      `json:"started_at" gorm:"column:started_at;not null;index"`
+	FinishedAt *time.Time     `json:"finished_at" gorm:"column:finished_at"`
+}
+
+func (RetentionRun) TableName() string {
+	return "retention_runs"
+}
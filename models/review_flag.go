@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// ReviewFlag records why a customer was routed to manual review.
+type ReviewFlag struct {
+	ID         int            `json:"id" gorm:"column:id;primaryKey;autoIncrement"`
+	CustomerID string         `json:"customer_id" gorm:"column:customer_id;type:uuid;not null;index"`
+	Customer   *Customer      `json:"-" gorm:"foreignKey:CustomerID;references:ID;constraint:OnDelete:CASCADE"`
+	Source     string         `json:"source" gorm:"column:source;size:50;not null"`
+	Code       string         `json:"code" gorm:"column:code;size:100;not null"`
+	Details    datatypes.JSON `json:"details" gorm:"column:details;type:jsonb"`
+	Status     string         `json:"status" gorm:"column:status;size:20;not null"`
+	ResolvedAt *time.Time     `json:"resolved_at" gorm:"column:resolved_at"`
+	CreatedAt  time.Time      `json:"created_at" gorm:"column:created_at;default:CURRENT_TIMESTAMP;autoCreateTime"`
+	UpdatedAt  time.Time      `json:"updated_at" gorm:"column:updated_at;default:CURRENT_TIMESTAMP;autoUpdateTime"`
+}
+
+func (ReviewFlag) TableName() string {
+	return "customer_review_flags"
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// ScreeningMatch is a watchlist entry a customer's name matched above the threshold.
+type ScreeningMatch struct {
+	ID          int            `json:"id" gorm:"column:id;primaryKey;autoIncrement"`
+	CustomerID  string         `json:"customer_id" gorm:"column:customer_id;type:uuid;not null;uniqueIndex:idx_screening_customer_entry"`
+	Customer    *Customer      `json:"-" gorm:"foreignKey:CustomerID;references:ID;constraint:OnDelete:CASCADE"`
+	PersonID    int            `json:"person_id" gorm:"column:person_id;not null"`
+	ListVersion string         `json:"list_version" gorm:"column:list_version;size:64;not null"`
+	EntryUID    string         `json:"entry_uid" gorm:"column:entry_uid;size:100;not null;uniqueIndex:idx_screening_customer_entry"`
+	EntryName   string         `json:"entry_name" gorm:"column:entry_name;not null"`
+	MatchedName string         `json:"matched_name" gorm:"column:matched_name;not null"`
+	ScreenedAs  string         `json:"screened_as" gorm:"column:screened_as;not null"`
+	Programs    pq.StringArray `json:"programs" gorm:"column:programs;type:text[]"`
+	Score       float64        `json:"score" gorm:"column:score;not null"`
+	CreatedAt   time.Time      `json:"created_at" gorm:"column:created_at;default:CURRENT_TIMESTAMP;autoCreateTime"`
+}
+
+func (ScreeningMatch) TableName() string {
+	return "screening_matches"
+}
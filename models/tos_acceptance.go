@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// TOSAcceptance records a customer accepting a terms of service version, with the
+// request it came from.
+type TOSAcceptance struct {
+	ID         int        `json:"id" gorm:"column:id;primaryKey;autoIncrement"`
+	CustomerID string     `json:"customer_id" gorm:"column:customer_id;type:uuid;not null;uniqueIndex:idx_tos_acceptance"`
+	Customer   *Customer  `json:"-" gorm:"foreignKey:CustomerID;references:ID;constraint:OnDelete:CASCADE"`
+	PolicyID   string     `json:"policy_id" gorm:"column:policy_id;type:uuid;not null;uniqueIndex:idx_tos_acceptance;index"`
+	Policy     *TOSPolicy `json:"policy,omitempty" gorm:"foreignKey:PolicyID;references:ID"`
+	ClientID   *string    `json:"client_id" gorm:"column:client_id;size:100"`
+	IPAddress  *string    `json:"ip_address" gorm:"column:ip_address;size:45"`
+	UserAgent  *string    `json:"user_agent" gorm:"column:user_agent;size:255"`
+	AcceptedAt time.Time  `json:"accepted_at" gorm:"column:accepted_at;not null"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"column:created_at;default:CURRENT_TIMESTAMP;autoCreateTime"`
+}
+
+func (TOSAcceptance) TableName() string {
+	return "tos_acceptances"
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TOSPolicy is a published version of the terms of service. Versions are not edited;
+// a change is published as a new version. Customers accepted before the latest version
+// requiring re-acceptance must accept again.
+type TOSPolicy struct {
+	ID                   string    `json:"id" gorm:"type:uuid;primaryKey"`
+	Version              string    `json:"version" gorm:"column:version;size:20;not null;uniqueIndex"`
+	Title                string    `json:"title" gorm:"column:title;size:200;not null"`
+	DocumentURL          string    `json:"document_url" gorm:"column:document_url;size:500;not null"`
+	DocumentSHA256       string    `json:"document_sha256" gorm:"column:document_sha256;size:64;not null"`
+	RequiresReacceptance bool      `json:"requires_reacceptance" gorm:"column:requires_reacceptance;not null;default:false"`
+	EffectiveAt          time.Time `json:"effective_at" gorm:"column:effective_at;not null;index"`
+	CreatedAt            time.Time `json:"created_at" gorm:"column:created_at;default:CURRENT_TIMESTAMP;autoCreateTime"`
+}
+
+func (TOSPolicy) TableName() string {
+	return "tos_policies"
+}
+
+func (p *TOSPolicy) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == "" {
+		p.ID = uuid.New().String()
+	}
+	return nil
+}
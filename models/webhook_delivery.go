@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+type WebhookDelivery struct {
+	ID               int                  `json:"id" gorm:"column:id;primaryKey;autoIncrement"`
+	SubscriptionID   int                  `json:"subscription_id" gorm:"column:subscription_id;not null;index"`
+	Subscription     *WebhookSubscription `json:"-" gorm:"foreignKey:SubscriptionID;references:ID;constraint:OnDelete:CASCADE"`
+	ClientID         string               `json:"client_id" gorm:"column:client_id;size:100;not null;index"`
+	EventID          string               `json:"event_id" gorm:"column:event_id;type:uuid;not null;index"`
+	EventType        string               `json:"event_type" gorm:"column:event_type;size:100;not null"`
+	Payload          datatypes.JSON       `json:"payload" gorm:"column:payload;type:jsonb"`
+	Status           string               `json:"status" gorm:"column:status;size:20;not null;index:idx_webhook_deliveries_due"`
+	Attempts         int                  `json:"attempts" gorm:"column:attempts;default:0"`
+	NextAttemptAt    time.Time            `json:"next_attempt_at" gorm:"column:next_attempt_at;index:idx_webhook_deliveries_due"`
+	LastResponseCode *int                 `json:"last_response_code" gorm:"column:last_response_code"`
+	LastError        *string              `json:"last_error" gorm:"column:last_error"`
+	DeliveredAt      *time.Time           `json:"delivered_at" gorm:"column:delivered_at"`
+	CreatedAt        time.Time            `json:"created_at" gorm:"column:created_at;default:CURRENT_TIMESTAMP;autoCreateTime"`
+	UpdatedAt        time.Time            `json:"updated_at" gorm:"column:updated_at;default:CURRENT_TIMESTAMP;autoUpdateTime"`
+}
+
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type WebhookSubscription struct {
+	ID          int            `json:"id" gorm:"column:id;primaryKey;autoIncrement"`
+	ClientID    string         `json:"client_id" gorm:"column:client_id;size:100;not null;index"`
+	URL         string         `json:"url" gorm:"column:url;not null"`
+	EventTypes  pq.StringArray `json:"event_types" gorm:"column:event_types;type:text[]"`
+	Secret      string         `json:"-" gorm:"column:secret;size:100;not null"`
+	Description *string        `json:"description" gorm:"column:description"`
+	IsActive    bool           `json:"is_active" gorm:"column:is_active;default:true"`
+	CreatedAt   time.Time      `json:"created_at" gorm:"column:created_at;default:CURRENT_TIMESTAMP;autoCreateTime"`
+	UpdatedAt   time.Time      `json:"updated_at" gorm:"column:updated_at;default:CURRENT_TIMESTAMP;autoUpdateTime"`
+}
+
+func (WebhookSubscription) TableName() string {
+	return "webhook_subscriptions"
+}
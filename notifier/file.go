@@ -0,0 +1,67 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"fin-auth/domain"
+	"fin-auth/utils"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// File appends notifications as JSON lines to a local file, so that they can be
+// read back while developing without a mail or SMS provider.
+type File struct {
+	path string
+	mu   sync.Mutex
+}
+
+type fileEntry struct {
+	Channel string    `json:"channel"`
+	To      string    `json:"to"`
+	Subject string    `json:"subject"`
+	Body    string    `json:"body"`
+	SentAt  time.Time `json:"sent_at"`
+}
+
+func NewFileNotifier(path string) (*File, error) {
+	if path == "" {
+		path = "storage/notifications/otp.log"
+	}
+	if err := utils.EnsureDir(filepath.Dir(path)); err != nil {
+		return nil, err
+	}
+	return &File{path: path}, nil
+}
+
+func (n *File) Name() string {
+	return utils.NOTIFIER_FILE
+}
+
+func (n *File) Send(ctx context.Context, msg *domain.Notification) error {
+	line, err := json.Marshal(fileEntry{
+		Channel: msg.Channel,
+		To:      msg.To,
+		Subject: msg.Subject,
+		Body:    msg.Body,
+		SentAt:  utils.CurrentTime(),
+	})
+	if err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	f, err := os.OpenFile(n.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write notification: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package notifier
+
+import (
+	"context"
+	"fin-auth/domain"
+	"fin-auth/utils"
+	"log"
+	"regexp"
+)
+
+// codeRegex matches the one-time code in a notification body.
+var codeRegex = regexp.MustCompile(`\d{4,}`)
+
+// Log writes notifications to the application log, with codes redacted since logs are
+// shipped and kept. Use the file notifier to read codes while developing.
+type Log struct{}
+
+func NewLogNotifier() *Log {
+	return &Log{}
+}
+
+func (n *Log) Name() string {
+	return utils.NOTIFIER_LOG
+}
+
+func (n *Log) Send(ctx context.Context, msg *domain.Notification) error {
+	log.Printf("[notifier] %s to %s: %s", msg.Channel, maskTo(msg), codeRegex.ReplaceAllString(msg.Body, "******"))
+	return nil
+}
+
+func maskTo(msg *domain.Notification) string {
+	if msg.Channel == utils.OTP_CHANNEL_EMAIL {
+		return utils.MaskEmail(msg.To)
+	}
+	return utils.MaskPhone(msg.To)
+}
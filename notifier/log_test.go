@@ -0,0 +1,59 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"fin-auth/domain"
+	"fin-auth/utils"
+	"log"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestLogSend(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	tests := []struct {
+		name     string
+		msg      *domain.Notification
+		code     string
+		to       string
+		maskedTo string
+	}{
+		{
+			name:     "email",
+			msg:      &domain.Notification{Channel: utils.OTP_CHANNEL_EMAIL, To: "jane.doe@example.com", Body: "Your verification code is 483920. It expires in 10 minutes."},
+			code:     "483920",
+			to:       "jane.doe@example.com",
+			maskedTo: "j***@example.com",
+		},
+		{
+			name:     "phone",
+			msg:      &domain.Notification{Channel: utils.OTP_CHANNEL_PHONE, To: "+14155550123", Body: "Code: 0042"},
+			code:     "0042",
+			to:       "+14155550123",
+			maskedTo: "+*******0123",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			if err := NewLogNotifier().Send(context.Background(), tt.msg); err != nil {
+				t.Fatalf("Send() error = %v", err)
+			}
+			line := buf.String()
+			if strings.Contains(line, tt.code) {
+				t.Errorf("log line contains the code: %q", line)
+			}
+			if strings.Contains(line, tt.to) {
+				t.Errorf("log line contains the recipient: %q", line)
+			}
+			if !strings.Contains(line, tt.maskedTo) {
+				t.Errorf("log line = %q, want the recipient masked as %q", line, tt.maskedTo)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package notifier
+
+import (
+	"errors"
+	"fin-auth/config"
+	"fin-auth/domain"
+	"fin-auth/utils"
+	"fmt"
+)
+
+// New returns the notifier named in the config. There is no default: codes must not
+// end up somewhere nobody chose.
+func New(cfg config.OTPConfig) (domain.Notifier, error) {
+	switch cfg.Notifier {
+	case "":
+		return nil, errors.New("no notifier is configured for one-time codes")
+	case utils.NOTIFIER_LOG:
+		return NewLogNotifier(), nil
+	case utils.NOTIFIER_FILE:
+		return NewFileNotifier(cfg.FilePath)
+	default:
+		return nil, fmt.Errorf("unknown notifier %q", cfg.Notifier)
+	}
+}
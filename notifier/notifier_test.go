@@ -0,0 +1,32 @@
+package notifier
+
+import (
+	"fin-auth/config"
+	"fin-auth/utils"
+	"path/filepath"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     config.OTPConfig
+		wantErr bool
+	}{
+		{"unset", config.OTPConfig{}, true},
+		{"unknown", config.OTPConfig{Notifier: "sms"}, true},
+		{"log", config.OTPConfig{Notifier: utils.NOTIFIER_LOG}, false},
+		{"file", config.OTPConfig{Notifier: utils.NOTIFIER_FILE, FilePath: filepath.Join(t.TempDir(), "otp.log")}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := New(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && n == nil {
+				t.Errorf("New() returned no notifier")
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package repo
+
+import (
+	"context"
+	"fin-auth/cache"
+	"fin-auth/models"
+	"fin-auth/utils"
+
+	"gorm.io/gorm"
+)
+
+type Outbox struct {
+	db    *gorm.DB
+	cache *cache.RedisCache
+}
+
+func NewOutboxRepository(db *gorm.DB, cache *cache.RedisCache) *Outbox {
+	return &Outbox{
+		db:    db,
+		cache: cache,
+	}
+}
+
+func (o *Outbox) GetDB(tx ...*gorm.DB) *gorm.DB {
+	db := o.db
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db
+}
+
+func (o *Outbox) Add(ctx context.Context, tx *gorm.DB, aggregateType, aggregateId string, clientId *string, eventType string, payload interface{}) error {
+	data, err := utils.ToDatatypesJSON(payload)
+	if err != nil {
+		return err
+	}
+
+	event := &models.OutboxEvent{
+		AggregateType: aggregateType,
+		AggregateID:   aggregateId,
+		ClientID:      clientId,
+		EventType:     eventType,
+		Payload:       data,
+		Status:        utils.OUTBOX_STATUS_PENDING,
+		AvailableAt:   utils.CurrentTime(),
+	}
+	return o.GetDB(tx).Create(event).Error
+}
@@ -0,0 +1,34 @@
+package sink
+
+import (
+	"context"
+	"fin-auth/cache"
+	"fin-auth/models"
+	"fin-auth/utils"
+
+	"gorm.io/gorm"
+)
+
+// Cache drops cached customer entries, covering invalidations lost to a crash between
+// commit and the repository's own best-effort invalidation.
+type Cache struct {
+	cache *cache.RedisCache
+}
+
+func NewCacheSink(cache *cache.RedisCache) *Cache {
+	return &Cache{cache: cache}
+}
+
+func (s *Cache) Name() string {
+	return utils.OUTBOX_SINK_CACHE
+}
+
+func (s *Cache) Dispatch(ctx context.Context, tx *gorm.DB, event *models.OutboxEvent) error {
+	if event.AggregateType != utils.AGGREGATE_CUSTOMER || event.ClientID == nil {
+		return nil
+	}
+	if err := s.cache.InvalidateCustomer(ctx, *event.ClientID, event.AggregateID); err != nil {
+		return err
+	}
+	return s.cache.InvalidateCustomerList(ctx, *event.ClientID)
+}
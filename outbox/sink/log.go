@@ -0,0 +1,25 @@
+package sink
+
+import (
+	"context"
+	"fin-auth/models"
+	"fin-auth/utils"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+type Log struct{}
+
+func NewLogSink() *Log {
+	return &Log{}
+}
+
+func (s *Log) Name() string {
+	return utils.OUTBOX_SINK_LOG
+}
+
+func (s *Log) Dispatch(ctx context.Context, tx *gorm.DB, event *models.OutboxEvent) error {
+	log.Printf("[outbox] %s %s/%s (event %d)", event.EventType, event.AggregateType, event.AggregateID, event.ID)
+	return nil
+}
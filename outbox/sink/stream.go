@@ -0,0 +1,44 @@
+package sink
+
+import (
+	"context"
+	"fin-auth/cache"
+	"fin-auth/models"
+	"fin-auth/utils"
+
+	"gorm.io/gorm"
+)
+
+// Stream publishes every event to a Redis Stream for internal consumers.
+type Stream struct {
+	cache  *cache.RedisCache
+	stream string
+	maxLen int64
+}
+
+func NewStreamSink(cache *cache.RedisCache, stream string, maxLen int64) *Stream {
+	if stream == "" {
+		stream = "outbox:events"
+	}
+	return &Stream{
+		cache:  cache,
+		stream: stream,
+		maxLen: maxLen,
+	}
+}
+
+func (s *Stream) Name() string {
+	return utils.OUTBOX_SINK_STREAM
+}
+
+func (s *Stream) Dispatch(ctx context.Context, tx *gorm.DB, event *models.OutboxEvent) error {
+	return s.cache.PublishStream(ctx, s.stream, s.maxLen, map[string]interface{}{
+		"id":             event.ID,
+		"aggregate_type": event.AggregateType,
+		"aggregate_id":   event.AggregateID,
+		"client_id":      utils.SafeString(event.ClientID),
+		"event_type":     event.EventType,
+		"payload":        string(event.Payload),
+		"created_at":     event.CreatedAt.Unix(),
+	})
+}
@@ -0,0 +1,31 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"fin-auth/domain"
+	"fin-auth/models"
+	"fin-auth/utils"
+
+	"gorm.io/gorm"
+)
+
+// Webhook fans client-facing events out to the client's webhook subscriptions.
+type Webhook struct {
+	repo domain.WebhookRepository
+}
+
+func NewWebhookSink(repo domain.WebhookRepository) *Webhook {
+	return &Webhook{repo: repo}
+}
+
+func (s *Webhook) Name() string {
+	return utils.OUTBOX_SINK_WEBHOOK
+}
+
+func (s *Webhook) Dispatch(ctx context.Context, tx *gorm.DB, event *models.OutboxEvent) error {
+	if event.ClientID == nil || !utils.InArrayString(event.EventType, utils.WebhookEventTypes) {
+		return nil
+	}
+	return s.repo.EnqueueEvent(ctx, tx, *event.ClientID, event.EventType, json.RawMessage(event.Payload))
+}
@@ -2,8 +2,11 @@ package repo
 
 import (
 	"context"
+	"errors"
 	"fin-auth/cache"
 	"fin-auth/models"
+	"fin-auth/utils"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -34,3 +37,63 @@ func (o *Person) Create(ctx context.Context, person *models.Person) (*models.Per
 	}
 	return person, nil
 }
+
+// ListByCustomer returns the customer's persons, the primary one first.
+func (o *Person) ListByCustomer(ctx context.Context, customerId string) ([]*models.Person, error) {
+	var persons []*models.Person
+	err := o.db.WithContext(ctx).
+		Where("customer_id = ?", customerId).
+		Order("role <> '" + utils.PERSON_ROLE_PRIMARY + "', id").
+		Find(&persons).Error
+	if err != nil {
+		return nil, err
+	}
+	return persons, nil
+}
+
+func (o *Person) FindByID(ctx context.Context, customerId string, personId int) (*models.Person, error) {
+	var person models.Person
+	err := o.db.WithContext(ctx).Where("id = ? AND customer_id = ?", personId, customerId).First(&person).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, utils.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &person, nil
+}
+
+func (o *Person) Update(ctx context.Context, person *models.Person) error {
+	return o.db.WithContext(ctx).Save(person).Error
+}
+
+func (o *Person) Delete(ctx context.Context, person *models.Person) error {
+	return o.db.WithContext(ctx).Delete(person).Error
+}
+
+// MarkVerified records that the person proved ownership of their email or phone. It
+// only applies while the address still has the given blind index, so an address
+// changed after the code was sent is not marked; utils.ErrConflict is returned then.
+func (o *Person) MarkVerified(ctx context.Context, person *models.Person, channel, targetIndex string, at time.Time) error {
+	column, indexColumn := "email_verified_at", "email_bidx"
+	if channel == utils.OTP_CHANNEL_PHONE {
+		column, indexColumn = "phone_verified_at", "phone_bidx"
+	}
+
+	res := o.db.WithContext(ctx).Model(&models.Person{}).
+		Where("id = ? AND "+indexColumn+" = ?", person.ID, targetIndex).
+		UpdateColumn(column, at)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return utils.ErrConflict
+	}
+
+	if channel == utils.OTP_CHANNEL_PHONE {
+		person.PhoneVerifiedAt = &at
+	} else {
+		person.EmailVerifiedAt = &at
+	}
+	return nil
+}
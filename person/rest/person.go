@@ -1,20 +1,162 @@
 package rest
 
 import (
+	"errors"
 	"fin-auth/domain"
+	"fin-auth/dto"
+	"fin-auth/models"
+	"fin-auth/utils"
+	"strconv"
 
 	"github.com/labstack/echo/v4"
 )
 
-type CustomerHandler struct {
-	Service  domain.CustomerService
-	Response domain.Response
+type PersonHandler struct {
+	Service   domain.PersonService
+	PIIAccess domain.PIIAccessService
+	Response  domain.Response
 }
 
-func SetupCustomerRoutes(api *echo.Group, s domain.CustomerService) {
-	// handler := &CustomerHandler{
-	// 	Service:  s,
-	// 	Response: domain.NewResponse(),
-	// }
+func SetupPersonRoutes(api *echo.Group, s domain.PersonService, piiAccess domain.PIIAccessService) {
+	handler := &PersonHandler{
+		Service:   s,
+		PIIAccess: piiAccess,
+		Response:  domain.NewResponse(),
+	}
+	persons := api.Group("/customers/:id/persons")
+	persons.GET("", handler.listPersons)
+	persons.POST("", handler.addPerson)
+	persons.GET("/:person_id", handler.getPerson)
+	persons.PATCH("/:person_id", handler.updatePerson)
+	persons.DELETE("/:person_id", handler.removePerson)
+}
+
+func (h *PersonHandler) listPersons(c echo.Context) error {
+	unmasked := domain.Unmasked(c)
+
+	clientId := c.Get("client_id").(string)
+	persons, err := h.Service.List(c.Request().Context(), clientId, c.Param("id"))
+	if err != nil {
+		return h.handleError(c, err)
+	}
+	return h.present(c, persons, unmasked)
+}
+
+func (h *PersonHandler) getPerson(c echo.Context) error {
+	unmasked := domain.Unmasked(c)
+	personId, err := strconv.Atoi(c.Param("person_id"))
+	if err != nil {
+		return h.Response.InvalidData(c, utils.StringPtr("Invalid person id"))
+	}
+
+	clientId := c.Get("client_id").(string)
+	person, err := h.Service.Get(c.Request().Context(), clientId, c.Param("id"), personId)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+	return h.presentOne(c, person, unmasked)
+}
+
+func (h *PersonHandler) addPerson(c echo.Context) error {
+	unmasked := domain.Unmasked(c)
+	var req dto.AssociatedPersonReq
+	if err := c.Bind(&req); err != nil {
+		return h.Response.InvalidData(c, nil)
+	}
+	v := req.Validate()
+	if v.Status {
+		return h.Response.ValidationFail(c, v, nil)
+	}
+
+	clientId := c.Get("client_id").(string)
+	person, err := h.Service.AddAssociated(c.Request().Context(), clientId, c.Param("id"), &req)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+	return h.presentOne(c, person, unmasked)
+}
+
+func (h *PersonHandler) updatePerson(c echo.Context) error {
+	unmasked := domain.Unmasked(c)
+	personId, err := strconv.Atoi(c.Param("person_id"))
+	if err != nil {
+		return h.Response.InvalidData(c, utils.StringPtr("Invalid person id"))
+	}
+	var req dto.PersonPatchReq
+	if err := c.Bind(&req); err != nil {
+		return h.Response.InvalidData(c, nil)
+	}
+	v := req.Validate()
+	if v.Status {
+		return h.Response.ValidationFail(c, v, nil)
+	}
+
+	clientId := c.Get("client_id").(string)
+	person, err := h.Service.Update(c.Request().Context(), clientId, c.Param("id"), personId, &req)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+	return h.presentOne(c, person, unmasked)
+}
+
+func (h *PersonHandler) removePerson(c echo.Context) error {
+	personId, err := strconv.Atoi(c.Param("person_id"))
+	if err != nil {
+		return h.Response.InvalidData(c, utils.StringPtr("Invalid person id"))
+	}
+
+	clientId := c.Get("client_id").(string)
+	if err := h.Service.Remove(c.Request().Context(), clientId, c.Param("id"), personId); err != nil {
+		return h.handleError(c, err)
+	}
+	return h.Response.SuccessMessage(c, "Person removed")
+}
+
+// present masks the persons unless unmasked PII was requested, in which case the read
+// is logged before anything is returned.
+func (h *PersonHandler) present(c echo.Context, persons []*models.Person, unmasked bool) error {
+	if unmasked && len(persons) > 0 {
+		err := h.PIIAccess.RecordUnmaskedRead(c.Request().Context(), domain.NewPIIAccess(c), []string{c.Param("id")})
+		if err != nil {
+			return h.Response.InternalServerError(c, err)
+		}
+	}
+
+	res := make([]*dto.PersonRes, 0, len(persons))
+	for _, person := range persons {
+		res = append(res, dto.NewPersonRes(person, unmasked))
+	}
+	return h.Response.SuccessOk(c, res)
+}
+
+func (h *PersonHandler) presentOne(c echo.Context, person *models.Person, unmasked bool) error {
+	if unmasked {
+		err := h.PIIAccess.RecordUnmaskedRead(c.Request().Context(), domain.NewPIIAccess(c), []string{c.Param("id")})
+		if err != nil {
+			return h.Response.InternalServerError(c, err)
+		}
+	}
+	return h.Response.SuccessOk(c, dto.NewPersonRes(person, unmasked))
+}
 
+func (h *PersonHandler) handleError(c echo.Context, err error) error {
+	var fieldErrs utils.FieldErrors
+	var duplicateErr *utils.DuplicateCustomerError
+	switch {
+	case errors.As(err, &fieldErrs):
+		v := utils.NewValidationError()
+		v.Response = utils.ErrorResponse{}
+		for path, codes := range fieldErrs {
+			v.Response.Add(path, codes)
+		}
+		return h.Response.ValidationFail(c, v, nil)
+	case errors.As(err, &duplicateErr):
+		return h.Response.ConflictError(c, utils.StringPtr("Customer already exists"), map[string]interface{}{"fields": duplicateErr.Fields})
+	case errors.Is(err, utils.ErrNotFound):
+		return h.Response.NotFound(c, utils.StringPtr("Person not found"))
+	case errors.Is(err, utils.ErrConflict):
+		return h.Response.ConflictError(c, utils.StringPtr(err.Error()), nil)
+	default:
+		return h.Response.InternalServerError(c, err)
+	}
 }
@@ -2,20 +2,46 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fin-auth/cache"
 	"fin-auth/domain"
+	"fin-auth/dto"
 	"fin-auth/models"
+	"fin-auth/utils"
+	"fmt"
+	"log"
+	"strings"
 )
 
+// identityFields are verified by KYC; changing any of them invalidates the verification.
+var identityFields = []string{"first_name", "last_name", "dob", "tin", "nationality"}
+
+// riskFields feed the customer's risk score.
+var riskFields = []string{"country_of_residence", "nationality", "occupation_id", "source_of_fund_id", "purpose_id", "monthly_volume_usd"}
+
 type Person struct {
-	PersonRepository domain.PersonRepository
-	Cache            *cache.RedisCache
+	PersonRepository    domain.PersonRepository
+	CustomerRepository  domain.CustomerRepository
+	JurisdictionService domain.JurisdictionService
+	KYCService          domain.KYCService
+	ScreeningService    domain.ScreeningService
+	RiskService         domain.RiskService
+	ReferenceService    domain.ReferenceService
+	DedupeService       domain.DedupeService
+	Cache               *cache.RedisCache
 }
 
-func NewPersonService(repo domain.PersonRepository, cache *cache.RedisCache) *Person {
+func NewPersonService(repo domain.PersonRepository, customerRepo domain.CustomerRepository, jurisdictionService domain.JurisdictionService, kycService domain.KYCService, screeningService domain.ScreeningService, riskService domain.RiskService, referenceService domain.ReferenceService, dedupeService domain.DedupeService, cache *cache.RedisCache) *Person {
 	return &Person{
-		PersonRepository: repo,
-		Cache:            cache,
+		PersonRepository:    repo,
+		CustomerRepository:  customerRepo,
+		JurisdictionService: jurisdictionService,
+		KYCService:          kycService,
+		ScreeningService:    screeningService,
+		RiskService:         riskService,
+		ReferenceService:    referenceService,
+		DedupeService:       dedupeService,
+		Cache:               cache,
 	}
 }
 
@@ -26,3 +52,302 @@ func (s *Person) Create(ctx context.Context, person *models.Person) (*models.Per
 	}
 	return createdPerson, nil
 }
+
+func (s *Person) List(ctx context.Context, clientId, customerId string) ([]*models.Person, error) {
+	if _, err := s.CustomerRepository.FindByID(ctx, clientId, customerId); err != nil {
+		return nil, err
+	}
+	return s.PersonRepository.ListByCustomer(ctx, customerId)
+}
+
+func (s *Person) Get(ctx context.Context, clientId, customerId string, personId int) (*models.Person, error) {
+	if _, err := s.CustomerRepository.FindByID(ctx, clientId, customerId); err != nil {
+		return nil, err
+	}
+	return s.PersonRepository.FindByID(ctx, customerId, personId)
+}
+
+func (s *Person) Update(ctx context.Context, clientId, customerId string, personId int, req *dto.PersonPatchReq) (*models.Person, error) {
+	customer, err := s.findCustomer(ctx, clientId, customerId)
+	if err != nil {
+		return nil, err
+	}
+	person, err := s.PersonRepository.FindByID(ctx, customer.ID, personId)
+	if err != nil {
+		return nil, err
+	}
+	primary := person.Role == utils.PERSON_ROLE_PRIMARY
+	if primary && req.OwnershipPercent != nil {
+		return nil, utils.FieldErrors{"ownership_percent": {utils.NotAllowed()}}
+	}
+
+	changed, errs := req.Apply(person)
+	if errs != nil {
+		return nil, errs
+	}
+	if len(changed) == 0 {
+		return person, nil
+	}
+
+	if errs, err := s.resolveFinancialProfile(ctx, person, changed); err != nil {
+		return nil, err
+	} else if len(errs) > 0 {
+		return nil, errs
+	}
+
+	var controlled []dto.JurisdictionHit
+	if changedAny(changed, "nationality", "country_of_residence") {
+		if controlled, err = s.checkJurisdiction(ctx, customer, person); err != nil {
+			return nil, err
+		}
+	}
+
+	// Associated persons may be customers of their own, only the primary person is
+	// checked for duplicates.
+	var duplicates []dto.DuplicateMatch
+	if primary && changedAny(changed, "email", "phone", "tin") {
+		if duplicates, err = s.checkDuplicates(ctx, customer, person); err != nil {
+			return nil, err
+		}
+	}
+
+	// Review flags, duplicate links, the KYC status and screening are settled before the
+	// change is saved: if any of them fails nothing is saved, and if the save fails the
+	// customer is only left under closer review than needed.
+	if len(controlled) > 0 {
+		details := map[string]interface{}{"hits": controlled, "person_id": person.ID}
+		if err := s.KYCService.FlagForReview(ctx, customer, utils.REVIEW_SOURCE_JURISDICTION, utils.ControlledJurisdictionCode(), details); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.DedupeService.Link(ctx, customer, duplicates); err != nil {
+		return nil, err
+	}
+	if changedAny(changed, identityFields...) {
+		if err := s.requireReverification(ctx, customer); err != nil {
+			return nil, err
+		}
+	}
+	if changedAny(changed, "first_name", "last_name", "dob", "nationality") {
+		if err := s.screen(ctx, customer, person); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.PersonRepository.Update(ctx, person); err != nil {
+		return nil, err
+	}
+	s.invalidate(ctx, customer)
+
+	if primary && changedAny(changed, riskFields...) {
+		if _, err := s.RiskService.Reassess(ctx, clientId, customer.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return person, nil
+}
+
+func (s *Person) AddAssociated(ctx context.Context, clientId, customerId string, req *dto.AssociatedPersonReq) (*models.Person, error) {
+	customer, err := s.findCustomer(ctx, clientId, customerId)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(utils.SafeString(customer.CustomerType), utils.CUSTOMER_TYPE_BUSINESS) {
+		return nil, fmt.Errorf("associated persons are only kept for business customers: %w", utils.ErrConflict)
+	}
+
+	person := req.GetPerson(customer.ID)
+	controlled, err := s.checkJurisdiction(ctx, customer, person)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.PersonRepository.Create(ctx, person); err != nil {
+		return nil, err
+	}
+
+	if len(controlled) > 0 {
+		details := map[string]interface{}{"hits": controlled, "person_id": person.ID}
+		if err := s.KYCService.FlagForReview(ctx, customer, utils.REVIEW_SOURCE_JURISDICTION, utils.ControlledJurisdictionCode(), details); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.screen(ctx, customer, person); err != nil {
+		return nil, err
+	}
+
+	s.invalidate(ctx, customer)
+	return person, nil
+}
+
+// Remove deletes an associated person. The primary person stays with the customer.
+func (s *Person) Remove(ctx context.Context, clientId, customerId string, personId int) error {
+	customer, err := s.findCustomer(ctx, clientId, customerId)
+	if err != nil {
+		return err
+	}
+	person, err := s.PersonRepository.FindByID(ctx, customer.ID, personId)
+	if err != nil {
+		return err
+	}
+	if person.Role == utils.PERSON_ROLE_PRIMARY {
+		return fmt.Errorf("person %d is the customer's primary person: %w", person.ID, utils.ErrConflict)
+	}
+
+	if err := s.PersonRepository.Delete(ctx, person); err != nil {
+		return err
+	}
+	s.invalidate(ctx, customer)
+	return nil
+}
+
+// findCustomer loads the customer a person change applies to. Erased customers keep
+// no personal data to change.
+func (s *Person) findCustomer(ctx context.Context, clientId, customerId string) (*models.Customer, error) {
+	cached, err := s.CustomerRepository.FindByID(ctx, clientId, customerId)
+	if err != nil {
+		return nil, err
+	}
+	if cached.Customer.ErasedAt != nil {
+		return nil, fmt.Errorf("customer %s was erased: %w", customerId, utils.ErrConflict)
+	}
+	return cached.Customer, nil
+}
+
+// screen matches the person against the watchlist. When the list can't be read the
+// customer goes to manual review rather than keeping an unscreened name.
+func (s *Person) screen(ctx context.Context, customer *models.Customer, person *models.Person) error {
+	_, err := s.ScreeningService.ScreenPerson(ctx, customer, person)
+	if err == nil {
+		return nil
+	}
+	log.Printf("[person] failed to screen person %d of customer %s: %v", person.ID, customer.ID, err)
+	details := map[string]interface{}{"person_id": person.ID}
+	return s.KYCService.FlagForReview(ctx, customer, utils.REVIEW_SOURCE_SANCTIONS, utils.ScreeningUnavailableCode(), details)
+}
+
+// requireReverification sends the customer back through KYC after an identity field
+// changed. Customers that never started verification, or were rejected, keep their
+// status; a pending applicant was created from the old data and starts over.
+func (s *Person) requireReverification(ctx context.Context, customer *models.Customer) error {
+	var status string
+	switch utils.SafeString(customer.KYCStatus) {
+	case "", utils.KYC_STATUS_INCOMPLETE, utils.KYC_STATUS_REJECTED, utils.KYC_STATUS_RESUBMISSION_REQUIRED:
+		return nil
+	case utils.KYC_STATUS_PENDING:
+		status = utils.KYC_STATUS_INCOMPLETE
+	default:
+		status = utils.KYC_STATUS_RESUBMISSION_REQUIRED
+	}
+	return s.KYCService.TransitionStatus(ctx, customer, status, utils.IdentityChangedCode())
+}
+
+// resolveFinancialProfile checks the changed financial profile ids against the
+// reference catalogs and stores the occupation and purpose codes on the person.
+func (s *Person) resolveFinancialProfile(ctx context.Context, person *models.Person, changed []string) (utils.FieldErrors, error) {
+	errs := utils.FieldErrors{}
+	lookup := func(catalog string, id *int, invalidCode, field string) (*models.ReferenceItem, error) {
+		if id == nil || !utils.InArrayString(field, changed) {
+			return nil, nil
+		}
+		item, err := s.ReferenceService.Lookup(ctx, catalog, *id)
+		if errors.Is(err, utils.ErrNotFound) {
+			errs[field] = append(errs[field], invalidCode)
+			return nil, nil
+		}
+		return item, err
+	}
+
+	occupation, err := lookup(utils.REFERENCE_OCCUPATIONS, person.OccupationID, utils.InvalidOccupationId(), "occupation_id")
+	if err != nil {
+		return nil, err
+	}
+	sourceOfFunds, err := lookup(utils.REFERENCE_SOURCES_OF_FUNDS, person.SourceOfFundsID, utils.InvalidSourceOfFundId(), "source_of_fund_id")
+	if err != nil {
+		return nil, err
+	}
+	purpose, err := lookup(utils.REFERENCE_PURPOSES, person.PurposeID, utils.InvalidPurposeId(), "purpose_id")
+	if err != nil {
+		return nil, err
+	}
+
+	if occupation != nil {
+		person.Occupation = &occupation.Code
+	}
+	if sourceOfFunds != nil {
+		person.SourceOfFunds = &sourceOfFunds.Code
+	}
+	if purpose != nil {
+		person.Purpose = &purpose.Code
+	}
+	return errs, nil
+}
+
+// checkJurisdiction rejects persons whose nationality or residence is prohibited and
+// returns the controlled hits, which are flagged for review before the change is saved.
+func (s *Person) checkJurisdiction(ctx context.Context, customer *models.Customer, person *models.Person) ([]dto.JurisdictionHit, error) {
+	targets := []dto.JurisdictionTarget{
+		{Field: "nationality", Country: utils.SafeString(person.Nationality)},
+		{Field: "country_of_residence", Country: utils.SafeString(person.CountryOfResidence)},
+	}
+	hits, err := s.JurisdictionService.Evaluate(ctx, utils.SafeString(customer.ClientID), targets)
+	if err != nil {
+		return nil, err
+	}
+
+	var controlled []dto.JurisdictionHit
+	prohibited := utils.FieldErrors{}
+	for _, hit := range hits {
+		if hit.Category == utils.JURISDICTION_CONTROLLED {
+			controlled = append(controlled, hit)
+			continue
+		}
+		prohibited[hit.Field] = append(prohibited[hit.Field], utils.RestrictedCountryCode())
+	}
+	if len(prohibited) > 0 {
+		return nil, prohibited
+	}
+	return controlled, nil
+}
+
+// checkDuplicates matches the changed person against other customers. Reject rules
+// fail the change; the other matches are returned to be linked.
+func (s *Person) checkDuplicates(ctx context.Context, customer *models.Customer, person *models.Person) ([]dto.DuplicateMatch, error) {
+	matches, err := s.DedupeService.Check(ctx, utils.SafeString(customer.ClientID), person)
+	if err != nil {
+		return nil, err
+	}
+
+	var others []dto.DuplicateMatch
+	var rejected []string
+	for _, match := range matches {
+		if match.CustomerID == customer.ID {
+			continue
+		}
+		others = append(others, match)
+		if match.Action == utils.DEDUPE_ACTION_REJECT && !utils.InArrayString(match.Field, rejected) {
+			rejected = append(rejected, match.Field)
+		}
+	}
+	if len(rejected) > 0 {
+		return nil, &utils.DuplicateCustomerError{Fields: rejected}
+	}
+	return others, nil
+}
+
+func (s *Person) invalidate(ctx context.Context, customer *models.Customer) {
+	if s.Cache == nil || customer.ClientID == nil {
+		return
+	}
+	s.Cache.InvalidateCustomer(ctx, *customer.ClientID, customer.ID)
+	s.Cache.InvalidateCustomerList(ctx, *customer.ClientID)
+}
+
+func changedAny(changed []string, fields ...string) bool {
+	for _, field := range fields {
+		if utils.InArrayString(field, changed) {
+			return true
+		}
+	}
+	return false
+}
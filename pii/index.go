@@ -0,0 +1,25 @@
+package pii
+
+import (
+	"fin-auth/utils"
+	"strings"
+)
+
+// EmailIndex is the blind index of an email, compared case-insensitively.
+func EmailIndex(email string) (string, error) {
+	return BlindIndex(strings.ToLower(strings.TrimSpace(email)))
+}
+
+// PhoneIndex is the blind index of an E.164 phone number.
+func PhoneIndex(phone string) (string, error) {
+	return BlindIndex(strings.TrimSpace(phone))
+}
+
+// TINIndex is the blind index of a TIN within its issuing country.
+func TINIndex(tin, country string) (string, error) {
+	tin = utils.NormalizeTIN(tin)
+	if tin == "" || country == "" {
+		return "", nil
+	}
+	return BlindIndex(strings.ToUpper(country) + ":" + tin)
+}
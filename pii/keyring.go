@@ -0,0 +1,208 @@
+// Package pii encrypts personal data with envelope encryption: every value gets a
+// fresh AES-256-GCM data key, which is itself sealed with a versioned master key.
+package pii
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Ciphertexts look like "enc:v1:<key version>:<wrapped data key>:<sealed value>" with
+// both binary parts in unpadded base64url.
+const (
+	ciphertextPrefix = "enc:v1:"
+	dataKeySize      = 32
+)
+
+var (
+	ErrNotConfigured = errors.New("pii keyring is not configured")
+	ErrUnknownKey    = errors.New("pii master key version is not configured")
+	ErrMalformed     = errors.New("malformed pii ciphertext")
+)
+
+type keyring struct {
+	active   int
+	masters  map[int]cipher.AEAD
+	blindKey []byte
+}
+
+var (
+	mu      sync.RWMutex
+	current *keyring
+)
+
+// Init installs the master keys. active selects the version new values are sealed
+// with; older versions stay available for decryption until data is rotated.
+func Init(active int, masterKeys map[int][]byte, blindIndexKey []byte) error {
+	if _, ok := masterKeys[active]; !ok {
+		return fmt.Errorf("active pii key version %d: %w", active, ErrUnknownKey)
+	}
+	if len(blindIndexKey) < 32 {
+		return errors.New("pii blind index key must be at least 32 bytes")
+	}
+
+	k := &keyring{active: active, masters: map[int]cipher.AEAD{}, blindKey: blindIndexKey}
+	for version, key := range masterKeys {
+		aead, err := newAEAD(key)
+		if err != nil {
+			return fmt.Errorf("pii master key version %d: %w", version, err)
+		}
+		k.masters[version] = aead
+	}
+
+	mu.Lock()
+	current = k
+	mu.Unlock()
+	return nil
+}
+
+func getKeyring() (*keyring, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+	if current == nil {
+		return nil, ErrNotConfigured
+	}
+	return current, nil
+}
+
+// ActiveVersion returns the master key version new values are sealed with.
+func ActiveVersion() (int, error) {
+	k, err := getKeyring()
+	if err != nil {
+		return 0, err
+	}
+	return k.active, nil
+}
+
+// ActivePrefix is the ciphertext prefix of values sealed with the active key.
+func ActivePrefix() (string, error) {
+	version, err := ActiveVersion()
+	if err != nil {
+		return "", err
+	}
+	return ciphertextPrefix + strconv.Itoa(version) + ":", nil
+}
+
+// IsEncrypted reports whether value is a pii ciphertext rather than legacy plaintext.
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, ciphertextPrefix)
+}
+
+func Encrypt(plaintext []byte) (string, error) {
+	k, err := getKeyring()
+	if err != nil {
+		return "", err
+	}
+
+	dataKey := make([]byte, dataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", err
+	}
+	wrapped, err := seal(k.masters[k.active], dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+	sealed, err := seal(aead, plaintext)
+	if err != nil {
+		return "", err
+	}
+
+	return ciphertextPrefix + strconv.Itoa(k.active) + ":" +
+		base64.RawURLEncoding.EncodeToString(wrapped) + ":" +
+		base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+func Decrypt(value string) ([]byte, error) {
+	k, err := getKeyring()
+	if err != nil {
+		return nil, err
+	}
+
+	parts := strings.Split(strings.TrimPrefix(value, ciphertextPrefix), ":")
+	if !IsEncrypted(value) || len(parts) != 3 {
+		return nil, ErrMalformed
+	}
+	version, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return nil, ErrMalformed
+	}
+	master, ok := k.masters[version]
+	if !ok {
+		return nil, fmt.Errorf("version %d: %w", version, ErrUnknownKey)
+	}
+	wrapped, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrMalformed
+	}
+	sealed, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrMalformed
+	}
+
+	dataKey, err := open(master, wrapped)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	return open(aead, sealed)
+}
+
+// BlindIndex returns a keyed hash of an already normalized value for equality lookups
+// on encrypted columns. Empty values have no index.
+func BlindIndex(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	k, err := getKeyring()
+	if err != nil {
+		return "", err
+	}
+	mac := hmac.New(sha256.New, k.blindKey)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != 32 {
+		return nil, errors.New("key must be 32 bytes for AES-256")
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func seal(aead cipher.AEAD, plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func open(aead cipher.AEAD, sealed []byte) ([]byte, error) {
+	if len(sealed) < aead.NonceSize() {
+		return nil, ErrMalformed
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, nil)
+}
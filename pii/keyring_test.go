@@ -0,0 +1,219 @@
+package pii
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func testKey(b byte) []byte {
+	return bytes.Repeat([]byte{b}, 32)
+}
+
+func mustInit(t *testing.T, active int, keys map[int][]byte) {
+	t.Helper()
+	if err := Init(active, keys, testKey(0xbb)); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+}
+
+func TestInit(t *testing.T) {
+	tests := []struct {
+		name     string
+		active   int
+		keys     map[int][]byte
+		blindKey []byte
+		wantErr  bool
+	}{
+		{"valid", 1, map[int][]byte{1: testKey(1)}, testKey(0xbb), false},
+		{"active version missing", 2, map[int][]byte{1: testKey(1)}, testKey(0xbb), true},
+		{"short master key", 1, map[int][]byte{1: testKey(1)[:16]}, testKey(0xbb), true},
+		{"short blind index key", 1, map[int][]byte{1: testKey(1)}, testKey(0xbb)[:16], true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Init(tt.active, tt.keys, tt.blindKey); (err != nil) != tt.wantErr {
+				t.Errorf("Init() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	mustInit(t, 1, map[int][]byte{1: testKey(1)})
+
+	tests := []struct {
+		name      string
+		plaintext []byte
+	}{
+		{"empty", []byte{}},
+		{"ascii", []byte("john.doe@example.com")},
+		{"unicode", []byte("Иван Сидоров")},
+		{"binary", []byte{0, 1, 2, 0xff}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sealed, err := Encrypt(tt.plaintext)
+			if err != nil {
+				t.Fatalf("Encrypt() error = %v", err)
+			}
+			if !strings.HasPrefix(sealed, "enc:v1:1:") || !IsEncrypted(sealed) {
+				t.Fatalf("Encrypt() = %q, want an enc:v1:1: ciphertext", sealed)
+			}
+			again, _ := Encrypt(tt.plaintext)
+			if again == sealed {
+				t.Errorf("Encrypt() returned the same ciphertext twice")
+			}
+			got, err := Decrypt(sealed)
+			if err != nil {
+				t.Fatalf("Decrypt() error = %v", err)
+			}
+			if !bytes.Equal(got, tt.plaintext) {
+				t.Errorf("Decrypt() = %q, want %q", got, tt.plaintext)
+			}
+		})
+	}
+}
+
+func TestDecryptErrors(t *testing.T) {
+	mustInit(t, 1, map[int][]byte{1: testKey(1)})
+	sealed, err := Encrypt([]byte("secret"))
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	parts := strings.Split(sealed, ":")
+	tampered := []byte(parts[4])
+	tampered[len(tampered)-1] ^= 1
+
+	tests := []struct {
+		name    string
+		value   string
+		wantErr error
+	}{
+		{"plaintext", "secret", ErrMalformed},
+		{"missing part", strings.Join(parts[:4], ":"), ErrMalformed},
+		{"bad version", "enc:v1:x:" + parts[3] + ":" + parts[4], ErrMalformed},
+		{"bad base64", "enc:v1:1:!!!:" + parts[4], ErrMalformed},
+		{"unknown version", "enc:v1:9:" + parts[3] + ":" + parts[4], ErrUnknownKey},
+		{"tampered", strings.Join(append(parts[:4:4], string(tampered)), ":"), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Decrypt(tt.value)
+			if err == nil {
+				t.Fatalf("Decrypt(%q) succeeded, want an error", tt.value)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Decrypt() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestKeyRotation(t *testing.T) {
+	mustInit(t, 1, map[int][]byte{1: testKey(1)})
+	old, err := Encrypt([]byte("before rotation"))
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	oldIndex, _ := EmailIndex("john@example.com")
+
+	mustInit(t, 2, map[int][]byte{1: testKey(1), 2: testKey(2)})
+	if prefix, _ := ActivePrefix(); prefix != "enc:v1:2:" {
+		t.Errorf("ActivePrefix() = %q, want enc:v1:2:", prefix)
+	}
+	sealed, err := Encrypt([]byte("after rotation"))
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"old version", old, "before rotation"},
+		{"new version", sealed, "after rotation"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Decrypt(tt.value)
+			if err != nil {
+				t.Fatalf("Decrypt() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Decrypt() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if index, _ := EmailIndex("john@example.com"); index != oldIndex {
+		t.Errorf("blind index changed with the master key")
+	}
+
+	mustInit(t, 2, map[int][]byte{2: testKey(2)})
+	if _, err := Decrypt(old); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("Decrypt() after retiring version 1 error = %v, want %v", err, ErrUnknownKey)
+	}
+}
+
+func TestBlindIndex(t *testing.T) {
+	mustInit(t, 1, map[int][]byte{1: testKey(1)})
+
+	email := func(v string) func() (string, error) { return func() (string, error) { return EmailIndex(v) } }
+	phone := func(v string) func() (string, error) { return func() (string, error) { return PhoneIndex(v) } }
+	tin := func(v, c string) func() (string, error) { return func() (string, error) { return TINIndex(v, c) } }
+
+	tests := []struct {
+		name  string
+		a, b  func() (string, error)
+		equal bool
+	}{
+		{"email case and whitespace", email(" John@Example.COM "), email("john@example.com"), true},
+		{"other email", email("jane@example.com"), email("john@example.com"), false},
+		{"phone whitespace", phone(" +15550100 "), phone("+15550100"), true},
+		{"other phone", phone("+15550101"), phone("+15550100"), false},
+		{"tin formatting and country case", tin("123-45-6789", "us"), tin("123456789", "US"), true},
+		{"tin other country", tin("123456789", "CA"), tin("123456789", "US"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := tt.a()
+			if err != nil {
+				t.Fatalf("index error = %v", err)
+			}
+			b, err := tt.b()
+			if err != nil {
+				t.Fatalf("index error = %v", err)
+			}
+			if a == "" || b == "" {
+				t.Fatalf("index is empty")
+			}
+			if (a == b) != tt.equal {
+				t.Errorf("indexes equal = %v, want %v", a == b, tt.equal)
+			}
+		})
+	}
+}
+
+func TestBlindIndexEmpty(t *testing.T) {
+	mustInit(t, 1, map[int][]byte{1: testKey(1)})
+
+	tests := []struct {
+		name  string
+		index func() (string, error)
+	}{
+		{"empty value", func() (string, error) { return BlindIndex("") }},
+		{"empty email", func() (string, error) { return EmailIndex("  ") }},
+		{"tin without country", func() (string, error) { return TINIndex("123456789", "") }},
+		{"empty tin", func() (string, error) { return TINIndex("", "US") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := tt.index(); err != nil || got != "" {
+				t.Errorf("index = %q, %v, want no index", got, err)
+			}
+		})
+	}
+}
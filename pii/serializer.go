@@ -0,0 +1,127 @@
+package pii
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"gorm.io/gorm/schema"
+)
+
+// legacyTimeLayouts covers timestamps written before a column was encrypted.
+var legacyTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999Z07",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+var (
+	timeType  = reflect.TypeOf(time.Time{})
+	bytesType = reflect.TypeOf([]byte(nil))
+)
+
+func init() {
+	schema.RegisterSerializer("pii", Serializer{})
+}
+
+// Serializer encrypts string, byte slice and time fields tagged `serializer:pii`. Columns still
+// holding plaintext from before encryption are read as-is and sealed on the next write.
+type Serializer struct{}
+
+func (Serializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) error {
+	target := field.ReflectValueOf(ctx, dst)
+	if dbValue == nil {
+		target.Set(reflect.Zero(field.FieldType))
+		return nil
+	}
+
+	baseType := field.FieldType
+	if baseType.Kind() == reflect.Ptr {
+		baseType = baseType.Elem()
+	}
+
+	var raw string
+	switch v := dbValue.(type) {
+	case string:
+		raw = v
+	case []byte:
+		raw = string(v)
+	case time.Time:
+		raw = v.Format(time.RFC3339Nano)
+	default:
+		return fmt.Errorf("pii: unsupported column value %T for %s", dbValue, field.Name)
+	}
+	if IsEncrypted(raw) {
+		plaintext, err := Decrypt(raw)
+		if err != nil {
+			return fmt.Errorf("pii: decrypt %s: %w", field.Name, err)
+		}
+		raw = string(plaintext)
+	}
+
+	value := reflect.New(baseType).Elem()
+	switch {
+	case baseType.Kind() == reflect.String:
+		value.SetString(raw)
+	case baseType == bytesType:
+		value.SetBytes([]byte(raw))
+	case baseType == timeType:
+		t, err := parseTime(raw)
+		if err != nil {
+			return fmt.Errorf("pii: %s: %w", field.Name, err)
+		}
+		value.Set(reflect.ValueOf(t))
+	default:
+		return fmt.Errorf("pii: unsupported field type %s for %s", field.FieldType, field.Name)
+	}
+
+	if field.FieldType.Kind() == reflect.Ptr {
+		ptr := reflect.New(baseType)
+		ptr.Elem().Set(value)
+		value = ptr
+	}
+	target.Set(value)
+	return nil
+}
+
+func (Serializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue interface{}) (interface{}, error) {
+	var plaintext string
+	switch v := fieldValue.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		plaintext = v
+	case *string:
+		if v == nil {
+			return nil, nil
+		}
+		plaintext = *v
+	case []byte:
+		if v == nil {
+			return nil, nil
+		}
+		plaintext = string(v)
+	case time.Time:
+		plaintext = v.UTC().Format(time.RFC3339Nano)
+	case *time.Time:
+		if v == nil {
+			return nil, nil
+		}
+		plaintext = v.UTC().Format(time.RFC3339Nano)
+	default:
+		return nil, fmt.Errorf("pii: unsupported field type %T for %s", fieldValue, field.Name)
+	}
+	return Encrypt([]byte(plaintext))
+}
+
+func parseTime(value string) (time.Time, error) {
+	for _, layout := range legacyTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized time %q", value)
+}
@@ -0,0 +1,53 @@
+package repo
+
+import (
+	"context"
+	"fin-auth/cache"
+	"fin-auth/models"
+
+	"gorm.io/gorm"
+)
+
+const maxAccessLogsListed = 500
+
+type PIIAccess struct {
+	db    *gorm.DB
+	cache *cache.RedisCache
+}
+
+func NewPIIAccessRepository(db *gorm.DB, cache *cache.RedisCache) *PIIAccess {
+	return &PIIAccess{
+		db:    db,
+		cache: cache,
+	}
+}
+
+func (o *PIIAccess) GetDB(tx ...*gorm.DB) *gorm.DB {
+	db := o.db
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db
+}
+
+func (o *PIIAccess) Create(ctx context.Context, logs []*models.PIIAccessLog) error {
+	if len(logs) == 0 {
+		return nil
+	}
+	return o.db.WithContext(ctx).Create(&logs).Error
+}
+
+func (o *PIIAccess) List(ctx context.Context, clientId, customerId string) ([]*models.PIIAccessLog, error) {
+	var logs []*models.PIIAccessLog
+	query := o.db.WithContext(ctx).Model(&models.PIIAccessLog{})
+	if clientId != "" {
+		query = query.Where("client_id = ?", clientId)
+	}
+	if customerId != "" {
+		query = query.Where("customer_id = ?", customerId)
+	}
+	if err := query.Order("id DESC").Limit(maxAccessLogsListed).Find(&logs).Error; err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
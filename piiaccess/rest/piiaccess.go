@@ -0,0 +1,37 @@
+package rest
+
+import (
+	"fin-auth/domain"
+	"fin-auth/utils"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+type PIIAccessHandler struct {
+	Service  domain.PIIAccessService
+	Response domain.Response
+}
+
+// SetupPIIAccessAdminRoutes exposes the log of unmasked PII reads to operators.
+func SetupPIIAccessAdminRoutes(admin *echo.Group, s domain.PIIAccessService) {
+	handler := &PIIAccessHandler{
+		Service:  s,
+		Response: domain.NewResponse(),
+	}
+	admin.GET("/pii-access-logs", handler.listAccessLogs)
+}
+
+func (h *PIIAccessHandler) listAccessLogs(c echo.Context) error {
+	clientId := strings.TrimSpace(c.QueryParam("client_id"))
+	customerId := strings.TrimSpace(c.QueryParam("customer_id"))
+	if customerId != "" && !utils.IsValidUUID(customerId) {
+		return h.Response.InvalidData(c, utils.StringPtr("customer_id must be a UUID"))
+	}
+
+	logs, err := h.Service.List(c.Request().Context(), clientId, customerId)
+	if err != nil {
+		return h.Response.InternalServerError(c, err)
+	}
+	return h.Response.SuccessOk(c, logs)
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"fin-auth/domain"
+	"fin-auth/dto"
+	"fin-auth/models"
+)
+
+const maxUserAgentLength = 255
+
+type PIIAccess struct {
+	PIIAccessRepository domain.PIIAccessRepository
+}
+
+func NewPIIAccessService(repo domain.PIIAccessRepository) *PIIAccess {
+	return &PIIAccess{
+		PIIAccessRepository: repo,
+	}
+}
+
+func (s *PIIAccess) PresentCustomers(ctx context.Context, access *models.PIIAccessLog, customers []*domain.CachedCustomer, unmasked bool) ([]*dto.CustomerRes, error) {
+	res := make([]*dto.CustomerRes, 0, len(customers))
+	customerIds := make([]string, 0, len(customers))
+	for _, customer := range customers {
+		res = append(res, dto.NewCustomerRes(customer.Customer, customer.Person, customer.Address, unmasked))
+		if customer.Customer != nil {
+			customerIds = append(customerIds, customer.Customer.ID)
+		}
+	}
+
+	if unmasked {
+		if err := s.RecordUnmaskedRead(ctx, access, customerIds); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (s *PIIAccess) RecordUnmaskedRead(ctx context.Context, access *models.PIIAccessLog, customerIds []string) error {
+	userAgent := access.UserAgent
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+
+	logs := make([]*models.PIIAccessLog, 0, len(customerIds))
+	for _, customerId := range customerIds {
+		logs = append(logs, &models.PIIAccessLog{
+			ClientID:   access.ClientID,
+			CustomerID: customerId,
+			Method:     access.Method,
+			Route:      access.Route,
+			IPAddress:  access.IPAddress,
+			UserAgent:  userAgent,
+		})
+	}
+	return s.PIIAccessRepository.Create(ctx, logs)
+}
+
+func (s *PIIAccess) List(ctx context.Context, clientId, customerId string) ([]*models.PIIAccessLog, error) {
+	return s.PIIAccessRepository.List(ctx, clientId, customerId)
+}
@@ -0,0 +1,250 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fin-auth/cache"
+	"fin-auth/dto"
+	"fin-auth/models"
+	"fin-auth/utils"
+	"time"
+
+	"github.com/lib/pq"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+type Privacy struct {
+	db    *gorm.DB
+	cache *cache.RedisCache
+}
+
+func NewPrivacyRepository(db *gorm.DB, cache *cache.RedisCache) *Privacy {
+	return &Privacy{
+		db:    db,
+		cache: cache,
+	}
+}
+
+func (o *Privacy) GetDB(tx ...*gorm.DB) *gorm.DB {
+	db := o.db
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db
+}
+
+func (o *Privacy) FindCustomer(ctx context.Context, clientId, customerId string) (*models.Customer, error) {
+	var customer models.Customer
+	query := o.db.WithContext(ctx).Where("id = ?", customerId)
+	if clientId != "" {
+		query = query.Where("client_id = ?", clientId)
+	}
+	err := query.First(&customer).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, utils.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &customer, nil
+}
+
+func (o *Privacy) LoadSubjectData(ctx context.Context, customer *models.Customer, export *dto.DataSubjectExport) error {
+	db := o.db.WithContext(ctx)
+	export.Customer = customer
+
+	if err := db.Where("customer_id = ?", customer.ID).Order("id").Find(&export.Persons).Error; err != nil {
+		return err
+	}
+
+	if err := db.Where("customer_id = ?", customer.ID).Order("id").Find(&export.Addresses).Error; err != nil {
+		return err
+	}
+
+	documents, err := o.ListDocuments(ctx, customer.ID)
+	if err != nil {
+		return err
+	}
+	export.Documents = make([]*dto.ExportedDocument, 0, len(documents))
+	for _, document := range documents {
+		export.Documents = append(export.Documents, &dto.ExportedDocument{Document: document})
+	}
+
+	if err := db.Where("customer_id = ?", customer.ID).Order("id").Find(&export.ReviewFlags).Error; err != nil {
+		return err
+	}
+	if err := db.Where("customer_id = ?", customer.ID).Order("id").Find(&export.ScreeningMatches).Error; err != nil {
+		return err
+	}
+	if err := db.Where("customer_id = ? OR matched_customer_id = ?", customer.ID, customer.ID).Order("id").Find(&export.DuplicateCandidates).Error; err != nil {
+		return err
+	}
+	if err := db.Where("aggregate_type = ? AND aggregate_id = ?", utils.AGGREGATE_CUSTOMER, customer.ID).Order("id").Find(&export.Events).Error; err != nil {
+		return err
+	}
+	if err := db.Where("customer_id = ?", customer.ID).Order("id").Find(&export.PIIAccessLogs).Error; err != nil {
+		return err
+	}
+	if err := db.Preload("Policy").Where("customer_id = ?", customer.ID).Order("id").Find(&export.TOSAcceptances).Error; err != nil {
+		return err
+	}
+	requests, err := o.ListRequests(ctx, customer.ID)
+	if err != nil {
+		return err
+	}
+	export.DataRequests = requests
+	return nil
+}
+
+func (o *Privacy) ListDocuments(ctx context.Context, customerId string) ([]*models.Document, error) {
+	var documents []*models.Document
+	if err := o.db.WithContext(ctx).Where("customer_id = ?", customerId).Order("created_at").Find(&documents).Error; err != nil {
+		return nil, err
+	}
+	return documents, nil
+}
+
+// Erase clears the PII columns of the person, address and documents, replaces event
+// payloads that carried them and drops stored idempotent responses mentioning the
+// customer. The customer row, screening matches, review flags, access logs and terms of
+// service acceptances stay, the latter without IP address and user agent.
+func (o *Privacy) Erase(ctx context.Context, customer *models.Customer, erasedAt time.Time) (*dto.ErasureResult, error) {
+	result := &dto.ErasureResult{Counts: map[string]int64{}}
+	redacted, err := json.Marshal(map[string]interface{}{"customer_id": customer.ID, "erased": true})
+	if err != nil {
+		return nil, err
+	}
+
+	err = o.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&models.Person{}).Where("customer_id = ?", customer.ID).UpdateColumns(map[string]interface{}{
+			"first_name":        nil,
+			"last_name":         nil,
+			"email":             nil,
+			"email_bidx":        nil,
+			"phone":             nil,
+			"phone_bidx":        nil,
+			"email_verified_at": nil,
+			"phone_verified_at": nil,
+			"dob":               nil,
+			"tin":               nil,
+			"tin_bidx":          nil,
+			"sof_description":   nil,
+			"updated_at":        erasedAt,
+		})
+		if res.Error != nil {
+			return res.Error
+		}
+		result.Counts["persons"] = res.RowsAffected
+
+		res = tx.Model(&models.Address{}).Where("customer_id = ?", customer.ID).UpdateColumns(map[string]interface{}{
+			"street":                 nil,
+			"city":                   nil,
+			"state":                  nil,
+			"state_code":             nil,
+			"postal_code":            nil,
+			"normalized_street":      nil,
+			"normalized_city":        nil,
+			"normalized_postal_code": nil,
+			"updated_at":             erasedAt,
+		})
+		if res.Error != nil {
+			return res.Error
+		}
+		result.Counts["addresses"] = res.RowsAffected
+
+		res = tx.Model(&models.Document{}).Where("customer_id = ?", customer.ID).UpdateColumns(map[string]interface{}{
+			"file_name":  "",
+			"status":     utils.DOCUMENT_STATUS_ERASED,
+			"updated_at": erasedAt,
+		})
+		if res.Error != nil {
+			return res.Error
+		}
+		result.Counts["documents"] = res.RowsAffected
+
+		res = tx.Model(&models.OutboxEvent{}).
+			Where("aggregate_type = ? AND aggregate_id = ?", utils.AGGREGATE_CUSTOMER, customer.ID).
+			UpdateColumn("payload", datatypes.JSON(redacted))
+		if res.Error != nil {
+			return res.Error
+		}
+		result.Counts["outbox_events"] = res.RowsAffected
+
+		res = tx.Model(&models.WebhookDelivery{}).
+			Where("payload->'customer'->>'id' = ? OR payload->>'customer_id' = ?", customer.ID, customer.ID).
+			UpdateColumn("payload", datatypes.JSON(redacted))
+		if res.Error != nil {
+			return res.Error
+		}
+		result.Counts["webhook_deliveries"] = res.RowsAffected
+
+		if customer.KYCApplicantID != nil {
+			res = tx.Model(&models.KYCWebhook{}).Where("applicant_id = ?", *customer.KYCApplicantID).UpdateColumn("payload", datatypes.JSON(redacted))
+			if res.Error != nil {
+				return res.Error
+			}
+			result.Counts["kyc_webhooks"] = res.RowsAffected
+		}
+
+		if customer.ClientID != nil {
+			var records []*models.IdempotencyRecord
+			err := tx.Select("id", "key").
+				Where("client_id = ? AND position(?::bytea in response_body) > 0", *customer.ClientID, []byte(customer.ID)).
+				Find(&records).Error
+			if err != nil {
+				return err
+			}
+			if len(records) > 0 {
+				ids := make([]int, 0, len(records))
+				for _, record := range records {
+					ids = append(ids, record.ID)
+					result.IdempotencyKeys = append(result.IdempotencyKeys, record.Key)
+				}
+				if err := tx.Where("id IN ?", ids).Delete(&models.IdempotencyRecord{}).Error; err != nil {
+					return err
+				}
+			}
+			result.Counts["idempotency_keys"] = int64(len(records))
+		}
+
+		res = tx.Model(&models.TOSAcceptance{}).Where("customer_id = ?", customer.ID).UpdateColumns(map[string]interface{}{
+			"ip_address": nil,
+			"user_agent": nil,
+		})
+		if res.Error != nil {
+			return res.Error
+		}
+		result.Counts["tos_acceptances"] = res.RowsAffected
+
+		return tx.Model(customer).UpdateColumns(map[string]interface{}{
+			"meta":                    nil,
+			"available_corridors_ids": pq.Int64Array{},
+			"usd_enable":              false,
+			"erased_at":               erasedAt,
+			"updated_at":              erasedAt,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	customer.Meta = nil
+	customer.AvailableCorridorsIDs = pq.Int64Array{}
+	customer.USDEnable = new(bool)
+	customer.ErasedAt = &erasedAt
+	return result, nil
+}
+
+func (o *Privacy) CreateRequest(ctx context.Context, request *models.DataSubjectRequest) error {
+	return o.db.WithContext(ctx).Create(request).Error
+}
+
+func (o *Privacy) ListRequests(ctx context.Context, customerId string) ([]*models.DataSubjectRequest, error) {
+	var requests []*models.DataSubjectRequest
+	if err := o.db.WithContext(ctx).Where("customer_id = ?", customerId).Order("created_at").Find(&requests).Error; err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
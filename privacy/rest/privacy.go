@@ -0,0 +1,80 @@
+package rest
+
+import (
+	"errors"
+	"fin-auth/domain"
+	"fin-auth/utils"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type PrivacyHandler struct {
+	Service   domain.PrivacyService
+	PIIAccess domain.PIIAccessService
+	Response  domain.Response
+}
+
+func SetupPrivacyRoutes(api *echo.Group, s domain.PrivacyService, piiAccess domain.PIIAccessService) {
+	handler := &PrivacyHandler{
+		Service:   s,
+		PIIAccess: piiAccess,
+		Response:  domain.NewResponse(),
+	}
+	customer := api.Group("/customers/:id")
+	customer.GET("/export", handler.exportCustomer)
+	customer.POST("/erasure", handler.eraseCustomer)
+	customer.GET("/data-requests", handler.listRequests)
+}
+
+// exportCustomer returns the archive unmasked, so it needs the same scope as any other
+// unmasked read and is written to the PII access log.
+func (h *PrivacyHandler) exportCustomer(c echo.Context) error {
+	if !domain.UnmaskPermitted(c) {
+		return h.Response.ForbiddenResponse(c, nil, utils.StringPtr("Data export requires the "+utils.SCOPE_PII_UNMASKED+" scope"))
+	}
+
+	clientId := c.Get("client_id").(string)
+	archive, receipt, err := h.Service.Export(c.Request().Context(), clientId, c.Param("id"), clientId)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+	if err := h.PIIAccess.RecordUnmaskedRead(c.Request().Context(), domain.NewPIIAccess(c), []string{receipt.CustomerID}); err != nil {
+		return h.Response.InternalServerError(c, err)
+	}
+
+	c.Response().Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="customer-%s.json"`, receipt.CustomerID))
+	c.Response().Header().Set("X-Data-Request-ID", receipt.RequestID)
+	c.Response().Header().Set("X-Archive-SHA256", receipt.ArchiveSHA256)
+	return c.Blob(http.StatusOK, echo.MIMEApplicationJSON, archive)
+}
+
+func (h *PrivacyHandler) eraseCustomer(c echo.Context) error {
+	clientId := c.Get("client_id").(string)
+	receipt, err := h.Service.Erase(c.Request().Context(), clientId, c.Param("id"), clientId)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+	return h.Response.SuccessOk(c, receipt)
+}
+
+func (h *PrivacyHandler) listRequests(c echo.Context) error {
+	clientId := c.Get("client_id").(string)
+	requests, err := h.Service.ListRequests(c.Request().Context(), clientId, c.Param("id"))
+	if err != nil {
+		return h.handleError(c, err)
+	}
+	return h.Response.SuccessOk(c, requests)
+}
+
+func (h *PrivacyHandler) handleError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, utils.ErrNotFound):
+		return h.Response.NotFound(c, utils.StringPtr("Customer not found"))
+	case errors.Is(err, utils.ErrConflict):
+		return h.Response.ConflictError(c, utils.StringPtr(err.Error()), nil)
+	default:
+		return h.Response.InternalServerError(c, err)
+	}
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fin-auth/cache"
+	"fin-auth/domain"
+	"fin-auth/dto"
+	"fin-auth/models"
+	"fin-auth/utils"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// retainedRecords lists what erasure keeps, for the receipt.
+var retainedRecords = []string{
+	"customer: identifiers, KYC status and risk assessment",
+	"persons: country of residence, nationality and financial profile",
+	"addresses: country",
+	"documents: type, hash and timestamps",
+	"screening_matches: sanctions screening results",
+	"customer_review_flags: manual review history",
+	"pii_access_logs: unmasked read history",
+	"tos_acceptances: accepted policy versions and times",
+	"data_subject_requests: export and erasure receipts",
+}
+
+type Privacy struct {
+	PrivacyRepository domain.PrivacyRepository
+	Storage           domain.DocumentStorage
+	Cache             *cache.RedisCache
+}
+
+func NewPrivacyService(repo domain.PrivacyRepository, storage domain.DocumentStorage, cache *cache.RedisCache) *Privacy {
+	return &Privacy{
+		PrivacyRepository: repo,
+		Storage:           storage,
+		Cache:             cache,
+	}
+}
+
+func (s *Privacy) Export(ctx context.Context, clientId, customerId, requestedBy string) ([]byte, *dto.DataSubjectReceipt, error) {
+	customer, err := s.PrivacyRepository.FindCustomer(ctx, clientId, customerId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	export := &dto.DataSubjectExport{
+		RequestID:  uuid.New().String(),
+		ExportedAt: time.Now().UTC(),
+	}
+	if err := s.PrivacyRepository.LoadSubjectData(ctx, customer, export); err != nil {
+		return nil, nil, err
+	}
+	for _, document := range export.Documents {
+		if document.Status == utils.DOCUMENT_STATUS_ERASED {
+			continue
+		}
+		content, err := s.readDocument(ctx, document.StorageKey)
+		if err != nil {
+			return nil, nil, fmt.Errorf("read document %s: %w", document.ID, err)
+		}
+		document.Content = content
+	}
+
+	archive, err := json.Marshal(export)
+	if err != nil {
+		return nil, nil, err
+	}
+	digest := sha256.Sum256(archive)
+
+	receipt := &dto.DataSubjectReceipt{
+		RequestID:     export.RequestID,
+		Type:          utils.DATA_REQUEST_EXPORT,
+		CustomerID:    customer.ID,
+		ClientID:      customer.ClientID,
+		RequestedBy:   requestedBy,
+		CompletedAt:   time.Now().UTC(),
+		ArchiveSHA256: hex.EncodeToString(digest[:]),
+	}
+	if err := s.recordRequest(ctx, receipt); err != nil {
+		return nil, nil, err
+	}
+	return archive, receipt, nil
+}
+
+func (s *Privacy) Erase(ctx context.Context, clientId, customerId, requestedBy string) (*dto.DataSubjectReceipt, error) {
+	customer, err := s.PrivacyRepository.FindCustomer(ctx, clientId, customerId)
+	if err != nil {
+		return nil, err
+	}
+	if customer.ErasedAt != nil {
+		return nil, fmt.Errorf("customer was erased on %s: %w", customer.ErasedAt.Format(time.RFC3339), utils.ErrConflict)
+	}
+
+	// Files go first: if the database step fails the erasure can simply be retried.
+	documents, err := s.PrivacyRepository.ListDocuments(ctx, customer.ID)
+	if err != nil {
+		return nil, err
+	}
+	var files int64
+	for _, document := range documents {
+		if document.Status == utils.DOCUMENT_STATUS_ERASED {
+			continue
+		}
+		if err := s.Storage.Delete(ctx, document.StorageKey); err != nil {
+			return nil, fmt.Errorf("delete document %s: %w", document.ID, err)
+		}
+		files++
+	}
+
+	erasedAt := time.Now().UTC()
+	result, err := s.PrivacyRepository.Erase(ctx, customer, erasedAt)
+	if err != nil {
+		return nil, err
+	}
+	result.Counts["document_files"] = files
+
+	if s.Cache != nil {
+		clientId := ""
+		if customer.ClientID != nil {
+			clientId = *customer.ClientID
+			for _, key := range result.IdempotencyKeys {
+				if err := s.Cache.InvalidateIdempotencyRecord(ctx, clientId, key); err != nil {
+					return nil, err
+				}
+			}
+		}
+		purged, err := s.Cache.PurgeCustomer(ctx, clientId, customer.ID)
+		if err != nil {
+			return nil, err
+		}
+		result.Counts["cache_keys"] = purged
+	}
+
+	receipt := &dto.DataSubjectReceipt{
+		RequestID:   uuid.New().String(),
+		Type:        utils.DATA_REQUEST_ERASURE,
+		CustomerID:  customer.ID,
+		ClientID:    customer.ClientID,
+		RequestedBy: requestedBy,
+		CompletedAt: erasedAt,
+		Erased:      result.Counts,
+		Retained:    retainedRecords,
+	}
+	if err := s.recordRequest(ctx, receipt); err != nil {
+		return nil, err
+	}
+	log.Printf("Erased customer %s for %s (request %s)", customer.ID, requestedBy, receipt.RequestID)
+	return receipt, nil
+}
+
+func (s *Privacy) ListRequests(ctx context.Context, clientId, customerId string) ([]*models.DataSubjectRequest, error) {
+	if _, err := s.PrivacyRepository.FindCustomer(ctx, clientId, customerId); err != nil {
+		return nil, err
+	}
+	return s.PrivacyRepository.ListRequests(ctx, customerId)
+}
+
+func (s *Privacy) recordRequest(ctx context.Context, receipt *dto.DataSubjectReceipt) error {
+	body, err := json.Marshal(receipt)
+	if err != nil {
+		return err
+	}
+	return s.PrivacyRepository.CreateRequest(ctx, &models.DataSubjectRequest{
+		ID:          receipt.RequestID,
+		CustomerID:  receipt.CustomerID,
+		ClientID:    receipt.ClientID,
+		Type:        receipt.Type,
+		RequestedBy: receipt.RequestedBy,
+		Receipt:     body,
+		CompletedAt: receipt.CompletedAt,
+	})
+}
+
+func (s *Privacy) readDocument(ctx context.Context, key string) ([]byte, error) {
+	file, err := s.Storage.Open(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
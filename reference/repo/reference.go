@@ -0,0 +1,42 @@
+package repo
+
+import (
+	"context"
+	"fin-auth/cache"
+	"fin-auth/models"
+
+	"gorm.io/gorm"
+)
+
+type Reference struct {
+	db    *gorm.DB
+	cache *cache.RedisCache
+}
+
+func NewReferenceRepository(db *gorm.DB, cache *cache.RedisCache) *Reference {
+	return &Reference{
+		db:    db,
+		cache: cache,
+	}
+}
+
+func (o *Reference) GetDB(tx ...*gorm.DB) *gorm.DB {
+	db := o.db
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db
+}
+
+// ListActive returns the active items of a catalog, where catalog is its table name.
+func (o *Reference) ListActive(ctx context.Context, catalog string) ([]*models.ReferenceItem, error) {
+	var items []*models.ReferenceItem
+	err := o.db.Table(catalog).
+		Where("active = ?", true).
+		Order("sort_order, id").
+		Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
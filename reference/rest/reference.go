@@ -0,0 +1,84 @@
+package rest
+
+import (
+	"errors"
+	"fin-auth/domain"
+	"fin-auth/utils"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/text/language"
+)
+
+type ReferenceHandler struct {
+	Service  domain.ReferenceService
+	Response domain.Response
+}
+
+func SetupReferenceRoutes(api *echo.Group, s domain.ReferenceService) {
+	handler := &ReferenceHandler{
+		Service:  s,
+		Response: domain.NewResponse(),
+	}
+	reference := api.Group("/reference")
+	reference.GET("/tin-formats/:country", handler.tinFormats)
+	reference.GET("/occupations", handler.listCatalog(utils.REFERENCE_OCCUPATIONS))
+	reference.GET("/sources-of-funds", handler.listCatalog(utils.REFERENCE_SOURCES_OF_FUNDS))
+	reference.GET("/purposes", handler.listCatalog(utils.REFERENCE_PURPOSES))
+}
+
+func (h *ReferenceHandler) tinFormats(c echo.Context) error {
+	res, err := h.Service.TINFormats(c.Request().Context(), c.Param("country"))
+	switch {
+	case errors.Is(err, utils.ErrBadRequest):
+		return h.Response.InvalidData(c, utils.StringPtr("Country must be an ISO 3166-1 alpha-3 code"))
+	case errors.Is(err, utils.ErrNotFound):
+		return h.Response.NotFound(c, utils.StringPtr("No TIN formats registered for this country"))
+	case err != nil:
+		return h.Response.InternalServerError(c, err)
+	}
+	return h.Response.SuccessOk(c, res)
+}
+
+// listCatalog serves a catalog in the language from ?lang= or Accept-Language and
+// answers 304 when the client's If-None-Match matches the rendered content.
+func (h *ReferenceHandler) listCatalog(catalog string) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		res, err := h.Service.List(c.Request().Context(), catalog, requestLanguage(c))
+		if err != nil {
+			return h.Response.InternalServerError(c, err)
+		}
+
+		header := c.Response().Header()
+		header.Set("ETag", res.ETag)
+		header.Set("Cache-Control", "private, max-age=300")
+		header.Set("Vary", "Accept-Language")
+		if etagMatches(c.Request().Header.Get("If-None-Match"), res.ETag) {
+			return c.NoContent(http.StatusNotModified)
+		}
+		return h.Response.SuccessOk(c, res)
+	}
+}
+
+func requestLanguage(c echo.Context) string {
+	if lang := strings.ToLower(strings.TrimSpace(c.QueryParam("lang"))); lang != "" {
+		return lang
+	}
+	tags, _, err := language.ParseAcceptLanguage(c.Request().Header.Get("Accept-Language"))
+	if err != nil || len(tags) == 0 {
+		return utils.DEFAULT_LANGUAGE
+	}
+	base, _ := tags[0].Base()
+	return base.String()
+}
+
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fin-auth/cache"
+	"fin-auth/domain"
+	"fin-auth/dto"
+	"fin-auth/models"
+	"fin-auth/utils"
+	"strings"
+	"time"
+)
+
+const referenceCacheTTL = time.Hour
+
+type Reference struct {
+	ReferenceRepository domain.ReferenceRepository
+	Cache               *cache.RedisCache
+}
+
+func NewReferenceService(repo domain.ReferenceRepository, cache *cache.RedisCache) *Reference {
+	return &Reference{
+		ReferenceRepository: repo,
+		Cache:               cache,
+	}
+}
+
+func (s *Reference) TINFormats(ctx context.Context, country string) (*dto.TINFormatsRes, error) {
+	country = strings.ToUpper(strings.TrimSpace(country))
+	if !utils.IsISOAlpha3Format(country) {
+		return nil, utils.ErrBadRequest
+	}
+
+	rules := utils.TINRulesFor(country)
+	if len(rules) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	return &dto.TINFormatsRes{
+		Country: country,
+		Formats: rules,
+	}, nil
+}
+
+// List renders the catalog in the requested language, falling back to English for
+// items without a label in that language.
+func (s *Reference) List(ctx context.Context, catalog, language string) (*dto.ReferenceListRes, error) {
+	items, err := s.itemsFor(ctx, catalog)
+	if err != nil {
+		return nil, err
+	}
+	if language == "" {
+		language = utils.DEFAULT_LANGUAGE
+	}
+
+	res := &dto.ReferenceListRes{
+		Catalog:  catalog,
+		Language: language,
+		Items:    make([]dto.ReferenceItemRes, 0, len(items)),
+	}
+	for _, item := range items {
+		labels := map[string]string{}
+		if err := json.Unmarshal(item.Labels, &labels); err != nil {
+			return nil, err
+		}
+		label, ok := labels[language]
+		if !ok {
+			label = labels[utils.DEFAULT_LANGUAGE]
+		}
+		res.Items = append(res.Items, dto.ReferenceItemRes{
+			ID:     item.ID,
+			Code:   item.Code,
+			Label:  label,
+			Labels: labels,
+		})
+	}
+
+	body, err := json.Marshal(res)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(body)
+	res.ETag = `"` + hex.EncodeToString(sum[:16]) + `"`
+	return res, nil
+}
+
+func (s *Reference) Lookup(ctx context.Context, catalog string, id int) (*models.ReferenceItem, error) {
+	items, err := s.itemsFor(ctx, catalog)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		if item.ID == id {
+			return item, nil
+		}
+	}
+	return nil, utils.ErrNotFound
+}
+
+// itemsFor loads the active items of a catalog through the cache. Catalogs only change
+// through migrations, so entries simply expire.
+func (s *Reference) itemsFor(ctx context.Context, catalog string) ([]*models.ReferenceItem, error) {
+	if !utils.InArrayString(catalog, utils.ReferenceCatalogs) {
+		return nil, utils.ErrNotFound
+	}
+
+	if s.Cache != nil {
+		if items, err := s.Cache.GetCachedReferenceItems(ctx, catalog); err == nil {
+			return items, nil
+		}
+	}
+
+	items, err := s.ReferenceRepository.ListActive(ctx, catalog)
+	if err != nil {
+		return nil, err
+	}
+
+	// An empty catalog is not cached: it was not seeded yet, and caching it would reject
+	// every id until the entry expired.
+	if s.Cache != nil && len(items) > 0 {
+		s.Cache.CacheReferenceItems(ctx, catalog, items, referenceCacheTTL)
+	}
+	return items, nil
+}
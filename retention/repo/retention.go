@@ -0,0 +1,217 @@
+package repo
+
+import (
+	"context"
+	"fin-auth/cache"
+	"fin-auth/models"
+	"fin-auth/utils"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// retentionTarget is one table of an entity and the condition making its rows
+// eligible. The condition takes the cutoff as its only argument.
+type retentionTarget struct {
+	table string
+	where string
+}
+
+var retentionTargets = map[string][]retentionTarget{
+	utils.RETENTION_ENTITY_SESSIONS: {
+		{table: "access_tokens", where: "deleted_at < ?"},
+		{table: "refresh_tokens", where: "deleted_at < ?"},
+	},
+	utils.RETENTION_ENTITY_SOFT_DELETED: {
+		{table: "users", where: "deleted_at < ?"},
+		{table: "secrets", where: "deleted_at < ?"},
+	},
+	utils.RETENTION_ENTITY_CUSTOMERS: {
+		{table: "customers", where: "erased_at < ? AND purged_at IS NULL"},
+	},
+	utils.RETENTION_ENTITY_DOCUMENTS: {
+		{table: "documents", where: fmt.Sprintf("status IN ('%s', '%s') AND updated_at < ?", utils.DOCUMENT_STATUS_REJECTED, utils.DOCUMENT_STATUS_ERASED)},
+	},
+	utils.RETENTION_ENTITY_AUDIT_EVENTS: {
+		{table: "outbox_events", where: fmt.Sprintf("status IN ('%s', '%s') AND created_at < ?", utils.OUTBOX_STATUS_DELIVERED, utils.OUTBOX_STATUS_FAILED)},
+		{table: "webhook_deliveries", where: fmt.Sprintf("status IN ('%s', '%s') AND created_at < ?", utils.WEBHOOK_DELIVERY_DELIVERED, utils.WEBHOOK_DELIVERY_DEAD)},
+		{table: "kyc_webhooks", where: "received_at < ?"},
+	},
+	utils.RETENTION_ENTITY_PII_ACCESS_LOGS: {
+		{table: "pii_access_logs", where: "created_at < ?"},
+	},
+	utils.RETENTION_ENTITY_IDEMPOTENCY_KEYS: {
+		{table: "idempotency_keys", where: "expires_at < ?"},
+	},
+}
+
+type Retention struct {
+	db    *gorm.DB
+	cache *cache.RedisCache
+}
+
+func NewRetentionRepository(db *gorm.DB, cache *cache.RedisCache) *Retention {
+	return &Retention{
+		db:    db,
+		cache: cache,
+	}
+}
+
+func (o *Retention) GetDB(tx ...*gorm.DB) *gorm.DB {
+	db := o.db
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db
+}
+
+func (o *Retention) Count(ctx context.Context, entity string, cutoff time.Time) (int64, error) {
+	targets, ok := retentionTargets[entity]
+	if !ok {
+		return 0, fmt.Errorf("unknown retention entity %q: %w", entity, utils.ErrBadRequest)
+	}
+
+	var total int64
+	for _, target := range targets {
+		var count int64
+		if err := o.db.WithContext(ctx).Table(target.table).Where(target.where, cutoff).Count(&count).Error; err != nil {
+			return 0, err
+		}
+		total += count
+	}
+	return total, nil
+}
+
+func (o *Retention) PurgeBatch(ctx context.Context, entity string, cutoff time.Time, limit int, removeFiles func(keys []string) error) (int64, int64, error) {
+	targets, ok := retentionTargets[entity]
+	if !ok {
+		return 0, 0, fmt.Errorf("unknown retention entity %q: %w", entity, utils.ErrBadRequest)
+	}
+
+	switch entity {
+	case utils.RETENTION_ENTITY_CUSTOMERS:
+		return o.purgeCustomers(ctx, targets[0], cutoff, limit, removeFiles)
+	case utils.RETENTION_ENTITY_DOCUMENTS:
+		return o.purgeDocuments(ctx, targets[0], cutoff, limit, removeFiles)
+	}
+
+	var purged int64
+	for _, target := range targets {
+		res := o.db.WithContext(ctx).Exec(
+			fmt.Sprintf("DELETE FROM %s WHERE ctid IN (SELECT ctid FROM %s WHERE %s LIMIT ?)", target.table, target.table, target.where),
+			cutoff, limit,
+		)
+		if res.Error != nil {
+			return purged, 0, res.Error
+		}
+		purged += res.RowsAffected
+	}
+	return purged, 0, nil
+}
+
+// customerPurgeTables hold the records of an erased customer that retention removes.
+// The customer row stays behind with its screening matches and review flags, which
+// are kept for sanctions and review history.
+var customerPurgeTables = []string{"documents", "customer_duplicate_candidates", "tos_acceptances", "persons", "addresses"}
+
+// purgeCustomers deletes the customers' document files, then their remaining records
+// in one transaction, and marks the customers purged.
+func (o *Retention) purgeCustomers(ctx context.Context, target retentionTarget, cutoff time.Time, limit int, removeFiles func(keys []string) error) (int64, int64, error) {
+	var customers []*models.Customer
+	if err := o.db.WithContext(ctx).Select("id", "client_id").Where(target.where, cutoff).Limit(limit).Find(&customers).Error; err != nil {
+		return 0, 0, err
+	}
+	if len(customers) == 0 {
+		return 0, 0, nil
+	}
+
+	ids := make([]string, 0, len(customers))
+	for _, customer := range customers {
+		ids = append(ids, customer.ID)
+	}
+
+	var keys []string
+	if err := o.db.WithContext(ctx).Model(&models.Document{}).Where("customer_id IN ? AND status <> ?", ids, utils.DOCUMENT_STATUS_ERASED).Pluck("storage_key", &keys).Error; err != nil {
+		return 0, 0, err
+	}
+	if err := removeFiles(keys); err != nil {
+		return 0, 0, err
+	}
+
+	var purged int64
+	err := o.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, table := range customerPurgeTables {
+			where := "customer_id IN ?"
+			args := []interface{}{ids}
+			if table == "customer_duplicate_candidates" {
+				where += " OR matched_customer_id IN ?"
+				args = append(args, ids)
+			}
+			if err := tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE %s", table, where), args...).Error; err != nil {
+				return err
+			}
+		}
+		res := tx.Model(&models.Customer{}).Where("id IN ?", ids).Update("purged_at", utils.CurrentTime())
+		purged = res.RowsAffected
+		return res.Error
+	})
+	if err != nil {
+		return 0, int64(len(keys)), err
+	}
+
+	if o.cache != nil {
+		for _, customer := range customers {
+			clientId := ""
+			if customer.ClientID != nil {
+				clientId = *customer.ClientID
+			}
+			o.cache.PurgeCustomer(ctx, clientId, customer.ID)
+		}
+	}
+	return purged, int64(len(keys)), nil
+}
+
+func (o *Retention) purgeDocuments(ctx context.Context, target retentionTarget, cutoff time.Time, limit int, removeFiles func(keys []string) error) (int64, int64, error) {
+	var documents []*models.Document
+	if err := o.db.WithContext(ctx).Select("id", "storage_key", "status").Where(target.where, cutoff).Limit(limit).Find(&documents).Error; err != nil {
+		return 0, 0, err
+	}
+	if len(documents) == 0 {
+		return 0, 0, nil
+	}
+
+	ids := make([]string, 0, len(documents))
+	keys := make([]string, 0, len(documents))
+	for _, document := range documents {
+		ids = append(ids, document.ID)
+		if document.Status != utils.DOCUMENT_STATUS_ERASED {
+			keys = append(keys, document.StorageKey)
+		}
+	}
+	if err := removeFiles(keys); err != nil {
+		return 0, 0, err
+	}
+
+	res := o.db.WithContext(ctx).Where("id IN ?", ids).Delete(&models.Document{})
+	if res.Error != nil {
+		return 0, int64(len(keys)), res.Error
+	}
+	return res.RowsAffected, int64(len(keys)), nil
+}
+
+func (o *Retention) CreateRun(ctx context.Context, run *models.RetentionRun) error {
+	return o.db.WithContext(ctx).Create(run).Error
+}
+
+func (o *Retention) UpdateRun(ctx context.Context, run *models.RetentionRun) error {
+	return o.db.WithContext(ctx).Model(run).Select("status", "results", "error", "finished_at").Updates(run).Error
+}
+
+func (o *Retention) ListRuns(ctx context.Context, limit int) ([]*models.RetentionRun, error) {
+	var runs []*models.RetentionRun
+	if err := o.db.WithContext(ctx).Order("id DESC").Limit(limit).Find(&runs).Error; err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
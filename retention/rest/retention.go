@@ -0,0 +1,29 @@
+package rest
+
+import (
+	"fin-auth/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+type RetentionHandler struct {
+	Service  domain.RetentionService
+	Response domain.Response
+}
+
+// SetupRetentionAdminRoutes lists past retention runs for compliance reporting.
+func SetupRetentionAdminRoutes(admin *echo.Group, s domain.RetentionService) {
+	handler := &RetentionHandler{
+		Service:  s,
+		Response: domain.NewResponse(),
+	}
+	admin.GET("/retention-runs", handler.listRuns)
+}
+
+func (h *RetentionHandler) listRuns(c echo.Context) error {
+	runs, err := h.Service.ListRuns(c.Request().Context())
+	if err != nil {
+		return h.Response.InternalServerError(c, err)
+	}
+	return h.Response.SuccessOk(c, runs)
+}
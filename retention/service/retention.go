@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fin-auth/config"
+	"fin-auth/domain"
+	"fin-auth/dto"
+	"fin-auth/models"
+	"fin-auth/utils"
+	"fmt"
+	"log"
+	"time"
+)
+
+const (
+	defaultRetentionBatchSize = 500
+	maxRetentionRunsListed    = 100
+)
+
+type Retention struct {
+	RetentionRepository domain.RetentionRepository
+	Storage             domain.DocumentStorage
+	Config              config.RetentionConfig
+}
+
+func NewRetentionService(repo domain.RetentionRepository, storage domain.DocumentStorage, cfg config.RetentionConfig) *Retention {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultRetentionBatchSize
+	}
+	return &Retention{
+		RetentionRepository: repo,
+		Storage:             storage,
+		Config:              cfg,
+	}
+}
+
+// Run applies the policies one after another. A failing policy is recorded in its
+// result and the others still run; the run is then marked failed.
+func (s *Retention) Run(ctx context.Context, dryRun bool) (*models.RetentionRun, error) {
+	run := &models.RetentionRun{
+		DryRun:    dryRun,
+		Status:    utils.RETENTION_RUN_RUNNING,
+		StartedAt: time.Now().UTC(),
+	}
+	if err := s.RetentionRepository.CreateRun(ctx, run); err != nil {
+		return nil, err
+	}
+
+	results := make([]*dto.RetentionResult, 0, len(s.Config.Policies))
+	failed := 0
+	for _, policy := range s.Config.Policies {
+		if policy.RetainDays <= 0 {
+			continue
+		}
+		result := s.apply(ctx, policy, run.StartedAt, dryRun)
+		if result.Error != nil {
+			failed++
+		}
+		results = append(results, result)
+	}
+
+	body, err := json.Marshal(results)
+	if err != nil {
+		return nil, err
+	}
+	finishedAt := time.Now().UTC()
+	run.Results = body
+	run.FinishedAt = &finishedAt
+	run.Status = utils.RETENTION_RUN_COMPLETED
+	if failed > 0 {
+		run.Status = utils.RETENTION_RUN_FAILED
+		run.Error = utils.StringPtr(fmt.Sprintf("%d of %d policies failed", failed, len(results)))
+	}
+	if err := s.RetentionRepository.UpdateRun(ctx, run); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+func (s *Retention) apply(ctx context.Context, policy config.RetentionPolicy, now time.Time, dryRun bool) *dto.RetentionResult {
+	result := &dto.RetentionResult{
+		Entity:     policy.Entity,
+		RetainDays: policy.RetainDays,
+		Cutoff:     now.AddDate(0, 0, -policy.RetainDays),
+	}
+
+	matched, err := s.RetentionRepository.Count(ctx, policy.Entity, result.Cutoff)
+	if err != nil {
+		result.Error = utils.StringPtr(err.Error())
+		return result
+	}
+	result.Matched = matched
+	if dryRun || matched == 0 {
+		return result
+	}
+
+	// Batches keep each delete short. Rows becoming eligible during the run are left
+	// for the next one so the loop always ends.
+	for result.Purged < matched {
+		purged, files, err := s.RetentionRepository.PurgeBatch(ctx, policy.Entity, result.Cutoff, s.Config.BatchSize, func(keys []string) error {
+			return s.removeFiles(ctx, keys)
+		})
+		result.Purged += purged
+		result.Files += files
+		if err != nil {
+			result.Error = utils.StringPtr(err.Error())
+			break
+		}
+		if purged == 0 {
+			break
+		}
+	}
+
+	log.Printf("[retention] %s: purged %d of %d rows older than %s", policy.Entity, result.Purged, result.Matched, result.Cutoff.Format(time.RFC3339))
+	return result
+}
+
+func (s *Retention) removeFiles(ctx context.Context, keys []string) error {
+	for _, key := range keys {
+		if err := s.Storage.Delete(ctx, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Retention) ListRuns(ctx context.Context) ([]*models.RetentionRun, error) {
+	return s.RetentionRepository.ListRuns(ctx, maxRetentionRunsListed)
+}
@@ -0,0 +1,51 @@
+package rest
+
+import (
+	"errors"
+	"fin-auth/domain"
+	"fin-auth/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+type RiskHandler struct {
+	Service  domain.RiskService
+	Response domain.Response
+}
+
+func SetupRiskRoutes(api *echo.Group, s domain.RiskService) {
+	handler := &RiskHandler{
+		Service:  s,
+		Response: domain.NewResponse(),
+	}
+	risk := api.Group("/customers/:id/risk")
+	risk.GET("", handler.getAssessment)
+	risk.POST("", handler.reassess)
+}
+
+func (h *RiskHandler) getAssessment(c echo.Context) error {
+	clientId := c.Get("client_id").(string)
+	res, err := h.Service.GetAssessment(c.Request().Context(), clientId, c.Param("id"))
+	if err != nil {
+		return h.handleError(c, err)
+	}
+	return h.Response.SuccessOk(c, res)
+}
+
+func (h *RiskHandler) reassess(c echo.Context) error {
+	clientId := c.Get("client_id").(string)
+	res, err := h.Service.Reassess(c.Request().Context(), clientId, c.Param("id"))
+	if err != nil {
+		return h.handleError(c, err)
+	}
+	return h.Response.SuccessOk(c, res)
+}
+
+func (h *RiskHandler) handleError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, utils.ErrNotFound):
+		return h.Response.NotFound(c, utils.StringPtr("Customer not found"))
+	default:
+		return h.Response.InternalServerError(c, err)
+	}
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fin-auth/config"
+	"fin-auth/domain"
+	"fin-auth/dto"
+	"fin-auth/models"
+	"fin-auth/utils"
+)
+
+const (
+	defaultMediumThreshold = 30
+	defaultHighThreshold   = 60
+)
+
+type Risk struct {
+	CustomerRepository domain.CustomerRepository
+	CorridorService    domain.CorridorService
+	Config             config.RiskConfig
+}
+
+func NewRiskService(customerRepo domain.CustomerRepository, corridorService domain.CorridorService, cfg config.RiskConfig) *Risk {
+	if cfg.MediumThreshold <= 0 {
+		cfg.MediumThreshold = defaultMediumThreshold
+	}
+	if cfg.HighThreshold <= cfg.MediumThreshold {
+		cfg.HighThreshold = max(defaultHighThreshold, cfg.MediumThreshold+1)
+	}
+	return &Risk{
+		CustomerRepository: customerRepo,
+		CorridorService:    corridorService,
+		Config:             cfg,
+	}
+}
+
+// Apply never downgrades STANDARD back to RELIANCE when the tier later drops; that
+// stays a manual decision.
+func (s *Risk) Apply(customer *models.Customer, person *models.Person) *dto.RiskAssessment {
+	assessment := s.score(person)
+
+	if assessment.Tier == utils.RISK_TIER_HIGH && s.Config.ForceStandardOnHigh && customer.VerificationType == utils.VERIFICATION_TYPE_RELIANCE {
+		customer.VerificationType = utils.VERIFICATION_TYPE_STANDARD
+		assessment.VerificationTypeForced = true
+	}
+	assessment.VerificationType = customer.VerificationType
+
+	factors, _ := json.Marshal(assessment.Factors)
+	customer.RiskScore = &assessment.Score
+	customer.RiskTier = &assessment.Tier
+	customer.RiskFactors = factors
+	customer.RiskAssessedAt = &assessment.AssessedAt
+	return assessment
+}
+
+func (s *Risk) Reassess(ctx context.Context, clientId, customerId string) (*dto.RiskAssessment, error) {
+	cached, err := s.CustomerRepository.FindByID(ctx, clientId, customerId)
+	if err != nil {
+		return nil, err
+	}
+	if cached.Person == nil {
+		return nil, utils.ErrNotFound
+	}
+
+	assessment := s.Apply(cached.Customer, cached.Person)
+	if err := s.CustomerRepository.UpdateRisk(ctx, cached.Customer); err != nil {
+		return nil, err
+	}
+	if err := s.CorridorService.Refresh(ctx, clientId, customerId); err != nil {
+		return nil, err
+	}
+	return assessment, nil
+}
+
+func (s *Risk) GetAssessment(ctx context.Context, clientId, customerId string) (*dto.RiskAssessment, error) {
+	cached, err := s.CustomerRepository.FindByID(ctx, clientId, customerId)
+	if err != nil {
+		return nil, err
+	}
+
+	customer := cached.Customer
+	if customer.RiskTier == nil {
+		return s.Reassess(ctx, clientId, customerId)
+	}
+
+	assessment := &dto.RiskAssessment{
+		Score:            utils.SafeInt(customer.RiskScore),
+		Tier:             *customer.RiskTier,
+		Factors:          []dto.RiskFactor{},
+		VerificationType: customer.VerificationType,
+	}
+	if customer.RiskAssessedAt != nil {
+		assessment.AssessedAt = *customer.RiskAssessedAt
+	}
+	if len(customer.RiskFactors) > 0 {
+		if err := json.Unmarshal(customer.RiskFactors, &assessment.Factors); err != nil {
+			return nil, err
+		}
+	}
+	return assessment, nil
+}
+
+// score sums the points of every matching rule. Only rules with non-zero points are
+// reported as factors.
+func (s *Risk) score(person *models.Person) *dto.RiskAssessment {
+	rules := s.Config.Rules
+	assessment := &dto.RiskAssessment{
+		Factors:    []dto.RiskFactor{},
+		AssessedAt: utils.CurrentTime(),
+	}
+	add := func(code string, points int, value interface{}) {
+		if points == 0 {
+			return
+		}
+		assessment.Score += points
+		assessment.Factors = append(assessment.Factors, dto.RiskFactor{Code: code, Points: points, Value: value})
+	}
+
+	nationality := utils.SafeString(person.Nationality)
+	residence := utils.SafeString(person.CountryOfResidence)
+	if nationality != "" && utils.InArrayString(nationality, rules.HighRiskCountries) {
+		add(utils.RISK_FACTOR_HIGH_RISK_NATIONALITY, rules.HighRiskNationality, nationality)
+	}
+	if residence != "" && utils.InArrayString(residence, rules.HighRiskCountries) {
+		add(utils.RISK_FACTOR_HIGH_RISK_RESIDENCE, rules.HighRiskResidence, residence)
+	}
+	if nationality != "" && residence != "" && nationality != residence {
+		add(utils.RISK_FACTOR_RESIDENCE_MISMATCH, rules.ResidenceMismatch, map[string]string{
+			"nationality":          nationality,
+			"country_of_residence": residence,
+		})
+	}
+
+	if person.MonthlyVolumeUSD != nil {
+		points := 0
+		for _, band := range rules.MonthlyVolume {
+			if *person.MonthlyVolumeUSD >= band.MinUSD && band.Points > points {
+				points = band.Points
+			}
+		}
+		add(utils.RISK_FACTOR_MONTHLY_VOLUME, points, *person.MonthlyVolumeUSD)
+	}
+	if person.Occupation != nil {
+		add(utils.RISK_FACTOR_OCCUPATION, rules.Occupations[*person.Occupation], *person.Occupation)
+	}
+	if person.SourceOfFunds != nil {
+		add(utils.RISK_FACTOR_SOURCE_OF_FUNDS, rules.SourcesOfFunds[*person.SourceOfFunds], *person.SourceOfFunds)
+	}
+	if person.Purpose != nil {
+		add(utils.RISK_FACTOR_PURPOSE, rules.Purposes[*person.Purpose], *person.Purpose)
+	}
+
+	switch {
+	case assessment.Score >= s.Config.HighThreshold:
+		assessment.Tier = utils.RISK_TIER_HIGH
+	case assessment.Score >= s.Config.MediumThreshold:
+		assessment.Tier = utils.RISK_TIER_MEDIUM
+	default:
+		assessment.Tier = utils.RISK_TIER_LOW
+	}
+	return assessment
+}
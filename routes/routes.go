@@ -2,6 +2,7 @@ package routes
 
 import (
 	addressRepo "fin-auth/address/repo"
+	addressRest "fin-auth/address/rest"
 	addressService "fin-auth/address/service"
 	authMiddleware "fin-auth/auth/middleware"
 	authRes "fin-auth/auth/repo"
@@ -9,11 +10,56 @@ import (
 	authService "fin-auth/auth/service"
 	"fin-auth/cache"
 	"fin-auth/config"
+	corridorRepo "fin-auth/corridor/repo"
+	corridorRest "fin-auth/corridor/rest"
+	corridorService "fin-auth/corridor/service"
 	customerRepo "fin-auth/customer/repo"
 	customerRest "fin-auth/customer/rest"
 	customerService "fin-auth/customer/service"
+	dedupeRepo "fin-auth/dedupe/repo"
+	dedupeRest "fin-auth/dedupe/rest"
+	dedupeService "fin-auth/dedupe/service"
+	documentRepo "fin-auth/document/repo"
+	documentRest "fin-auth/document/rest"
+	documentService "fin-auth/document/service"
+	idempotencyRepo "fin-auth/idempotency/repo"
+	jurisdictionRepo "fin-auth/jurisdiction/repo"
+	jurisdictionRest "fin-auth/jurisdiction/rest"
+	jurisdictionService "fin-auth/jurisdiction/service"
+	kycProvider "fin-auth/kyc/provider"
+	kycRepo "fin-auth/kyc/repo"
+	kycRest "fin-auth/kyc/rest"
+	kycService "fin-auth/kyc/service"
+	"fin-auth/notifier"
+	outboxRepo "fin-auth/outbox/repo"
 	personRepo "fin-auth/person/repo"
+	personRest "fin-auth/person/rest"
 	personService "fin-auth/person/service"
+	piiAccessRepo "fin-auth/piiaccess/repo"
+	piiAccessRest "fin-auth/piiaccess/rest"
+	piiAccessService "fin-auth/piiaccess/service"
+	privacyRepo "fin-auth/privacy/repo"
+	privacyRest "fin-auth/privacy/rest"
+	privacyService "fin-auth/privacy/service"
+	referenceRepo "fin-auth/reference/repo"
+	referenceRest "fin-auth/reference/rest"
+	referenceService "fin-auth/reference/service"
+	retentionRepo "fin-auth/retention/repo"
+	retentionRest "fin-auth/retention/rest"
+	retentionService "fin-auth/retention/service"
+	riskRest "fin-auth/risk/rest"
+	riskService "fin-auth/risk/service"
+	screeningRepo "fin-auth/screening/repo"
+	screeningService "fin-auth/screening/service"
+	"fin-auth/storage"
+	tosRepo "fin-auth/tos/repo"
+	tosRest "fin-auth/tos/rest"
+	tosService "fin-auth/tos/service"
+	verificationRest "fin-auth/verification/rest"
+	verificationService "fin-auth/verification/service"
+	webhookRepo "fin-auth/webhook/repo"
+	webhookRest "fin-auth/webhook/rest"
+	webhookService "fin-auth/webhook/service"
 	"log"
 
 	"github.com/labstack/echo/v4"
@@ -44,15 +90,95 @@ func SetupRoutes(e *echo.Echo) {
 
 	protected := api.Group("")
 	protected.Use(authMiddleware.AuthMiddleware(or, redisCache))
+	protected.Use(authMiddleware.UnmaskMiddleware())
 	protected.Use(authMiddleware.RateLimitMiddleware(redisCache, "api"))
+	protected.Use(authMiddleware.IdempotencyMiddleware(idempotencyRepo.NewIdempotencyRepository(db, redisCache), config.GetConfig().Idempotency))
 	authRest.SetupProtectedRoutes(protected, authSvc)
 
-	cr := customerRepo.NewCustomerRepository(db, redisCache)
+	admin := api.Group("/admin")
+	admin.Use(authMiddleware.AdminMiddleware(config.GetConfig().Admin.APIKeys))
+	authRest.SetupAuthAdminRoutes(admin, authSvc)
+
+	par := piiAccessRepo.NewPIIAccessRepository(db, redisCache)
+	piiAccessSvc := piiAccessService.NewPIIAccessService(par)
+	piiAccessRest.SetupPIIAccessAdminRoutes(admin, piiAccessSvc)
+
+	wr := webhookRepo.NewWebhookRepository(db, redisCache)
+	webhookSvc := webhookService.NewWebhookService(wr, redisCache)
+	webhookRest.SetupWebhookRoutes(protected, webhookSvc)
+
+	obr := outboxRepo.NewOutboxRepository(db, redisCache)
+	cr := customerRepo.NewCustomerRepository(db, redisCache, obr)
 	pr := personRepo.NewPersonRepository(db, redisCache)
-	ps := personService.NewPersonService(pr, redisCache)
+	kr := kycRepo.NewKYCRepository(db, redisCache)
+	kycProviders := kycProvider.NewRegistry(kycProvider.NewFake(), kycProvider.NewBridge())
+	crr := corridorRepo.NewCorridorRepository(db, redisCache)
+	corridorSvc := corridorService.NewCorridorService(crr, cr)
+	corridorRest.SetupCorridorRoutes(protected, corridorSvc)
+	corridorRest.SetupCorridorAdminRoutes(admin, corridorSvc)
+
+	kycSvc := kycService.NewKYCService(cr, kr, kycProviders, corridorSvc, config.GetConfig().KYC, redisCache)
+	kycRest.SetupKYCRoutes(protected, kycSvc, piiAccessSvc)
+	kycRest.SetupKYCWebhookRoutes(api, kycSvc)
+
+	jr := jurisdictionRepo.NewJurisdictionRepository(db, redisCache)
+	jurisdictionSvc := jurisdictionService.NewJurisdictionService(jr, config.GetConfig().Jurisdiction, redisCache)
+	jurisdictionRest.SetupJurisdictionAdminRoutes(admin, jurisdictionSvc)
+
+	sr := screeningRepo.NewScreeningRepository(db, redisCache)
+	screeningSvc := screeningService.NewScreeningService(sr, kycSvc, config.GetConfig().Screening)
+
+	riskSvc := riskService.NewRiskService(cr, corridorSvc, config.GetConfig().Risk)
+	riskRest.SetupRiskRoutes(protected, riskSvc)
+
 	ar := addressRepo.NewAddressRepository(db, redisCache)
-	as := addressService.NewAddressService(ar, redisCache)
-	customerSvc := customerService.NewCustomerService(cr, ps, as, redisCache)
-	customerRest.SetupCustomerRoutes(protected, customerSvc)
+	as := addressService.NewAddressService(ar, cr, jurisdictionSvc, kycSvc, screeningSvc, riskSvc, redisCache)
+	addressRest.SetupAddressRoutes(protected, as, piiAccessSvc)
+
+	rr := referenceRepo.NewReferenceRepository(db, redisCache)
+	referenceSvc := referenceService.NewReferenceService(rr, redisCache)
+	referenceRest.SetupReferenceRoutes(protected, referenceSvc)
+
+	ddr := dedupeRepo.NewDedupeRepository(db, redisCache)
+	dedupeSvc := dedupeService.NewDedupeService(ddr, cr, kycSvc, config.GetConfig().Dedupe)
+	dedupeRest.SetupDedupeRoutes(protected, dedupeSvc)
+	dedupeRest.SetupDedupeAdminRoutes(admin, dedupeSvc)
+
+	ps := personService.NewPersonService(pr, cr, jurisdictionSvc, kycSvc, screeningSvc, riskSvc, referenceSvc, dedupeSvc, redisCache)
+	personRest.SetupPersonRoutes(protected, ps, piiAccessSvc)
+
+	otpNotifier, err := notifier.New(config.GetConfig().OTP)
+	if err != nil {
+		log.Fatalf("Failed to initialize notifier: %v", err)
+	}
+	verificationSvc := verificationService.NewVerificationService(pr, cr, otpNotifier, config.GetConfig().OTP, redisCache)
+	verificationRest.SetupVerificationRoutes(protected, verificationSvc)
+
+	tr := tosRepo.NewTOSRepository(db, redisCache)
+	tosSvc := tosService.NewTOSService(tr, cr, config.GetConfig().TOS, redisCache)
+	tosRest.SetupTOSRoutes(protected, tosSvc)
+	tosRest.SetupTOSAdminRoutes(admin, tosSvc)
+
+	customerSvc := customerService.NewCustomerService(cr, ps, as, jurisdictionSvc, kycSvc, screeningSvc, riskSvc, referenceSvc, dedupeSvc, tosSvc, redisCache)
+	customerRest.SetupCustomerRoutes(protected, customerSvc, piiAccessSvc)
+
+	localStorage, err := storage.NewLocalStorage(config.GetConfig().Storage.LocalPath)
+	if err != nil {
+		log.Fatalf("Failed to initialize document storage: %v", err)
+	}
+	documentStorage := storage.NewSealedStorage(localStorage)
+	dr := documentRepo.NewDocumentRepository(db, redisCache)
+	documentSvc := documentService.NewDocumentService(dr, cr, documentStorage, kycSvc, config.GetConfig().Storage, redisCache)
+	documentRest.SetupDocumentRoutes(protected, documentSvc)
+	documentRest.SetupDocumentDownloadRoutes(api, documentSvc)
+
+	pvr := privacyRepo.NewPrivacyRepository(db, redisCache)
+	privacySvc := privacyService.NewPrivacyService(pvr, documentStorage, redisCache)
+	privacyRest.SetupPrivacyRoutes(protected, privacySvc, piiAccessSvc)
+
+	rtr := retentionRepo.NewRetentionRepository(db, redisCache)
+	retentionSvc := retentionService.NewRetentionService(rtr, documentStorage, config.GetConfig().Retention)
+	retentionRest.SetupRetentionAdminRoutes(admin, retentionSvc)
+
 	SetupHealthRoutes(e, db)
 }
@@ -0,0 +1,51 @@
+package repo
+
+import (
+	"context"
+	"fin-auth/cache"
+	"fin-auth/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Screening struct {
+	db    *gorm.DB
+	cache *cache.RedisCache
+}
+
+func NewScreeningRepository(db *gorm.DB, cache *cache.RedisCache) *Screening {
+	return &Screening{
+		db:    db,
+		cache: cache,
+	}
+}
+
+func (o *Screening) GetDB(tx ...*gorm.DB) *gorm.DB {
+	db := o.db
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db
+}
+
+func (o *Screening) CreateMatch(ctx context.Context, match *models.ScreeningMatch) (bool, error) {
+	result := o.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(match)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (o *Screening) ListPersonsAfter(ctx context.Context, afterId, limit int) ([]*models.Person, error) {
+	var persons []*models.Person
+	err := o.db.Preload("Customer").
+		Where("id > ? AND customer_id IS NOT NULL", afterId).
+		Order("id").
+		Limit(limit).
+		Find(&persons).Error
+	if err != nil {
+		return nil, err
+	}
+	return persons, nil
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"fin-auth/config"
+	"fin-auth/domain"
+	"fin-auth/dto"
+	"fin-auth/models"
+	"fin-auth/screening/watchlist"
+	"fin-auth/utils"
+	"strings"
+	"sync"
+)
+
+const (
+	defaultMatchThreshold = 0.92
+	rescreenBatchSize     = 500
+)
+
+type Screening struct {
+	ScreeningRepository domain.ScreeningRepository
+	KYCService          domain.KYCService
+	Watchlist           *watchlist.Store
+	Config              config.ScreeningConfig
+
+	mu                  sync.Mutex
+	lastRescreenVersion string
+}
+
+func NewScreeningService(repo domain.ScreeningRepository, kycService domain.KYCService, cfg config.ScreeningConfig) *Screening {
+	if cfg.Threshold <= 0 || cfg.Threshold > 1 {
+		cfg.Threshold = defaultMatchThreshold
+	}
+	return &Screening{
+		ScreeningRepository: repo,
+		KYCService:          kycService,
+		Watchlist:           watchlist.NewStore(cfg.ListPath),
+		Config:              cfg,
+	}
+}
+
+func (s *Screening) ScreenPerson(ctx context.Context, customer *models.Customer, person *models.Person) ([]*models.ScreeningMatch, error) {
+	index, err := s.Watchlist.Current()
+	if err != nil {
+		return nil, err
+	}
+	return s.screen(ctx, index, customer, person)
+}
+
+// Prepare screens a person who is not stored yet. It returns the matches and the review
+// flag to store along with the new customer; without matches there is no flag.
+func (s *Screening) Prepare(ctx context.Context, person *models.Person) ([]*models.ScreeningMatch, *models.ReviewFlag, error) {
+	index, err := s.Watchlist.Current()
+	if err != nil {
+		return nil, nil, err
+	}
+	matches := s.search(index, person)
+	if len(matches) == 0 {
+		return nil, nil, nil
+	}
+	flag, err := dto.NewReviewFlag("", utils.REVIEW_SOURCE_SANCTIONS, utils.SanctionsMatchCode(), s.flagDetails(index, matches))
+	if err != nil {
+		return nil, nil, err
+	}
+	return matches, flag, nil
+}
+
+func (s *Screening) RescreenAll(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	index, err := s.Watchlist.Current()
+	if err != nil {
+		return 0, err
+	}
+	if index.Version == s.lastRescreenVersion {
+		return 0, nil
+	}
+
+	screened, afterId := 0, 0
+	for {
+		persons, err := s.ScreeningRepository.ListPersonsAfter(ctx, afterId, rescreenBatchSize)
+		if err != nil {
+			return screened, err
+		}
+		if len(persons) == 0 {
+			break
+		}
+		for _, person := range persons {
+			afterId = person.ID
+			if person.Customer == nil {
+				continue
+			}
+			if _, err := s.screen(ctx, index, person.Customer, person); err != nil {
+				return screened, err
+			}
+			screened++
+		}
+	}
+
+	s.lastRescreenVersion = index.Version
+	return screened, nil
+}
+
+// screen stores matches the customer has not had before and flags the customer for
+// review when any are new, so a list refresh does not re-flag known matches.
+func (s *Screening) screen(ctx context.Context, index *watchlist.Index, customer *models.Customer, person *models.Person) ([]*models.ScreeningMatch, error) {
+	var created []*models.ScreeningMatch
+	for _, record := range s.search(index, person) {
+		record.CustomerID = customer.ID
+		isNew, err := s.ScreeningRepository.CreateMatch(ctx, record)
+		if err != nil {
+			return nil, err
+		}
+		if isNew {
+			created = append(created, record)
+		}
+	}
+	if len(created) == 0 {
+		return nil, nil
+	}
+
+	if err := s.KYCService.FlagForReview(ctx, customer, utils.REVIEW_SOURCE_SANCTIONS, utils.SanctionsMatchCode(), s.flagDetails(index, created)); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// search returns the person's watchlist matches, not yet tied to a customer.
+func (s *Screening) search(index *watchlist.Index, person *models.Person) []*models.ScreeningMatch {
+	name := strings.TrimSpace(utils.SafeString(person.FirstName) + " " + utils.SafeString(person.LastName))
+	if name == "" {
+		return nil
+	}
+
+	var records []*models.ScreeningMatch
+	for _, match := range index.Search(name, s.Config.Threshold) {
+		records = append(records, &models.ScreeningMatch{
+			PersonID:    person.ID,
+			ListVersion: index.Version,
+			EntryUID:    match.Entry.UID,
+			EntryName:   match.Entry.Name,
+			MatchedName: match.MatchedName,
+			ScreenedAs:  name,
+			Programs:    match.Entry.Programs,
+			Score:       utils.RoundTo(match.Score, 4),
+		})
+	}
+	return records
+}
+
+func (s *Screening) flagDetails(index *watchlist.Index, matches []*models.ScreeningMatch) map[string]interface{} {
+	return map[string]interface{}{
+		"list_version": index.Version,
+		"threshold":    s.Config.Threshold,
+		"matches":      matches,
+	}
+}
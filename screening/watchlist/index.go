@@ -0,0 +1,153 @@
+package watchlist
+
+import (
+	"sort"
+	"strings"
+)
+
+// Entry is one listed party with its primary name and aliases.
+type Entry struct {
+	UID      string   `json:"uid"`
+	Name     string   `json:"name"`
+	Type     string   `json:"type"`
+	Programs []string `json:"programs"`
+	Aliases  []string `json:"aliases"`
+}
+
+// Match is the best-scoring name of an entry for a query.
+type Match struct {
+	Entry       *Entry  `json:"entry"`
+	MatchedName string  `json:"matched_name"`
+	Score       float64 `json:"score"`
+}
+
+const subsetDiscount = 0.97
+
+type indexedName struct {
+	entry      int
+	raw        string
+	normalized string
+	sorted     string
+}
+
+// Index is an in-memory fuzzy name index. Names are blocked by the first two letters
+// of each token, so a query is only scored against names sharing a token prefix.
+type Index struct {
+	Version string
+	entries []*Entry
+	names   []indexedName
+	buckets map[string][]int
+}
+
+func NewIndex(version string, entries []*Entry) *Index {
+	ix := &Index{
+		Version: version,
+		entries: entries,
+		buckets: map[string][]int{},
+	}
+	for i, entry := range entries {
+		for _, name := range append([]string{entry.Name}, entry.Aliases...) {
+			normalized := Normalize(name)
+			if normalized == "" {
+				continue
+			}
+			pos := len(ix.names)
+			ix.names = append(ix.names, indexedName{
+				entry:      i,
+				raw:        name,
+				normalized: normalized,
+				sorted:     sortedTokens(normalized),
+			})
+			for _, key := range blockKeys(normalized) {
+				ix.buckets[key] = append(ix.buckets[key], pos)
+			}
+		}
+	}
+	return ix
+}
+
+// Len returns the number of entries in the index.
+func (ix *Index) Len() int {
+	return len(ix.entries)
+}
+
+// Search returns entries whose best name scores at least threshold against name,
+// highest score first. Entries with a type other than individual are skipped.
+func (ix *Index) Search(name string, threshold float64) []Match {
+	query := Normalize(name)
+	if query == "" {
+		return nil
+	}
+	querySorted := sortedTokens(query)
+
+	best := map[int]Match{}
+	seen := map[int]bool{}
+	for _, key := range blockKeys(query) {
+		for _, pos := range ix.buckets[key] {
+			if seen[pos] {
+				continue
+			}
+			seen[pos] = true
+
+			candidate := ix.names[pos]
+			entry := ix.entries[candidate.entry]
+			if entry.Type != "" && !strings.EqualFold(entry.Type, "individual") {
+				continue
+			}
+
+			score := max(
+				JaroWinkler(query, candidate.normalized),
+				JaroWinkler(querySorted, candidate.sorted),
+				tokenSubsetScore(query, candidate.normalized),
+			)
+			if score < threshold {
+				continue
+			}
+			if current, ok := best[candidate.entry]; !ok || score > current.Score {
+				best[candidate.entry] = Match{Entry: entry, MatchedName: candidate.raw, Score: score}
+			}
+		}
+	}
+
+	matches := make([]Match, 0, len(best))
+	for _, match := range best {
+		matches = append(matches, match)
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+	return matches
+}
+
+// tokenSubsetScore matches each query token to its closest candidate token, so a name
+// missing a middle name still scores high. It is discounted slightly below an exact
+// full-name match and needs at least two tokens on both sides.
+func tokenSubsetScore(query, candidate string) float64 {
+	queryTokens, candidateTokens := strings.Fields(query), strings.Fields(candidate)
+	if len(queryTokens) < 2 || len(candidateTokens) < 2 {
+		return 0
+	}
+
+	var total float64
+	for _, q := range queryTokens {
+		var best float64
+		for _, c := range candidateTokens {
+			best = max(best, JaroWinkler(q, c))
+		}
+		total += best
+	}
+	return total / float64(len(queryTokens)) * subsetDiscount
+}
+
+func blockKeys(normalized string) []string {
+	tokens := strings.Fields(normalized)
+	keys := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		r := []rune(token)
+		if len(r) > 2 {
+			r = r[:2]
+		}
+		keys = append(keys, string(r))
+	}
+	return keys
+}
@@ -0,0 +1,213 @@
+package watchlist
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Load reads a watchlist file. Files ending in .xml are parsed as the OFAC SDN XML
+// format; anything else as CSV. The index version is derived from the file content.
+func Load(path string) (*Index, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*Entry
+	if strings.EqualFold(filepath.Ext(path), ".xml") {
+		entries, err = parseXML(bytes.NewReader(data))
+	} else {
+		entries, err = parseCSV(bytes.NewReader(data))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse watchlist %s: %w", path, err)
+	}
+
+	sum := sha256.Sum256(data)
+	return NewIndex(hex.EncodeToString(sum[:8]), entries), nil
+}
+
+// parseCSV accepts either a file with a header row naming uid, name, type, programs
+// and aliases (aliases and programs separated by ";"), or the headerless OFAC sdn.csv
+// layout: ent_num, SDN_Name, SDN_Type, Program, ...
+func parseCSV(r io.Reader) ([]*Entry, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	columns := map[string]int{"uid": 0, "name": 1, "type": 2, "programs": 3, "aliases": -1}
+	if header := rows[0]; len(header) > 0 && !isNumeric(header[0]) {
+		columns = map[string]int{"uid": -1, "name": -1, "type": -1, "programs": -1, "aliases": -1}
+		for i, column := range header {
+			switch strings.ToLower(strings.TrimSpace(column)) {
+			case "uid", "ent_num", "id":
+				columns["uid"] = i
+			case "name", "sdn_name":
+				columns["name"] = i
+			case "type", "sdn_type":
+				columns["type"] = i
+			case "programs", "program":
+				columns["programs"] = i
+			case "aliases", "alias":
+				columns["aliases"] = i
+			}
+		}
+		if columns["uid"] < 0 || columns["name"] < 0 {
+			return nil, fmt.Errorf("csv header must include uid and name columns")
+		}
+		rows = rows[1:]
+	}
+
+	field := func(row []string, column string) string {
+		i := columns[column]
+		if i < 0 || i >= len(row) {
+			return ""
+		}
+		value := strings.TrimSpace(row[i])
+		// OFAC uses "-0-" for empty fields.
+		if value == "-0-" {
+			return ""
+		}
+		return value
+	}
+
+	entries := make([]*Entry, 0, len(rows))
+	for _, row := range rows {
+		uid, name := field(row, "uid"), field(row, "name")
+		if uid == "" || name == "" {
+			continue
+		}
+		entries = append(entries, &Entry{
+			UID:      uid,
+			Name:     name,
+			Type:     field(row, "type"),
+			Programs: splitList(field(row, "programs")),
+			Aliases:  splitList(field(row, "aliases")),
+		})
+	}
+	return entries, nil
+}
+
+type sdnXMLName struct {
+	FirstName string `xml:"firstName"`
+	LastName  string `xml:"lastName"`
+}
+
+func (n sdnXMLName) String() string {
+	return strings.TrimSpace(n.FirstName + " " + n.LastName)
+}
+
+type sdnXMLEntry struct {
+	UID      string       `xml:"uid"`
+	SDNType  string       `xml:"sdnType"`
+	Programs []string     `xml:"programList>program"`
+	AKAs     []sdnXMLName `xml:"akaList>aka"`
+	sdnXMLName
+}
+
+func parseXML(r io.Reader) ([]*Entry, error) {
+	var list struct {
+		Entries []sdnXMLEntry `xml:"sdnEntry"`
+	}
+	if err := xml.NewDecoder(r).Decode(&list); err != nil {
+		return nil, err
+	}
+
+	entries := make([]*Entry, 0, len(list.Entries))
+	for _, e := range list.Entries {
+		name := e.sdnXMLName.String()
+		if e.UID == "" || name == "" {
+			continue
+		}
+		entry := &Entry{
+			UID:      e.UID,
+			Name:     name,
+			Type:     e.SDNType,
+			Programs: e.Programs,
+		}
+		for _, aka := range e.AKAs {
+			if alias := aka.String(); alias != "" {
+				entry.Aliases = append(entry.Aliases, alias)
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ";") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func isNumeric(value string) bool {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return false
+	}
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Store keeps the current index for a file and reloads it when the file's size or
+// modification time changes.
+type Store struct {
+	path    string
+	mu      sync.Mutex
+	index   *Index
+	modTime time.Time
+	size    int64
+}
+
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// Current returns the index for the file as it is now on disk.
+func (s *Store) Current() (*Index, error) {
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.index != nil && info.ModTime().Equal(s.modTime) && info.Size() == s.size {
+		return s.index, nil
+	}
+
+	index, err := Load(s.path)
+	if err != nil {
+		return nil, err
+	}
+	s.index, s.modTime, s.size = index, info.ModTime(), info.Size()
+	return index, nil
+}
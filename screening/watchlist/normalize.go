@@ -0,0 +1,62 @@
+package watchlist
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// transliterations covers letters that do not decompose into a Latin base letter,
+// plus Cyrillic and Greek, which are common in sanctions lists.
+var transliterations = map[rune]string{
+	'ß': "ss", 'æ': "ae", 'œ': "oe", 'ø': "o", 'đ': "d", 'ð': "d", 'ł': "l", 'þ': "th", 'ı': "i",
+
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh", 'з': "z",
+	'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r",
+	'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch",
+	'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya", 'і': "i", 'ї': "yi", 'є': "ye", 'ґ': "g",
+
+	'α': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'ζ': "z", 'η': "i", 'θ': "th", 'ι': "i",
+	'κ': "k", 'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x", 'ο': "o", 'π': "p", 'ρ': "r", 'σ': "s",
+	'ς': "s", 'τ': "t", 'υ': "y", 'φ': "f", 'χ': "ch", 'ψ': "ps", 'ω': "o",
+}
+
+// Normalize lower-cases a name, transliterates it to Latin, strips diacritics and
+// punctuation and collapses whitespace.
+func Normalize(name string) string {
+	var b strings.Builder
+	// Transliterate composed letters first: decomposed, 'й' would be 'и' plus a breve.
+	// Letters without an entry of their own, like 'έ', fall back to their base letter.
+	for _, r := range norm.NFC.String(strings.ToLower(name)) {
+		if t, ok := transliterations[r]; ok {
+			b.WriteString(t)
+			continue
+		}
+		decomposed := []rune(norm.NFD.String(string(r)))
+		if t, ok := transliterations[decomposed[0]]; ok && len(decomposed) > 1 {
+			b.WriteString(t)
+			continue
+		}
+		b.WriteRune(r)
+	}
+
+	folded, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), b.String())
+	if err != nil {
+		folded = b.String()
+	}
+	return strings.Join(strings.FieldsFunc(folded, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+// sortedTokens returns the normalized name with its tokens in alphabetical order so
+// "DOE, John" and "John Doe" compare equal.
+func sortedTokens(normalized string) string {
+	tokens := strings.Fields(normalized)
+	sort.Strings(tokens)
+	return strings.Join(tokens, " ")
+}
@@ -0,0 +1,91 @@
+package watchlist
+
+import (
+	"math"
+	"testing"
+)
+
+func TestJaroWinkler(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"", "", 1},
+		{"abc", "", 0},
+		{"", "abc", 0},
+		{"abc", "xyz", 0},
+		{"martha", "martha", 1},
+		{"martha", "marhta", 0.961111},
+		{"dwayne", "duane", 0.84},
+		{"dixon", "dicksonx", 0.813333},
+		{"юлия", "юля", 0.933333},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			if got := JaroWinkler(tt.a, tt.b); math.Abs(got-tt.want) > 1e-6 {
+				t.Errorf("JaroWinkler(%q, %q) = %f, want %f", tt.a, tt.b, got, tt.want)
+			}
+			if got, rev := JaroWinkler(tt.a, tt.b), JaroWinkler(tt.b, tt.a); math.Abs(got-rev) > 1e-9 {
+				t.Errorf("JaroWinkler is not symmetric for %q, %q: %f != %f", tt.a, tt.b, got, rev)
+			}
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"John  DOE", "john doe"},
+		{"DOE, John-Paul", "doe john paul"},
+		{"José Müller", "jose muller"},
+		{"Straße", "strasse"},
+		{"Łukasz Øster", "lukasz oster"},
+		{"Сергей Шойгу", "sergey shoygu"},
+		{"Андрій Їжак", "andriy yizhak"},
+		{"Αλέξης Τσίπρας", "alexis tsipras"},
+		{"  ", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Normalize(tt.name); got != tt.want {
+				t.Errorf("Normalize(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIndexSearch(t *testing.T) {
+	ix := NewIndex("test", []*Entry{
+		{UID: "1", Name: "Ivan Petrovich Sidorov", Type: "Individual", Aliases: []string{"Иван Сидоров"}},
+		{UID: "2", Name: "Sidorov Shipping LLC", Type: "Entity"},
+		{UID: "3", Name: "Maria Gonzalez", Type: "Individual"},
+	})
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"Ivan Sidorov", []string{"1"}},
+		{"SIDOROV, Ivan Petrovich", []string{"1"}},
+		{"Иван Сидоров", []string{"1"}},
+		{"Sidorov Shipping LLC", nil},
+		{"María González", []string{"3"}},
+		{"John Smith", nil},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			matches := ix.Search(tt.query, 0.9)
+			if len(matches) != len(tt.want) {
+				t.Fatalf("Search(%q) returned %d matches, want %d: %+v", tt.query, len(matches), len(tt.want), matches)
+			}
+			for i, uid := range tt.want {
+				if matches[i].Entry.UID != uid {
+					t.Errorf("Search(%q)[%d] = %s, want %s", tt.query, i, matches[i].Entry.UID, uid)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fin-auth/domain"
+	"fin-auth/pii"
+	"io"
+	"strings"
+)
+
+// Sealed encrypts files with the pii keyring before handing them to the backend, so
+// identity documents are never at rest in plaintext, whatever the backend.
+type Sealed struct {
+	backend domain.DocumentStorage
+}
+
+func NewSealedStorage(backend domain.DocumentStorage) *Sealed {
+	return &Sealed{backend: backend}
+}
+
+func (s *Sealed) Put(ctx context.Context, key string, r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	sealed, err := pii.Encrypt(data)
+	if err != nil {
+		return err
+	}
+	return s.backend.Put(ctx, key, strings.NewReader(sealed))
+}
+
+func (s *Sealed) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	rc, err := s.backend.Open(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	sealed, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, err
+	}
+	data, err := pii.Decrypt(string(sealed))
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *Sealed) Delete(ctx context.Context, key string) error {
+	return s.backend.Delete(ctx, key)
+}
@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fin-auth/pii"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSealedStorage(t *testing.T) {
+	if err := pii.Init(1, map[int][]byte{1: bytes.Repeat([]byte{1}, 32)}, bytes.Repeat([]byte{2}, 32)); err != nil {
+		t.Fatalf("pii.Init() error = %v", err)
+	}
+	root := t.TempDir()
+	local, err := NewLocalStorage(root)
+	if err != nil {
+		t.Fatalf("NewLocalStorage() error = %v", err)
+	}
+	sealed := NewSealedStorage(local)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		key  string
+		data []byte
+	}{
+		{"pdf", "customer/doc.pdf", []byte("%PDF-1.7 passport scan")},
+		{"binary", "customer/doc.png", []byte{0x89, 'P', 'N', 'G', 0, 1, 2}},
+		{"empty", "customer/empty.jpg", []byte{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := sealed.Put(ctx, tt.key, bytes.NewReader(tt.data)); err != nil {
+				t.Fatalf("Put() error = %v", err)
+			}
+
+			onDisk, err := os.ReadFile(filepath.Join(root, tt.key))
+			if err != nil {
+				t.Fatalf("reading stored file: %v", err)
+			}
+			if !pii.IsEncrypted(string(onDisk)) || (len(tt.data) > 0 && bytes.Contains(onDisk, tt.data)) {
+				t.Errorf("stored file is not sealed: %q", onDisk)
+			}
+
+			rc, err := sealed.Open(ctx, tt.key)
+			if err != nil {
+				t.Fatalf("Open() error = %v", err)
+			}
+			got, _ := io.ReadAll(rc)
+			rc.Close()
+			if !bytes.Equal(got, tt.data) {
+				t.Errorf("Open() = %q, want %q", got, tt.data)
+			}
+
+			if err := sealed.Delete(ctx, tt.key); err != nil {
+				t.Fatalf("Delete() error = %v", err)
+			}
+			if _, err := sealed.Open(ctx, tt.key); err == nil {
+				t.Errorf("Open() after Delete() succeeded")
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fin-auth/cache"
+	"fin-auth/dto"
+	"fin-auth/models"
+	"fin-auth/utils"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type TOS struct {
+	db    *gorm.DB
+	cache *cache.RedisCache
+}
+
+func NewTOSRepository(db *gorm.DB, cache *cache.RedisCache) *TOS {
+	return &TOS{
+		db:    db,
+		cache: cache,
+	}
+}
+
+func (o *TOS) GetDB(tx ...*gorm.DB) *gorm.DB {
+	db := o.db
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db
+}
+
+func (o *TOS) CreatePolicy(ctx context.Context, policy *models.TOSPolicy) error {
+	err := o.db.WithContext(ctx).Create(policy).Error
+	if err != nil && utils.IsDuplicateKeyError(err) {
+		return utils.ErrConflict
+	}
+	return err
+}
+
+func (o *TOS) ListPolicies(ctx context.Context) ([]*models.TOSPolicy, error) {
+	var policies []*models.TOSPolicy
+	if err := o.db.WithContext(ctx).Order("effective_at DESC, created_at DESC").Find(&policies).Error; err != nil {
+		return nil, err
+	}
+	return policies, nil
+}
+
+func (o *TOS) FindPolicy(ctx context.Context, id string) (*models.TOSPolicy, error) {
+	var policy models.TOSPolicy
+	err := o.db.WithContext(ctx).Where("id = ?", id).First(&policy).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, utils.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+func (o *TOS) CurrentPolicy(ctx context.Context, now time.Time, reacceptance bool) (*models.TOSPolicy, error) {
+	query := o.db.WithContext(ctx).Where("effective_at <= ?", now)
+	if reacceptance {
+		query = query.Where("requires_reacceptance")
+	}
+
+	var policy models.TOSPolicy
+	err := query.Order("effective_at DESC, created_at DESC").First(&policy).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, utils.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+func (o *TOS) CreateAcceptance(ctx context.Context, acceptance *models.TOSAcceptance) error {
+	err := o.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(acceptance).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Customer{}).Where("id = ?", acceptance.CustomerID).UpdateColumn("tos_policies", acceptance.PolicyID).Error
+	})
+	if err != nil && utils.IsDuplicateKeyError(err) {
+		return utils.ErrConflict
+	}
+	return err
+}
+
+func (o *TOS) ListAcceptances(ctx context.Context, customerId string) ([]*models.TOSAcceptance, error) {
+	var acceptances []*models.TOSAcceptance
+	err := o.db.WithContext(ctx).Preload("Policy").Where("customer_id = ?", customerId).Order("accepted_at DESC").Find(&acceptances).Error
+	if err != nil {
+		return nil, err
+	}
+	return acceptances, nil
+}
+
+// pendingReacceptance selects the client's customers without an acceptance of the
+// policy or of any version effective since. Erased customers are left out.
+const pendingReacceptance = `
+	FROM customers c
+	WHERE c.client_id = ? AND c.erased_at IS NULL
+	AND NOT EXISTS (
+		SELECT 1 FROM tos_acceptances x JOIN tos_policies xp ON xp.id = x.policy_id
+		WHERE x.customer_id = c.id AND xp.effective_at >= ?
+	)`
+
+// ListPendingReacceptance returns a page of the customers who must accept the policy,
+// and how many there are in total.
+func (o *TOS) ListPendingReacceptance(ctx context.Context, clientId string, policy *models.TOSPolicy, limit, offset int) ([]*dto.TOSReacceptanceEntry, int64, error) {
+	var total int64
+	if err := o.db.WithContext(ctx).Raw("SELECT COUNT(*)"+pendingReacceptance, clientId, policy.EffectiveAt).Scan(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	entries := []*dto.TOSReacceptanceEntry{}
+	err := o.db.WithContext(ctx).Raw(`
+		SELECT pending.id AS customer_id, p.id AS accepted_policy_id, p.version AS accepted_version, a.accepted_at
+		FROM (SELECT c.id, c.tos_policies, c.created_at`+pendingReacceptance+`
+			ORDER BY c.created_at, c.id LIMIT ? OFFSET ?) pending
+		LEFT JOIN tos_policies p ON p.id = pending.tos_policies
+		LEFT JOIN tos_acceptances a ON a.customer_id = pending.id AND a.policy_id = pending.tos_policies
+		ORDER BY pending.created_at, pending.id`, clientId, policy.EffectiveAt, limit, offset).Scan(&entries).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return entries, total, nil
+}
@@ -0,0 +1,132 @@
+package rest
+
+import (
+	"errors"
+	"fin-auth/domain"
+	"fin-auth/dto"
+	"fin-auth/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+type TOSHandler struct {
+	Service  domain.TOSService
+	Response domain.Response
+}
+
+func SetupTOSRoutes(api *echo.Group, s domain.TOSService) {
+	handler := &TOSHandler{
+		Service:  s,
+		Response: domain.NewResponse(),
+	}
+	api.GET("/tos-policies/current", handler.currentPolicy)
+	api.GET("/tos-policies/reacceptance", handler.reacceptanceReport)
+	api.GET("/customers/:id/tos-acceptances", handler.listAcceptances)
+	api.POST("/customers/:id/tos-acceptances", handler.accept)
+}
+
+// SetupTOSAdminRoutes registers policy publishing on the admin group.
+func SetupTOSAdminRoutes(admin *echo.Group, s domain.TOSService) {
+	handler := &TOSHandler{
+		Service:  s,
+		Response: domain.NewResponse(),
+	}
+	admin.GET("/tos-policies", handler.listPolicies)
+	admin.POST("/tos-policies", handler.publish)
+}
+
+func (h *TOSHandler) currentPolicy(c echo.Context) error {
+	policy, err := h.Service.Current(c.Request().Context())
+	if errors.Is(err, utils.ErrNotFound) {
+		return h.Response.NotFound(c, utils.StringPtr("No terms of service policy in effect"))
+	}
+	if err != nil {
+		return h.Response.InternalServerError(c, err)
+	}
+	return h.Response.SuccessOk(c, policy)
+}
+
+func (h *TOSHandler) reacceptanceReport(c echo.Context) error {
+	limit, page, offset := utils.ParsePaginationParams(c)
+	clientId := c.Get("client_id").(string)
+	report, err := h.Service.ReacceptanceReport(c.Request().Context(), clientId, limit, offset)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+	report.CurrentPage = page
+	report.PerPage = limit
+	report.TotalPages = utils.CalculateTotalPages(report.Total, limit)
+	return h.Response.SuccessOk(c, report)
+}
+
+func (h *TOSHandler) listAcceptances(c echo.Context) error {
+	clientId := c.Get("client_id").(string)
+	acceptances, err := h.Service.ListAcceptances(c.Request().Context(), clientId, c.Param("id"))
+	if err != nil {
+		return h.handleError(c, err)
+	}
+	return h.Response.SuccessOk(c, acceptances)
+}
+
+func (h *TOSHandler) accept(c echo.Context) error {
+	var req dto.TOSAcceptanceReq
+	if err := c.Bind(&req); err != nil {
+		return h.Response.InvalidData(c, nil)
+	}
+	v := req.Validate()
+	if v.Status {
+		return h.Response.ValidationFail(c, v, nil)
+	}
+
+	clientId := c.Get("client_id").(string)
+	acceptance := dto.NewTOSAcceptance(clientId, req.TOSPolicyID, c.RealIP(), c.Request().UserAgent())
+	acceptance, err := h.Service.Accept(c.Request().Context(), clientId, c.Param("id"), acceptance)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+	return h.Response.SuccessOk(c, acceptance)
+}
+
+func (h *TOSHandler) listPolicies(c echo.Context) error {
+	policies, err := h.Service.ListPolicies(c.Request().Context())
+	if err != nil {
+		return h.Response.InternalServerError(c, err)
+	}
+	return h.Response.SuccessOk(c, policies)
+}
+
+func (h *TOSHandler) publish(c echo.Context) error {
+	var req dto.TOSPolicyReq
+	if err := c.Bind(&req); err != nil {
+		return h.Response.InvalidData(c, nil)
+	}
+	v := req.Validate()
+	if v.Status {
+		return h.Response.ValidationFail(c, v, nil)
+	}
+
+	policy, err := h.Service.Publish(c.Request().Context(), &req)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+	return h.Response.SuccessOk(c, policy)
+}
+
+func (h *TOSHandler) handleError(c echo.Context, err error) error {
+	var fieldErrs utils.FieldErrors
+	switch {
+	case errors.As(err, &fieldErrs):
+		v := utils.NewValidationError()
+		v.Response = utils.ErrorResponse{}
+		for path, codes := range fieldErrs {
+			v.Response.Add(path, codes)
+		}
+		return h.Response.ValidationFail(c, v, nil)
+	case errors.Is(err, utils.ErrNotFound):
+		return h.Response.NotFound(c, utils.StringPtr("Not found"))
+	case errors.Is(err, utils.ErrConflict):
+		return h.Response.ConflictError(c, utils.StringPtr(err.Error()), nil)
+	default:
+		return h.Response.InternalServerError(c, err)
+	}
+}
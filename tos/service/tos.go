@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fin-auth/cache"
+	"fin-auth/config"
+	"fin-auth/domain"
+	"fin-auth/dto"
+	"fin-auth/models"
+	"fin-auth/utils"
+	"fmt"
+)
+
+type TOS struct {
+	TOSRepository      domain.TOSRepository
+	CustomerRepository domain.CustomerRepository
+	Config             config.TOSConfig
+	Cache              *cache.RedisCache
+}
+
+func NewTOSService(repo domain.TOSRepository, customerRepo domain.CustomerRepository, cfg config.TOSConfig, cache *cache.RedisCache) *TOS {
+	return &TOS{
+		TOSRepository:      repo,
+		CustomerRepository: customerRepo,
+		Config:             cfg,
+		Cache:              cache,
+	}
+}
+
+// Publish adds a version. Versions take effect in publishing order, so a version may
+// not take effect before the latest one; the first version always requires acceptance.
+func (s *TOS) Publish(ctx context.Context, req *dto.TOSPolicyReq) (*models.TOSPolicy, error) {
+	policies, err := s.TOSRepository.ListPolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	policy := req.GetPolicy()
+	if len(policies) == 0 {
+		policy.RequiresReacceptance = true
+	} else if policy.EffectiveAt.Before(policies[0].EffectiveAt) {
+		return nil, utils.FieldErrors{"effective_at": {utils.InvalidValueCode()}}
+	}
+
+	if err := s.TOSRepository.CreatePolicy(ctx, policy); err != nil {
+		if errors.Is(err, utils.ErrConflict) {
+			return nil, fmt.Errorf("version %s is already published: %w", policy.Version, utils.ErrConflict)
+		}
+		return nil, err
+	}
+	return policy, nil
+}
+
+func (s *TOS) ListPolicies(ctx context.Context) ([]*models.TOSPolicy, error) {
+	return s.TOSRepository.ListPolicies(ctx)
+}
+
+func (s *TOS) Current(ctx context.Context) (*models.TOSPolicy, error) {
+	return s.TOSRepository.CurrentPolicy(ctx, utils.CurrentTime(), false)
+}
+
+// CheckAcceptable only lets customers accept the version in effect, not an older or
+// a scheduled one.
+func (s *TOS) CheckAcceptable(ctx context.Context, policyId string) error {
+	current, err := s.Current(ctx)
+	if errors.Is(err, utils.ErrNotFound) {
+		return utils.FieldErrors{"tos_policy_id": {utils.InvalidValueCode()}}
+	}
+	if err != nil {
+		return err
+	}
+	if current.ID == policyId {
+		return nil
+	}
+
+	if _, err := s.TOSRepository.FindPolicy(ctx, policyId); errors.Is(err, utils.ErrNotFound) {
+		return utils.FieldErrors{"tos_policy_id": {utils.InvalidValueCode()}}
+	} else if err != nil {
+		return err
+	}
+	return utils.FieldErrors{"tos_policy_id": {utils.OutdatedPolicyCode()}}
+}
+
+// OnboardingPolicy returns the version a new customer accepts: policyId when given,
+// else the version in effect unless the config requires clients to send one.
+func (s *TOS) OnboardingPolicy(ctx context.Context, policyId string) (string, error) {
+	if policyId != "" {
+		return policyId, s.CheckAcceptable(ctx, policyId)
+	}
+	if s.Config.RequirePolicyID {
+		return "", utils.FieldErrors{"tos_policy_id": {utils.RequiredCode()}}
+	}
+
+	current, err := s.Current(ctx)
+	if errors.Is(err, utils.ErrNotFound) {
+		return "", utils.FieldErrors{"tos_policy_id": {utils.RequiredCode()}}
+	}
+	if err != nil {
+		return "", err
+	}
+	return current.ID, nil
+}
+
+func (s *TOS) Accept(ctx context.Context, clientId, customerId string, acceptance *models.TOSAcceptance) (*models.TOSAcceptance, error) {
+	cached, err := s.CustomerRepository.FindByID(ctx, clientId, customerId)
+	if err != nil {
+		return nil, err
+	}
+	customer := cached.Customer
+	if customer.ErasedAt != nil {
+		return nil, fmt.Errorf("customer %s was erased: %w", customerId, utils.ErrConflict)
+	}
+	if err := s.CheckAcceptable(ctx, acceptance.PolicyID); err != nil {
+		return nil, err
+	}
+
+	acceptance.CustomerID = customer.ID
+	if err := s.TOSRepository.CreateAcceptance(ctx, acceptance); err != nil {
+		if errors.Is(err, utils.ErrConflict) {
+			return nil, fmt.Errorf("customer %s already accepted policy %s: %w", customer.ID, acceptance.PolicyID, utils.ErrConflict)
+		}
+		return nil, err
+	}
+
+	if s.Cache != nil {
+		s.Cache.InvalidateCustomer(ctx, clientId, customer.ID)
+		s.Cache.InvalidateCustomerList(ctx, clientId)
+	}
+	return acceptance, nil
+}
+
+func (s *TOS) ListAcceptances(ctx context.Context, clientId, customerId string) ([]*models.TOSAcceptance, error) {
+	if _, err := s.CustomerRepository.FindByID(ctx, clientId, customerId); err != nil {
+		return nil, err
+	}
+	return s.TOSRepository.ListAcceptances(ctx, customerId)
+}
+
+// ReacceptanceReport is empty until a version requiring re-acceptance is in effect.
+func (s *TOS) ReacceptanceReport(ctx context.Context, clientId string, limit, offset int) (*dto.TOSReacceptanceReport, error) {
+	report := &dto.TOSReacceptanceReport{Customers: []*dto.TOSReacceptanceEntry{}}
+	policy, err := s.TOSRepository.CurrentPolicy(ctx, utils.CurrentTime(), true)
+	if errors.Is(err, utils.ErrNotFound) {
+		return report, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	entries, total, err := s.TOSRepository.ListPendingReacceptance(ctx, clientId, policy, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	report.Policy = policy
+	report.Customers = entries
+	report.Total = int(total)
+	return report, nil
+}
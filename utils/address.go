@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// PoBoxRegex matches post office box designations in English, Spanish, Portuguese,
+// French, German, Italian, Dutch, Polish and Scandinavian addresses. The bare "C.P."
+// abbreviation is left out: in Mexico and elsewhere it introduces the postal code.
+var PoBoxRegex = regexp.MustCompile(`(?i)(^|[^\p{L}])(` +
+	`p\.?\s*o\.?\s*box|post\s*office\s*box|g\.?p\.?o\.?\s*box|box\s*#?\s*\d|pmb\s*#?\s*\d|` +
+	`apartado(\s+de\s+correos?)?|apdo\.?\s*\d|casilla(\s+de\s+correos?)?|` +
+	`caixa\s+postal|` +
+	`bo[iî]te\s+postale|b\.?\s*p\.?\s*\d|case\s+postale|` +
+	`postfach|casella\s+postale|postbus|skrytka\s+pocztowa|postboks|postbox` +
+	`)([^\p{L}]|$)`)
+
+// streetSuffixes maps street designators to their USPS-style abbreviations.
+var streetSuffixes = map[string]string{
+	"street":    "St",
+	"avenue":    "Ave",
+	"road":      "Rd",
+	"boulevard": "Blvd",
+	"drive":     "Dr",
+	"lane":      "Ln",
+	"court":     "Ct",
+	"place":     "Pl",
+	"square":    "Sq",
+	"terrace":   "Ter",
+	"parkway":   "Pkwy",
+	"highway":   "Hwy",
+	"circle":    "Cir",
+	"crescent":  "Cres",
+	"suite":     "Ste",
+	"apartment": "Apt",
+	"building":  "Bldg",
+	"floor":     "Fl",
+}
+
+// streetSuffixCountries use English street designators.
+var streetSuffixCountries = []string{"USA", "CAN", "GBR", "AUS", "NZL", "IRL"}
+
+// IsPoBox reports whether the street line is a post office box.
+func IsPoBox(street string) bool {
+	return PoBoxRegex.MatchString(street)
+}
+
+// NormalizeStreet collapses whitespace, title-cases words and, for English-speaking
+// countries, abbreviates street designators ("123 MAIN STREET" -> "123 Main St").
+func NormalizeStreet(street, countryCode string) string {
+	abbreviate := InArrayString(countryCode, streetSuffixCountries)
+	words := strings.Fields(street)
+	for i, word := range words {
+		bare := strings.TrimRight(word, ".,")
+		if abbreviate {
+			if short, ok := streetSuffixes[strings.ToLower(bare)]; ok {
+				words[i] = short + strings.TrimLeft(word[len(bare):], ".")
+				continue
+			}
+		}
+		words[i] = normalizeAddressWord(word)
+	}
+	return strings.Join(words, " ")
+}
+
+// NormalizeAddressCity collapses whitespace and title-cases the city name.
+func NormalizeAddressCity(city string) string {
+	words := strings.Fields(city)
+	for i, word := range words {
+		words[i] = normalizeAddressWord(word)
+	}
+	return strings.Join(words, " ")
+}
+
+// NormalizePostalCode upper-cases the postal code and collapses inner whitespace.
+func NormalizePostalCode(postalCode string) string {
+	return strings.Join(strings.Fields(strings.ToUpper(postalCode)), " ")
+}
+
+// normalizeAddressWord title-cases plain words and upper-cases words containing digits
+// such as unit numbers ("12b" -> "12B").
+func normalizeAddressWord(word string) string {
+	if strings.ContainsAny(word, "0123456789") {
+		return strings.ToUpper(word)
+	}
+	// A Caser keeps state between calls, so each call gets its own.
+	return cases.Title(language.Und).String(word)
+}
@@ -9,3 +9,273 @@ const (
 	VERIFICATION_TYPE_RELIANCE = "RELIANCE"
 	VERIFICATION_TYPE_STANDARD = "STANDARD"
 )
+
+const (
+	KYC_STATUS_INCOMPLETE            = "INCOMPLETE"
+	KYC_STATUS_PENDING               = "PENDING"
+	KYC_STATUS_UNDER_REVIEW          = "UNDER_REVIEW"
+	KYC_STATUS_MANUAL_REVIEW         = "MANUAL_REVIEW"
+	KYC_STATUS_RESUBMISSION_REQUIRED = "RESUBMISSION_REQUIRED"
+	KYC_STATUS_APPROVED              = "APPROVED"
+	KYC_STATUS_REJECTED              = "REJECTED"
+)
+
+const (
+	KYC_PROVIDER_FAKE   = "fake"
+	KYC_PROVIDER_BRIDGE = "bridge"
+)
+
+const (
+	WEBHOOK_EVENT_CUSTOMER_CREATED            = "customer.created"
+	WEBHOOK_EVENT_CUSTOMER_KYC_STATUS_CHANGED = "customer.kyc_status_changed"
+)
+
+const (
+	WEBHOOK_DELIVERY_PENDING   = "PENDING"
+	WEBHOOK_DELIVERY_FAILED    = "FAILED"
+	WEBHOOK_DELIVERY_DELIVERED = "DELIVERED"
+	WEBHOOK_DELIVERY_DEAD      = "DEAD"
+)
+
+var WebhookEventTypes = []string{
+	WEBHOOK_EVENT_CUSTOMER_CREATED,
+	WEBHOOK_EVENT_CUSTOMER_KYC_STATUS_CHANGED,
+}
+
+const (
+	OUTBOX_STATUS_PENDING   = "PENDING"
+	OUTBOX_STATUS_DELIVERED = "DELIVERED"
+	OUTBOX_STATUS_FAILED    = "FAILED"
+)
+
+const (
+	AGGREGATE_CUSTOMER = "customer"
+)
+
+const (
+	OUTBOX_SINK_WEBHOOK = "webhook"
+	OUTBOX_SINK_STREAM  = "stream"
+	OUTBOX_SINK_CACHE   = "cache"
+	OUTBOX_SINK_LOG     = "log"
+)
+
+const (
+	DOCUMENT_TYPE_PASSPORT         = "PASSPORT"
+	DOCUMENT_TYPE_ID_CARD          = "ID_CARD"
+	DOCUMENT_TYPE_DRIVERS_LICENSE  = "DRIVERS_LICENSE"
+	DOCUMENT_TYPE_PROOF_OF_ADDRESS = "PROOF_OF_ADDRESS"
+)
+
+const (
+	DOCUMENT_SIDE_FRONT = "FRONT"
+	DOCUMENT_SIDE_BACK  = "BACK"
+)
+
+const (
+	DOCUMENT_STATUS_UPLOADED = "UPLOADED"
+	DOCUMENT_STATUS_ACCEPTED = "ACCEPTED"
+	DOCUMENT_STATUS_REJECTED = "REJECTED"
+	DOCUMENT_STATUS_ERASED   = "ERASED"
+)
+
+var DocumentTypes = []string{
+	DOCUMENT_TYPE_PASSPORT,
+	DOCUMENT_TYPE_ID_CARD,
+	DOCUMENT_TYPE_DRIVERS_LICENSE,
+	DOCUMENT_TYPE_PROOF_OF_ADDRESS,
+}
+
+const (
+	REVIEW_FLAG_OPEN     = "OPEN"
+	REVIEW_FLAG_RESOLVED = "RESOLVED"
+)
+
+const (
+	REVIEW_SOURCE_PROOF_OF_ADDRESS = "proof_of_address"
+	REVIEW_SOURCE_JURISDICTION     = "jurisdiction"
+	REVIEW_SOURCE_SANCTIONS        = "sanctions"
+	REVIEW_SOURCE_DEDUPE           = "dedupe"
+)
+
+const (
+	JURISDICTION_PROHIBITED = "PROHIBITED"
+	JURISDICTION_CONTROLLED = "CONTROLLED"
+)
+
+const (
+	JURISDICTION_PARTNER_BRIDGE = "bridge"
+)
+
+const (
+	RISK_TIER_LOW    = "LOW"
+	RISK_TIER_MEDIUM = "MEDIUM"
+	RISK_TIER_HIGH   = "HIGH"
+)
+
+const (
+	RISK_FACTOR_HIGH_RISK_NATIONALITY = "high_risk_nationality"
+	RISK_FACTOR_HIGH_RISK_RESIDENCE   = "high_risk_residence"
+	RISK_FACTOR_RESIDENCE_MISMATCH    = "residence_mismatch"
+	RISK_FACTOR_MONTHLY_VOLUME        = "monthly_volume"
+	RISK_FACTOR_OCCUPATION            = "occupation"
+	RISK_FACTOR_SOURCE_OF_FUNDS       = "source_of_funds"
+	RISK_FACTOR_PURPOSE               = "purpose"
+)
+
+// Reference catalogs, named after their tables.
+const (
+	REFERENCE_OCCUPATIONS      = "occupations"
+	REFERENCE_SOURCES_OF_FUNDS = "sources_of_funds"
+	REFERENCE_PURPOSES         = "purposes"
+)
+
+var ReferenceCatalogs = []string{
+	REFERENCE_OCCUPATIONS,
+	REFERENCE_SOURCES_OF_FUNDS,
+	REFERENCE_PURPOSES,
+}
+
+const DEFAULT_LANGUAGE = "en"
+
+const (
+	DEDUPE_FIELD_EMAIL = "email"
+	DEDUPE_FIELD_PHONE = "phone"
+	DEDUPE_FIELD_TIN   = "tin"
+)
+
+const (
+	DEDUPE_SCOPE_CLIENT = "client"
+	DEDUPE_SCOPE_GLOBAL = "global"
+)
+
+const (
+	DEDUPE_ACTION_REJECT = "reject"
+	DEDUPE_ACTION_FLAG   = "flag"
+)
+
+const (
+	IDEMPOTENCY_STATUS_PROCESSING = "PROCESSING"
+	IDEMPOTENCY_STATUS_COMPLETED  = "COMPLETED"
+)
+
+// SCOPE_PII_UNMASKED lets a client read customer PII in the clear with ?unmasked=true.
+const SCOPE_PII_UNMASKED = "pii:unmasked"
+
+var ClientScopes = []string{
+	SCOPE_PII_UNMASKED,
+}
+
+const (
+	DATA_REQUEST_EXPORT  = "EXPORT"
+	DATA_REQUEST_ERASURE = "ERASURE"
+)
+
+// DATA_REQUEST_BY_CLI marks requests run by an operator through the CLI.
+const DATA_REQUEST_BY_CLI = "cli"
+
+const (
+	RETENTION_ENTITY_SESSIONS         = "sessions"
+	RETENTION_ENTITY_SOFT_DELETED     = "soft_deleted"
+	RETENTION_ENTITY_CUSTOMERS        = "customers"
+	RETENTION_ENTITY_DOCUMENTS        = "documents"
+	RETENTION_ENTITY_AUDIT_EVENTS     = "audit_events"
+	RETENTION_ENTITY_PII_ACCESS_LOGS  = "pii_access_logs"
+	RETENTION_ENTITY_IDEMPOTENCY_KEYS = "idempotency_keys"
+)
+
+var RetentionEntities = []string{
+	RETENTION_ENTITY_SESSIONS,
+	RETENTION_ENTITY_SOFT_DELETED,
+	RETENTION_ENTITY_CUSTOMERS,
+	RETENTION_ENTITY_DOCUMENTS,
+	RETENTION_ENTITY_AUDIT_EVENTS,
+	RETENTION_ENTITY_PII_ACCESS_LOGS,
+	RETENTION_ENTITY_IDEMPOTENCY_KEYS,
+}
+
+const (
+	RETENTION_RUN_RUNNING   = "RUNNING"
+	RETENTION_RUN_COMPLETED = "COMPLETED"
+	RETENTION_RUN_FAILED    = "FAILED"
+)
+
+const (
+	ADDRESS_TYPE_RESIDENTIAL = "RESIDENTIAL"
+	ADDRESS_TYPE_MAILING     = "MAILING"
+	ADDRESS_TYPE_REGISTERED  = "REGISTERED"
+)
+
+var AddressTypes = []string{
+	ADDRESS_TYPE_RESIDENTIAL,
+	ADDRESS_TYPE_MAILING,
+	ADDRESS_TYPE_REGISTERED,
+}
+
+// Person roles. Every customer has one PRIMARY person; business customers add the
+// others.
+const (
+	PERSON_ROLE_PRIMARY           = "PRIMARY"
+	PERSON_ROLE_BENEFICIAL_OWNER  = "BENEFICIAL_OWNER"
+	PERSON_ROLE_DIRECTOR          = "DIRECTOR"
+	PERSON_ROLE_CONTROL_PERSON    = "CONTROL_PERSON"
+	PERSON_ROLE_AUTHORIZED_SIGNER = "AUTHORIZED_SIGNER"
+)
+
+// AssociatedPersonRoles are the roles a person added to a business customer may take.
+var AssociatedPersonRoles = []string{
+	PERSON_ROLE_BENEFICIAL_OWNER,
+	PERSON_ROLE_DIRECTOR,
+	PERSON_ROLE_CONTROL_PERSON,
+	PERSON_ROLE_AUTHORIZED_SIGNER,
+}
+
+// One-time code channels, named after the person field they verify.
+const (
+	OTP_CHANNEL_EMAIL = "email"
+	OTP_CHANNEL_PHONE = "phone"
+)
+
+var OTPChannels = []string{OTP_CHANNEL_EMAIL, OTP_CHANNEL_PHONE}
+
+const (
+	NOTIFIER_LOG  = "log"
+	NOTIFIER_FILE = "file"
+)
+
+const (
+	CORRIDOR_RAIL_ACH   = "ACH"
+	CORRIDOR_RAIL_WIRE  = "WIRE"
+	CORRIDOR_RAIL_RTP   = "RTP"
+	CORRIDOR_RAIL_SWIFT = "SWIFT"
+	CORRIDOR_RAIL_SEPA  = "SEPA"
+	CORRIDOR_RAIL_SPEI  = "SPEI"
+	CORRIDOR_RAIL_PIX   = "PIX"
+)
+
+var CorridorRails = []string{
+	CORRIDOR_RAIL_ACH,
+	CORRIDOR_RAIL_WIRE,
+	CORRIDOR_RAIL_RTP,
+	CORRIDOR_RAIL_SWIFT,
+	CORRIDOR_RAIL_SEPA,
+	CORRIDOR_RAIL_SPEI,
+	CORRIDOR_RAIL_PIX,
+}
+
+// Reasons a customer does not qualify for a corridor.
+const (
+	CORRIDOR_REASON_ERASED             = "customer_erased"
+	CORRIDOR_REASON_KYC_NOT_APPROVED   = "kyc_not_approved"
+	CORRIDOR_REASON_RESIDENCE          = "residence_not_eligible"
+	CORRIDOR_REASON_RISK_NOT_ASSESSED  = "risk_not_assessed"
+	CORRIDOR_REASON_RISK_TIER_TOO_HIGH = "risk_tier_too_high"
+)
+
+// RiskTiers lists the risk tiers from lowest to highest.
+var RiskTiers = []string{
+	RISK_TIER_LOW,
+	RISK_TIER_MEDIUM,
+	RISK_TIER_HIGH,
+}
+
+const CURRENCY_USD = "USD"
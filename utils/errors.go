@@ -28,6 +28,8 @@ var (
 	ErrSrcAmountBelowMin       = errors.New("source amount is below the minimum required amount")
 	ErrSrcAmountZeroOrNeg      = errors.New("source amount must be greater than zero")
 	ErrLaDrainNotEnoughBalance = errors.New("Insufficient balance on liquidation address.")
+	ErrOTPSendLimit            = errors.New(MaxGetOtp)
+	ErrOTPAttemptLimit         = errors.New(MaxAttempt)
 
 	// LaDrainNotEnoughBalance = "Insufficient {source.currency} balance on {source.rail} liquidation address to settle {source_amount} {source.currency}. Please fund the liquidation address"
 	LaDrainNotEnoughBalance   = "Insufficient %s balance on %s liquidation address to settle %s %s. Please fund the liquidation address."
@@ -82,6 +84,20 @@ func (e *InvalidTransitionError) Error() string {
 		e.FromStatus, e.FromSub, e.ToStatus, e.ToSub)
 }
 
+// DuplicateCustomerError reports the identity fields that matched an existing customer
+// under a reject rule. It matches ErrConflict.
+type DuplicateCustomerError struct {
+	Fields []string
+}
+
+func (e *DuplicateCustomerError) Error() string {
+	return fmt.Sprintf("duplicate customer: matched on %s", strings.Join(e.Fields, ", "))
+}
+
+func (e *DuplicateCustomerError) Is(target error) bool {
+	return target == ErrConflict
+}
+
 func GetErrCode(err error) string {
 	wrapErr := &WrapErr{}
 	if errors.As(err, wrapErr) {
@@ -128,3 +144,11 @@ func IsDuplicateKeyError(err error) bool {
 	return strings.Contains(errStr, "duplicate key value violates unique constraint") ||
 		strings.Contains(errStr, "SQLSTATE 23505")
 }
+
+// FieldErrors carries validation codes keyed by JSON path from checks that run in the
+// service layer, such as ones that need the database.
+type FieldErrors map[string][]string
+
+func (e FieldErrors) Error() string {
+	return "validation failed"
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"math/big"
 	"mime/multipart"
 	"net/url"
 	"os"
@@ -52,6 +53,19 @@ func GenerateRandomString(length int) string {
 	return string(bytes)
 }
 
+// GenerateNumericCode returns a random code of length decimal digits.
+func GenerateNumericCode(length int) (string, error) {
+	digits := make([]byte, length)
+	for i := range digits {
+		n, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		digits[i] = byte('0' + n.Int64())
+	}
+	return string(digits), nil
+}
+
 func HashMake(str string) (string, error) {
 	str = salt + str
 	return hashCreate(str)
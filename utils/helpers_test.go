@@ -0,0 +1,32 @@
+package utils
+
+import "testing"
+
+func TestGenerateNumericCode(t *testing.T) {
+	tests := []struct {
+		name   string
+		length int
+	}{
+		{"empty", 0},
+		{"four digits", 4},
+		{"six digits", 6},
+		{"ten digits", 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := GenerateNumericCode(tt.length)
+			if err != nil {
+				t.Fatalf("GenerateNumericCode() error = %v", err)
+			}
+			if len(code) != tt.length {
+				t.Errorf("GenerateNumericCode() = %q, want %d digits", code, tt.length)
+			}
+			for _, r := range code {
+				if r < '0' || r > '9' {
+					t.Errorf("GenerateNumericCode() = %q, want only digits", code)
+					break
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package utils
+
+import "testing"
+
+func TestSignHMACSHA256(t *testing.T) {
+	got := SignHMACSHA256("key", []byte("The quick brown fox jumps over the lazy dog"))
+	want := "f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8"
+	if got != want {
+		t.Fatalf("SignHMACSHA256() = %s, want %s", got, want)
+	}
+}
+
+func TestVerifyHMACSHA256(t *testing.T) {
+	secret := "whsec_test"
+	payload := []byte(`{"event":"kyc.completed"}`)
+	signature := SignHMACSHA256(secret, payload)
+
+	tests := []struct {
+		name      string
+		secret    string
+		payload   []byte
+		signature string
+		want      bool
+	}{
+		{"valid", secret, payload, signature, true},
+		{"sha256 prefix", secret, payload, "sha256=" + signature, true},
+		{"surrounding whitespace", secret, payload, " " + signature + "\n", true},
+		{"wrong secret", "other", payload, signature, false},
+		{"tampered payload", secret, []byte(`{"event":"kyc.rejected"}`), signature, false},
+		{"truncated signature", secret, payload, signature[:len(signature)-2], false},
+		{"not hex", secret, payload, "zz" + signature[2:], false},
+		{"empty signature", secret, payload, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := VerifyHMACSHA256(tt.secret, tt.payload, tt.signature); got != tt.want {
+				t.Errorf("VerifyHMACSHA256() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaskTIN keeps the last four characters of a TIN. US SSNs keep their familiar
+// ***-**-1234 shape; short values are fully hidden.
+func MaskTIN(tin, country string) string {
+	if tin == "" {
+		return ""
+	}
+	tin = NormalizeTIN(tin)
+	if country == "USA" && len(tin) == 9 {
+		return "***-**-" + tin[5:]
+	}
+	if len(tin) <= 6 {
+		return strings.Repeat("*", len(tin))
+	}
+	return HideString(tin, 4)
+}
+
+// MaskEmail keeps the first character of the local part and the whole domain.
+func MaskEmail(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at <= 0 {
+		return strings.Repeat("*", utf8.RuneCountInString(email))
+	}
+	first, _ := utf8.DecodeRuneInString(email)
+	return string(first) + "***" + email[at:]
+}
+
+// MaskPhone keeps the leading + of an E.164 number and its last four digits.
+func MaskPhone(phone string) string {
+	digits := strings.TrimPrefix(phone, "+")
+	if len(digits) <= 4 {
+		return strings.Repeat("*", len(phone))
+	}
+	return phone[:len(phone)-len(digits)] + HideString(digits, 4)
+}
+
+// MaskText keeps the first character of a free-text value such as a street or city.
+func MaskText(value string) string {
+	if value == "" {
+		return ""
+	}
+	first, _ := utf8.DecodeRuneInString(value)
+	return string(first) + "***"
+}
+
+// MaskDate keeps only the year.
+func MaskDate(t time.Time) string {
+	return t.Format("2006") + "-**-**"
+}
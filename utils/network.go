@@ -0,0 +1,84 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+	"strings"
+)
+
+var ErrNonPublicAddress = errors.New("address is not publicly routable")
+
+// nonPublicNetworks are reserved ranges the net.IP predicates don't cover.
+var nonPublicNetworks = []*net.IPNet{
+	mustCIDR("0.0.0.0/8"),
+	mustCIDR("100.64.0.0/10"),
+	mustCIDR("192.0.0.0/24"),
+	mustCIDR("198.18.0.0/15"),
+	mustCIDR("240.0.0.0/4"),
+	mustCIDR("64:ff9b::/96"),
+}
+
+func mustCIDR(cidr string) *net.IPNet {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		panic(err)
+	}
+	return network
+}
+
+// IsPublicIP reports whether ip is reachable on the public internet, i.e. not loopback,
+// private, link-local, multicast or otherwise reserved.
+func IsPublicIP(ip net.IP) bool {
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+	for _, network := range nonPublicNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsValidWebhookURL accepts https URLs on a domain name other than localhost. Names
+// are resolved separately by CheckPublicHost.
+func IsValidWebhookURL(urlStr string) bool {
+	urlStr = strings.TrimSpace(urlStr)
+	if !strings.HasPrefix(urlStr, "https://") || !IsValidURL(urlStr) {
+		return false
+	}
+	u, err := url.Parse(urlStr)
+	if err != nil || u.User != nil {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return IsPublicIP(ip)
+	}
+	return true
+}
+
+// CheckPublicHost resolves the host of urlStr and returns ErrNonPublicAddress when any
+// of its addresses is not public.
+func CheckPublicHost(ctx context.Context, urlStr string) error {
+	u, err := url.Parse(strings.TrimSpace(urlStr))
+	if err != nil {
+		return err
+	}
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, u.Hostname())
+	if err != nil {
+		return err
+	}
+	for _, addr := range addrs {
+		if !IsPublicIP(addr.IP) {
+			return ErrNonPublicAddress
+		}
+	}
+	return nil
+}
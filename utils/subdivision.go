@@ -0,0 +1,96 @@
+package utils
+
+import (
+	_ "embed"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// subdivisionData holds ISO 3166-2 subdivisions and postal code patterns keyed by
+// ISO 3166-1 alpha-3. Countries without a subdivision list accept any state value.
+//
+//go:embed data/subdivisions.json
+var subdivisionData []byte
+
+type countryGeo struct {
+	Alpha2        string            `json:"alpha2"`
+	PostalPattern string            `json:"postal_pattern"`
+	PostalExample string            `json:"postal_example"`
+	Subdivisions  map[string]string `json:"subdivisions"`
+
+	postalRegex *regexp.Regexp
+	byName      map[string]string
+}
+
+var countryGeos = loadCountryGeos()
+
+func loadCountryGeos() map[string]*countryGeo {
+	geos := map[string]*countryGeo{}
+	if err := json.Unmarshal(subdivisionData, &geos); err != nil {
+		panic("utils: invalid subdivision dataset: " + err.Error())
+	}
+	for _, geo := range geos {
+		if geo.PostalPattern != "" {
+			geo.postalRegex = regexp.MustCompile(geo.PostalPattern)
+		}
+		geo.byName = make(map[string]string, len(geo.Subdivisions))
+		for code, name := range geo.Subdivisions {
+			geo.byName[foldSubdivisionName(name)] = code
+		}
+	}
+	return geos
+}
+
+// foldSubdivisionName lower-cases a name and strips accents and punctuation so
+// "São Paulo", "Sao Paulo" and "sao-paulo" compare equal.
+func foldSubdivisionName(name string) string {
+	folded, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), name)
+	if err != nil {
+		folded = name
+	}
+	return strings.Join(strings.FieldsFunc(strings.ToLower(folded), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+// HasSubdivisionData reports whether the country's subdivisions are validated.
+func HasSubdivisionData(countryCode string) bool {
+	geo := countryGeos[countryCode]
+	return geo != nil && len(geo.Subdivisions) > 0
+}
+
+// NormalizeSubdivision resolves a state given as a code ("NY"), a full ISO 3166-2 code
+// ("US-NY") or a name ("New York") to its ISO 3166-2 code. It returns false when the
+// country has subdivision data and the state is not one of them.
+func NormalizeSubdivision(countryCode, state string) (string, bool) {
+	geo := countryGeos[countryCode]
+	if geo == nil || len(geo.Subdivisions) == 0 {
+		return "", false
+	}
+
+	value := strings.ToUpper(strings.TrimSpace(state))
+	value = strings.TrimPrefix(value, geo.Alpha2+"-")
+	if _, ok := geo.Subdivisions[value]; ok {
+		return geo.Alpha2 + "-" + value, true
+	}
+	if code, ok := geo.byName[foldSubdivisionName(state)]; ok {
+		return geo.Alpha2 + "-" + code, true
+	}
+	return "", false
+}
+
+// IsPostalCodeValid checks a postal code against the country's pattern. Countries
+// without a registered pattern accept any value.
+func IsPostalCodeValid(countryCode, postalCode string) bool {
+	geo := countryGeos[countryCode]
+	if geo == nil || geo.postalRegex == nil {
+		return true
+	}
+	return geo.postalRegex.MatchString(strings.ToUpper(strings.TrimSpace(postalCode)))
+}
@@ -0,0 +1,142 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+// TINRule describes one accepted tax identification number format for a country.
+// Pattern applies to the normalized value (upper case, separators removed).
+type TINRule struct {
+	Name        string `json:"name"`
+	Pattern     string `json:"pattern"`
+	Example     string `json:"example"`
+	Description string `json:"description"`
+
+	regex    *regexp.Regexp
+	checksum func(string) bool
+}
+
+func newTINRule(name, pattern, example, description string, checksum func(string) bool) TINRule {
+	return TINRule{
+		Name:        name,
+		Pattern:     pattern,
+		Example:     example,
+		Description: description,
+		regex:       regexp.MustCompile(pattern),
+		checksum:    checksum,
+	}
+}
+
+// tinRules is keyed by ISO 3166-1 alpha-3. A TIN is valid when it satisfies any rule
+// for its country; countries without rules only get the generic length check.
+var tinRules = map[string][]TINRule{
+	"USA": {
+		newTINRule("SSN", `^(?:00[1-9]|0[1-9]\d|[1-578]\d{2}|6[0-57-9]\d|66[0-57-9])(?:0[1-9]|[1-9]\d)(?:000[1-9]|00[1-9]\d|0[1-9]\d{2}|[1-9]\d{3})$`, "123-45-6789", "Social Security Number, 9 digits", nil),
+		newTINRule("ITIN", `^9\d{2}(?:5\d|6[0-5]|7\d|8[0-8]|9[0-24-9])\d{4}$`, "912-70-1234", "Individual Taxpayer Identification Number, 9 digits starting with 9", nil),
+	},
+	"GBR": {
+		newTINRule("NINO", `^[A-CEGHJ-PR-TW-Z][A-CEGHJ-NPR-TW-Z]\d{6}[A-D]$`, "AB 12 34 56 C", "National Insurance number, 2 letters, 6 digits and a suffix A-D", validNINOPrefix),
+	},
+	"IND": {
+		newTINRule("PAN", `^[A-Z]{3}[ABCFGHJLPT][A-Z]\d{4}[A-Z]$`, "ABCPE1234F", "Permanent Account Number, 5 letters, 4 digits and a letter", nil),
+	},
+	"BRA": {
+		newTINRule("CPF", `^\d{11}$`, "123.456.789-09", "Cadastro de Pessoas Físicas, 11 digits with two check digits", validCPF),
+	},
+	"CAN": {
+		newTINRule("SIN", `^[1-79]\d{8}$`, "130 692 544", "Social Insurance Number, 9 digits with a Luhn check digit", validLuhn),
+	},
+	"DEU": {newTINRule("VAT", `^(?:DE)?\d{9}$`, "DE123456789", "USt-IdNr, 9 digits", nil)},
+	"FRA": {newTINRule("VAT", `^(?:FR)?[0-9A-HJ-NP-Z]{2}\d{9}$`, "FR12345678901", "Numéro de TVA, 2 characters and 9 digits", nil)},
+	"ITA": {newTINRule("VAT", `^(?:IT)?\d{11}$`, "IT12345678901", "Partita IVA, 11 digits", nil)},
+	"ESP": {newTINRule("VAT", `^(?:ES)?[0-9A-Z]\d{7}[0-9A-Z]$`, "ESX1234567X", "NIF/NIE, 9 characters", nil)},
+	"NLD": {newTINRule("VAT", `^(?:NL)?\d{9}B\d{2}$`, "NL123456789B01", "BTW-id, 9 digits, B and 2 digits", nil)},
+	"BEL": {newTINRule("VAT", `^(?:BE)?[01]\d{9}$`, "BE0123456789", "Ondernemingsnummer, 10 digits starting with 0 or 1", nil)},
+	"AUT": {newTINRule("VAT", `^(?:AT)?U\d{8}$`, "ATU12345678", "UID, U followed by 8 digits", nil)},
+	"IRL": {newTINRule("VAT", `^(?:IE)?\d{7}[A-W][A-IW]?$`, "IE1234567WA", "PPS/VAT number, 7 digits and 1-2 letters", nil)},
+	"PRT": {newTINRule("VAT", `^(?:PT)?\d{9}$`, "PT123456789", "NIF, 9 digits", nil)},
+	"POL": {newTINRule("VAT", `^(?:PL)?\d{10}$`, "PL1234567890", "NIP, 10 digits", nil)},
+}
+
+var (
+	tinSeparators  = strings.NewReplacer(" ", "", "-", "", ".", "", "/", "")
+	genericTINRule = regexp.MustCompile(`^[A-Z0-9]{4,20}$`)
+)
+
+// NormalizeTIN upper-cases the TIN and strips common separators.
+func NormalizeTIN(tin string) string {
+	return tinSeparators.Replace(strings.ToUpper(strings.TrimSpace(tin)))
+}
+
+// TINRulesFor returns the TIN formats registered for an alpha-3 country code.
+func TINRulesFor(countryCode string) []TINRule {
+	return tinRules[strings.ToUpper(strings.TrimSpace(countryCode))]
+}
+
+// CheckTIN validates a normalized TIN against the country's rules. It returns an empty
+// string when the TIN is valid, otherwise the validation code describing the failure.
+func CheckTIN(tin, countryCode string) string {
+	rules := TINRulesFor(countryCode)
+	if len(rules) == 0 {
+		if !genericTINRule.MatchString(tin) {
+			return InvalidFormatCode()
+		}
+		return ""
+	}
+
+	code := InvalidFormatCode()
+	for _, rule := range rules {
+		if !rule.regex.MatchString(tin) {
+			continue
+		}
+		if rule.checksum != nil && !rule.checksum(tin) {
+			code = InvalidChecksumCode()
+			continue
+		}
+		return ""
+	}
+	return code
+}
+
+// validNINOPrefix rejects prefixes HMRC never issues.
+func validNINOPrefix(tin string) bool {
+	return !InArrayString(tin[:2], []string{"BG", "GB", "NK", "KN", "TN", "NT", "ZZ"})
+}
+
+// validCPF checks both CPF check digits and rejects repeated-digit numbers.
+func validCPF(tin string) bool {
+	if strings.Count(tin, tin[:1]) == len(tin) {
+		return false
+	}
+	for _, n := range []int{9, 10} {
+		sum := 0
+		for i := 0; i < n; i++ {
+			sum += int(tin[i]-'0') * (n + 1 - i)
+		}
+		digit := sum * 10 % 11
+		if digit == 10 {
+			digit = 0
+		}
+		if digit != int(tin[n]-'0') {
+			return false
+		}
+	}
+	return true
+}
+
+// validLuhn checks a Luhn (mod 10) check digit.
+func validLuhn(tin string) bool {
+	sum := 0
+	for i := len(tin) - 1; i >= 0; i-- {
+		d := int(tin[i] - '0')
+		if (len(tin)-i)%2 == 0 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
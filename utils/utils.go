@@ -1,8 +1,10 @@
 package utils
 
 import (
+	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"math"
@@ -74,6 +76,13 @@ func SafeString(s *string) string {
 	return ""
 }
 
+func SafeInt(i *int) int {
+	if i != nil {
+		return *i
+	}
+	return 0
+}
+
 func StringPtr(s string) *string {
 	return &s
 }
@@ -200,61 +209,6 @@ func BytesToDataURI(file *[]byte, filename string) string {
 	return fmt.Sprintf("data:%s;base64,%s", mimeType, b64)
 }
 
-func IsStateAllowedForBridge(state string) bool {
-	state = strings.ToUpper(strings.TrimSpace(state))
-	prohibitedState := map[string]bool{
-		"US-NY": true,
-		"US-AK": true,
-	}
-	return !prohibitedState[state]
-}
-
-// IsCountryAllowedForBridge checks if a country is allowed for Bridge customer creation
-// Based on Bridge's restricted country lists
-func IsCountryAllowedForBridge(countryCode string) bool {
-	if countryCode == "" {
-		return false
-	}
-
-	// Convert to uppercase for consistent comparison
-	countryCode = strings.ToUpper(strings.TrimSpace(countryCode))
-
-	prohibitedCountries := map[string]bool{
-		// Prohibited countries - completely blocked
-		"PRK": true, // North Korea
-		"SYR": true, // Syria
-		"IRN": true, // Iran, Islamic Republic of
-		"CUB": true, // Cuba
-
-		// Controlled countries - restricted but may have some allowances
-		"ERI": true, // Eritrea
-		"SOM": true, // Somalia
-		"ZWE": true, // Zimbabwe
-		"NIC": true, // Nicaragua
-		"MKD": true, // North Macedonia
-		"SVN": true, // Slovenia
-		"ALB": true, // Albania
-		"MMR": true, // Myanmar
-		"BGD": true, // Bangladesh
-		"PAK": true, // Pakistan
-		"UKR": true, // Ukraine
-		"NPL": true, // Nepal
-		"PSE": true, // Gaza Strip
-		"CHN": true, // China
-		"QAT": true, // Qatar
-		"DZA": true, // Algeria
-		"KEN": true, // Kenya
-		"XKX": true, // Kosovo
-		"SSD": true, // South Sudan
-		"SDN": true, // Sudan
-		"MAR": true, // Morocco
-		"MLI": true, // Mali
-		"YEM": true, // Yemen
-		"NER": true, // Niger
-	}
-	return !prohibitedCountries[countryCode]
-}
-
 func ToSnakeCase(str string) string {
 	var result strings.Builder
 	for i, char := range str {
@@ -360,6 +314,26 @@ func GenerateHash(input string) string {
 	return fmt.Sprintf("%x", hash)
 }
 
+// SignHMACSHA256 returns the hex encoded HMAC-SHA256 of payload.
+func SignHMACSHA256(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyHMACSHA256 compares signature against the HMAC of payload in constant time.
+// A "sha256=" prefix on the signature is accepted.
+func VerifyHMACSHA256(secret string, payload []byte, signature string) bool {
+	signature = strings.TrimPrefix(strings.TrimSpace(signature), "sha256=")
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
 func ValidateSuffix(s, suffix string) error {
 	if !strings.HasSuffix(s, suffix) {
 		return fmt.Errorf("invalid InternalTxRefId: missing required suffix %q", suffix)
@@ -178,6 +178,14 @@ func NonPositiveCode() string {
 	return "non_positive"
 }
 
+func NonPublicAddressCode() string {
+	return "non_public_address"
+}
+
+func UnresolvableHostCode() string {
+	return "unresolvable_host"
+}
+
 func OutOfRangeCode() string {
 	return "out_of_range"
 }
@@ -186,6 +194,10 @@ func PrecisionExceededCode() string {
 	return "precision_exceeded"
 }
 
+func InvalidChecksumCode() string {
+	return "invalid_checksum"
+}
+
 func CountryMismatchCode() string {
 	return "country_mismatch"
 }
@@ -194,6 +206,42 @@ func InvalidSubdivisionCode() string {
 	return "invalid_subdivision"
 }
 
+func RestrictedSubdivisionCode() string {
+	return "restricted_subdivision"
+}
+
+func ControlledJurisdictionCode() string {
+	return "controlled_jurisdiction"
+}
+
+func SanctionsMatchCode() string {
+	return "sanctions_match"
+}
+
+func ScreeningUnavailableCode() string {
+	return "screening_unavailable"
+}
+
+func DuplicateCustomerCode() string {
+	return "duplicate_customer"
+}
+
+func IdentityChangedCode() string {
+	return "identity_changed"
+}
+
+func OutdatedPolicyCode() string {
+	return "outdated_policy"
+}
+
+func InvalidOTPCode() string {
+	return "invalid_code"
+}
+
+func ExpiredOTPCode() string {
+	return "code_expired"
+}
+
 func InvalidPostalCodeCode() string {
 	return "invalid_postal_code"
 }
@@ -222,6 +270,18 @@ func InvalidReference() string {
 	return "invalid_reference"
 }
 
+func FileTooLargeCode() string {
+	return "file_too_large"
+}
+
+func InvalidMimeTypeCode() string {
+	return "invalid_mime_type"
+}
+
+func InvalidDimensionsCode() string {
+	return "invalid_dimensions"
+}
+
 func RequiredCode() string {
 	return "required"
 }
@@ -338,8 +398,8 @@ func IsPositiveFloat(n float64) bool {
 
 // ValidateRequiredStringWithRegex validates a required string field with length and regex pattern
 func ValidateRequiredStringWithRegex(value string, fieldName string, minLen, maxLen int, pattern *regexp.Regexp, errors map[string][]string, fieldPath string) string {
-	if value == "" {
-		errors[fieldPath] = append(errors[fieldPath], ErrorMessage(fieldName))
+	if strings.TrimSpace(value) == "" {
+		errors[fieldPath] = append(errors[fieldPath], RequiredCode())
 		return ""
 	}
 
@@ -362,7 +422,7 @@ func ValidateRequiredString(value string, fieldName string, minLen, maxLen int,
 
 // ValidateOptionalString validates an optional string field with length constraints
 func ValidateOptionalString(value string, minLen, maxLen int, errors map[string][]string, fieldPath string) string {
-	if value == "" {
+	if strings.TrimSpace(value) == "" {
 		return ""
 	}
 
@@ -376,8 +436,8 @@ func ValidateOptionalString(value string, minLen, maxLen int, errors map[string]
 
 // ValidateEmail validates email with common rules
 func ValidateEmail(email string, fieldName string, errors map[string][]string, fieldPath string) string {
-	if email == "" {
-		errors[fieldPath] = append(errors[fieldPath], ErrorMessage(fieldName))
+	if strings.TrimSpace(email) == "" {
+		errors[fieldPath] = append(errors[fieldPath], RequiredCode())
 		return ""
 	}
 
@@ -409,8 +469,8 @@ func ValidateEmail(email string, fieldName string, errors map[string][]string, f
 
 // ValidateCountryCode validates ISO country codes
 func ValidateCountryCode(countryCode string, fieldName string, errors map[string][]string, fieldPath string, countryCodeRegex *regexp.Regexp) string {
-	if countryCode == "" {
-		errors[fieldPath] = append(errors[fieldPath], ErrorMessage(fieldName))
+	if strings.TrimSpace(countryCode) == "" {
+		errors[fieldPath] = append(errors[fieldPath], RequiredCode())
 		return ""
 	}
 
@@ -437,8 +497,8 @@ func NormalizeName(name string) string {
 
 // ValidateRequiredName validates a required name field with normalization
 func ValidateRequiredName(name string, fieldName string, minLen, maxLen int, nameRegex *regexp.Regexp, errors map[string][]string, fieldPath string) string {
-	if name == "" {
-		errors[fieldPath] = append(errors[fieldPath], ErrorMessage(fieldName))
+	if strings.TrimSpace(name) == "" {
+		errors[fieldPath] = append(errors[fieldPath], RequiredCode())
 		return ""
 	}
 
@@ -457,8 +517,8 @@ func ValidateRequiredName(name string, fieldName string, minLen, maxLen int, nam
 
 // ValidateRequiredStreet validates a required street address field
 func ValidateRequiredStreet(street string, fieldName string, minLen, maxLen int, poBoxRegex *regexp.Regexp, errors map[string][]string, fieldPath string) string {
-	if street == "" {
-		errors[fieldPath] = append(errors[fieldPath], ErrorMessage(fieldName))
+	if strings.TrimSpace(street) == "" {
+		errors[fieldPath] = append(errors[fieldPath], RequiredCode())
 		return ""
 	}
 
@@ -487,8 +547,8 @@ func NormalizeCity(city string) string {
 
 // ValidateRequiredCity validates a required city field with normalization
 func ValidateRequiredCity(city string, fieldName string, minLen, maxLen int, errors map[string][]string, fieldPath string) string {
-	if city == "" {
-		errors[fieldPath] = append(errors[fieldPath], ErrorMessage(fieldName))
+	if strings.TrimSpace(city) == "" {
+		errors[fieldPath] = append(errors[fieldPath], RequiredCode())
 		return ""
 	}
 
@@ -504,7 +564,7 @@ func ValidateRequiredCity(city string, fieldName string, minLen, maxLen int, err
 // ValidateRequiredPositiveInt validates a required positive integer field
 func ValidateRequiredPositiveInt(value int, fieldName string, errors map[string][]string, fieldPath string) int {
 	if value == 0 {
-		errors[fieldPath] = append(errors[fieldPath], ErrorMessage(fieldName))
+		errors[fieldPath] = append(errors[fieldPath], RequiredCode())
 		return 0
 	}
 
@@ -519,7 +579,7 @@ func ValidateRequiredPositiveInt(value int, fieldName string, errors map[string]
 // ValidateRequiredPositiveFloat validates a required positive float field with range check
 func ValidateRequiredPositiveFloat(value float64, fieldName string, maxValue float64, errors map[string][]string, fieldPath string) float64 {
 	if value == 0 {
-		errors[fieldPath] = append(errors[fieldPath], ErrorMessage(fieldName))
+		errors[fieldPath] = append(errors[fieldPath], RequiredCode())
 		return 0
 	}
 
@@ -538,7 +598,7 @@ func ValidateRequiredPositiveFloat(value float64, fieldName string, maxValue flo
 // ValidateMonthlyVolumeUSD validates monthly volume with range check and precision validation
 func ValidateMonthlyVolumeUSD(value float64, maxValue float64, errors map[string][]string, fieldPath string) float64 {
 	if value == 0 {
-		errors[fieldPath] = append(errors[fieldPath], ErrorMessage("monthly_volume_usd"))
+		errors[fieldPath] = append(errors[fieldPath], RequiredCode())
 		return 0
 	}
 
@@ -563,7 +623,7 @@ func ValidateMonthlyVolumeUSD(value float64, maxValue float64, errors map[string
 
 // ValidateOptionalStringWithLength validates an optional string field with length constraints
 func ValidateOptionalStringWithLength(value string, minLength, maxLength int, errors map[string][]string, fieldPath string, errorCode string) string {
-	if value == "" {
+	if strings.TrimSpace(value) == "" {
 		return ""
 	}
 
@@ -577,8 +637,8 @@ func ValidateOptionalStringWithLength(value string, minLength, maxLength int, er
 
 // ValidatePhone validates and normalizes a required phone field
 func ValidatePhone(phone string, region string, errors map[string][]string, fieldPath string) string {
-	if phone == "" {
-		errors[fieldPath] = append(errors[fieldPath], ErrorMessage("phone"))
+	if strings.TrimSpace(phone) == "" {
+		errors[fieldPath] = append(errors[fieldPath], RequiredCode())
 		return ""
 	}
 
@@ -594,7 +654,7 @@ func ValidatePhone(phone string, region string, errors map[string][]string, fiel
 
 // ValidateOptionalDateOfBirth validates an optional DOB field with age constraints
 func ValidateOptionalDateOfBirth(dob string, minAge, maxAge int, errors map[string][]string, fieldPath string) string {
-	if dob == "" {
+	if strings.TrimSpace(dob) == "" {
 		return ""
 	}
 
@@ -636,7 +696,7 @@ func ValidateOptionalDateOfBirth(dob string, minAge, maxAge int, errors map[stri
 
 // ValidateOptionalStringWithMaxLength validates an optional string field with maximum length
 func ValidateOptionalStringWithMaxLength(value string, maxLength int, errors map[string][]string, fieldPath string) string {
-	if value == "" {
+	if strings.TrimSpace(value) == "" {
 		return ""
 	}
 
@@ -650,8 +710,8 @@ func ValidateOptionalStringWithMaxLength(value string, maxLength int, errors map
 
 // ValidateCountryCodeWithRestrictions validates a country code and checks against restricted list
 func ValidateCountryCodeWithRestrictions(countryCode string, fieldName string, restrictedCountries []string, errors map[string][]string, fieldPath string, countryCodeRegex *regexp.Regexp) string {
-	if countryCode == "" {
-		errors[fieldPath] = append(errors[fieldPath], ErrorMessage(fieldName))
+	if strings.TrimSpace(countryCode) == "" {
+		errors[fieldPath] = append(errors[fieldPath], RequiredCode())
 		return ""
 	}
 
@@ -674,29 +734,24 @@ func ValidateCountryCodeWithRestrictions(countryCode string, fieldName string, r
 	return normalized
 }
 
-// ValidateTIN validates Tax Identification Number with country-specific format checking
-func ValidateTIN(tin string, countryCode string, ssnRegex *regexp.Regexp, errors map[string][]string, fieldPath string) string {
-	if tin == "" {
-		errors[fieldPath] = append(errors[fieldPath], ErrorMessage("tin"))
+// ValidateTIN validates Tax Identification Number against the country's TIN rules
+func ValidateTIN(tin string, countryCode string, errors map[string][]string, fieldPath string) string {
+	if strings.TrimSpace(tin) == "" {
+		errors[fieldPath] = append(errors[fieldPath], RequiredCode())
 		return ""
 	}
 
-	normalized := strings.ToUpper(strings.TrimSpace(tin))
-
-	// Country-specific validation
-	if countryCode == "USA" && ssnRegex != nil {
-		if !ssnRegex.MatchString(normalized) {
-			errors[fieldPath] = append(errors[fieldPath], InvalidFormatCode())
-		}
+	normalized := NormalizeTIN(tin)
+	if code := CheckTIN(normalized, countryCode); code != "" {
+		errors[fieldPath] = append(errors[fieldPath], code)
 	}
-	// Add more country-specific validations as needed
 
 	return normalized
 }
 
 // ValidateOptionalRegexField validates an optional field against a regex pattern
 func ValidateOptionalRegexField(value string, validationRegex *regexp.Regexp, errorCode string, errors map[string][]string, fieldPath string) string {
-	if value == "" {
+	if strings.TrimSpace(value) == "" {
 		return ""
 	}
 
@@ -710,8 +765,8 @@ func ValidateOptionalRegexField(value string, validationRegex *regexp.Regexp, er
 
 // ValidateRequiredRegexField validates a required field against a regex pattern
 func ValidateRequiredRegexField(value string, fieldName string, validationRegex *regexp.Regexp, errorCode string, errors map[string][]string, fieldPath string) string {
-	if value == "" {
-		errors[fieldPath] = append(errors[fieldPath], ErrorMessage(fieldName))
+	if strings.TrimSpace(value) == "" {
+		errors[fieldPath] = append(errors[fieldPath], RequiredCode())
 		return ""
 	}
 
@@ -0,0 +1,93 @@
+package rest
+
+import (
+	"errors"
+	"fin-auth/domain"
+	"fin-auth/dto"
+	"fin-auth/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type VerificationHandler struct {
+	Service  domain.VerificationService
+	Response domain.Response
+}
+
+func SetupVerificationRoutes(api *echo.Group, s domain.VerificationService) {
+	handler := &VerificationHandler{
+		Service:  s,
+		Response: domain.NewResponse(),
+	}
+	verifications := api.Group("/customers/:id/persons/:person_id/verifications")
+	verifications.POST("", handler.sendCode)
+	verifications.POST("/confirm", handler.confirmCode)
+}
+
+func (h *VerificationHandler) sendCode(c echo.Context) error {
+	personId, err := strconv.Atoi(c.Param("person_id"))
+	if err != nil {
+		return h.Response.InvalidData(c, utils.StringPtr("Invalid person id"))
+	}
+	var req dto.OTPSendReq
+	if err := c.Bind(&req); err != nil {
+		return h.Response.InvalidData(c, nil)
+	}
+	v := req.Validate()
+	if v.Status {
+		return h.Response.ValidationFail(c, v, nil)
+	}
+
+	clientId := c.Get("client_id").(string)
+	res, err := h.Service.Send(c.Request().Context(), clientId, c.Param("id"), personId, req.Channel)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+	return h.Response.SuccessOk(c, res)
+}
+
+// confirmCode returns the person, masked, with the verified timestamp set.
+func (h *VerificationHandler) confirmCode(c echo.Context) error {
+	personId, err := strconv.Atoi(c.Param("person_id"))
+	if err != nil {
+		return h.Response.InvalidData(c, utils.StringPtr("Invalid person id"))
+	}
+	var req dto.OTPConfirmReq
+	if err := c.Bind(&req); err != nil {
+		return h.Response.InvalidData(c, nil)
+	}
+	v := req.Validate()
+	if v.Status {
+		return h.Response.ValidationFail(c, v, nil)
+	}
+
+	clientId := c.Get("client_id").(string)
+	person, err := h.Service.Confirm(c.Request().Context(), clientId, c.Param("id"), personId, &req)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+	return h.Response.SuccessOk(c, dto.NewPersonRes(person, false))
+}
+
+func (h *VerificationHandler) handleError(c echo.Context, err error) error {
+	var fieldErrs utils.FieldErrors
+	switch {
+	case errors.As(err, &fieldErrs):
+		v := utils.NewValidationError()
+		v.Response = utils.ErrorResponse{}
+		for path, codes := range fieldErrs {
+			v.Response.Add(path, codes)
+		}
+		return h.Response.ValidationFail(c, v, nil)
+	case errors.Is(err, utils.ErrOTPSendLimit), errors.Is(err, utils.ErrOTPAttemptLimit):
+		return h.Response.CustomValidationFail(c, nil, err.Error(), http.StatusTooManyRequests)
+	case errors.Is(err, utils.ErrNotFound):
+		return h.Response.NotFound(c, utils.StringPtr("Person not found"))
+	case errors.Is(err, utils.ErrConflict):
+		return h.Response.ConflictError(c, utils.StringPtr(err.Error()), nil)
+	default:
+		return h.Response.InternalServerError(c, err)
+	}
+}
@@ -0,0 +1,238 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fin-auth/cache"
+	"fin-auth/config"
+	"fin-auth/domain"
+	"fin-auth/dto"
+	"fin-auth/models"
+	"fin-auth/pii"
+	"fin-auth/utils"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type Verification struct {
+	PersonRepository   domain.PersonRepository
+	CustomerRepository domain.CustomerRepository
+	Notifier           domain.Notifier
+	Config             config.OTPConfig
+	Cache              *cache.RedisCache
+}
+
+func NewVerificationService(repo domain.PersonRepository, customerRepo domain.CustomerRepository, notifier domain.Notifier, cfg config.OTPConfig, cache *cache.RedisCache) *Verification {
+	return &Verification{
+		PersonRepository:   repo,
+		CustomerRepository: customerRepo,
+		Notifier:           notifier,
+		Config:             cfg,
+		Cache:              cache,
+	}
+}
+
+func (s *Verification) Send(ctx context.Context, clientId, customerId string, personId int, channel string) (*dto.OTPSendRes, error) {
+	if s.Cache == nil {
+		return nil, errors.New("one-time codes require redis")
+	}
+	person, err := s.findPerson(ctx, clientId, customerId, personId)
+	if err != nil {
+		return nil, err
+	}
+	target, verifiedAt := address(person, channel)
+	if target == "" {
+		return nil, utils.FieldErrors{channel: {utils.RequiredCode()}}
+	}
+	if verifiedAt != nil {
+		return nil, fmt.Errorf("%s of person %d is already verified: %w", channel, person.ID, utils.ErrConflict)
+	}
+	targetIndex, err := addressIndex(channel, target)
+	if err != nil {
+		return nil, err
+	}
+
+	sends, err := s.Cache.CountOTPSend(ctx, channel, targetIndex, s.sendWindow())
+	if err != nil {
+		return nil, err
+	}
+	if sends > int64(s.maxSends()) {
+		return nil, utils.ErrOTPSendLimit
+	}
+
+	code, err := utils.GenerateNumericCode(s.codeLength())
+	if err != nil {
+		return nil, err
+	}
+	codeHash, err := hashCode(channel, person.ID, code)
+	if err != nil {
+		return nil, err
+	}
+	expiresAt := utils.CurrentTime().Add(s.ttl()).Truncate(time.Second)
+	challenge := &domain.OTPChallenge{CodeHash: codeHash, TargetIndex: targetIndex, ExpiresAt: expiresAt}
+	if err := s.Cache.StoreOTPChallenge(ctx, channel, person.ID, challenge); err != nil {
+		s.Cache.RefundOTPSend(ctx, channel, targetIndex)
+		return nil, err
+	}
+
+	err = s.Notifier.Send(ctx, &domain.Notification{
+		Channel: channel,
+		To:      target,
+		Subject: "Your verification code",
+		Body:    fmt.Sprintf("Your verification code is %s. It expires in %d minutes.", code, int(s.ttl().Minutes())),
+	})
+	if err != nil {
+		s.Cache.DeleteOTPChallenge(ctx, channel, person.ID)
+		s.Cache.RefundOTPSend(ctx, channel, targetIndex)
+		return nil, fmt.Errorf("failed to send %s code: %w", channel, err)
+	}
+
+	return &dto.OTPSendRes{
+		Channel:     channel,
+		Destination: maskAddress(channel, target),
+		ExpiresAt:   expiresAt,
+	}, nil
+}
+
+// Confirm checks the code against the pending challenge. The challenge is dropped once
+// it was used, guessed too often, or the address changed since it was sent.
+func (s *Verification) Confirm(ctx context.Context, clientId, customerId string, personId int, req *dto.OTPConfirmReq) (*models.Person, error) {
+	if s.Cache == nil {
+		return nil, errors.New("one-time codes require redis")
+	}
+	person, err := s.findPerson(ctx, clientId, customerId, personId)
+	if err != nil {
+		return nil, err
+	}
+	channel := req.Channel
+
+	challenge, err := s.Cache.GetOTPChallenge(ctx, channel, person.ID)
+	if errors.Is(err, redis.Nil) {
+		return nil, utils.FieldErrors{"code": {utils.ExpiredOTPCode()}}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	target, _ := address(person, channel)
+	targetIndex, err := addressIndex(channel, target)
+	if err != nil {
+		return nil, err
+	}
+	if target == "" || targetIndex != challenge.TargetIndex {
+		s.Cache.DeleteOTPChallenge(ctx, channel, person.ID)
+		return nil, utils.FieldErrors{"code": {utils.ExpiredOTPCode()}}
+	}
+
+	attempts, err := s.Cache.IncrementOTPAttempts(ctx, channel, person.ID)
+	if errors.Is(err, redis.Nil) {
+		return nil, utils.FieldErrors{"code": {utils.ExpiredOTPCode()}}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if attempts > int64(s.maxAttempts()) {
+		s.Cache.DeleteOTPChallenge(ctx, channel, person.ID)
+		return nil, utils.ErrOTPAttemptLimit
+	}
+
+	codeHash, err := hashCode(channel, person.ID, req.Code)
+	if err != nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(codeHash), []byte(challenge.CodeHash)) != 1 {
+		if attempts == int64(s.maxAttempts()) {
+			s.Cache.DeleteOTPChallenge(ctx, channel, person.ID)
+		}
+		return nil, utils.FieldErrors{"code": {utils.InvalidOTPCode()}}
+	}
+
+	s.Cache.DeleteOTPChallenge(ctx, channel, person.ID)
+	if err := s.PersonRepository.MarkVerified(ctx, person, channel, targetIndex, utils.CurrentTime()); err != nil {
+		return nil, err
+	}
+	s.invalidate(ctx, clientId, customerId)
+	return person, nil
+}
+
+func (s *Verification) findPerson(ctx context.Context, clientId, customerId string, personId int) (*models.Person, error) {
+	cached, err := s.CustomerRepository.FindByID(ctx, clientId, customerId)
+	if err != nil {
+		return nil, err
+	}
+	if cached.Customer.ErasedAt != nil {
+		return nil, fmt.Errorf("customer %s was erased: %w", customerId, utils.ErrConflict)
+	}
+	return s.PersonRepository.FindByID(ctx, cached.Customer.ID, personId)
+}
+
+func (s *Verification) invalidate(ctx context.Context, clientId, customerId string) {
+	s.Cache.InvalidateCustomer(ctx, clientId, customerId)
+	s.Cache.InvalidateCustomerList(ctx, clientId)
+}
+
+func (s *Verification) codeLength() int {
+	if s.Config.Length < 4 || s.Config.Length > 10 {
+		return 6
+	}
+	return s.Config.Length
+}
+
+func (s *Verification) ttl() time.Duration {
+	if s.Config.TTLSeconds <= 0 {
+		return 10 * time.Minute
+	}
+	return time.Duration(s.Config.TTLSeconds) * time.Second
+}
+
+func (s *Verification) maxAttempts() int {
+	if s.Config.MaxAttempts <= 0 {
+		return 5
+	}
+	return s.Config.MaxAttempts
+}
+
+func (s *Verification) maxSends() int {
+	if s.Config.MaxSends <= 0 {
+		return 5
+	}
+	return s.Config.MaxSends
+}
+
+func (s *Verification) sendWindow() time.Duration {
+	if s.Config.SendWindowSeconds <= 0 {
+		return time.Hour
+	}
+	return time.Duration(s.Config.SendWindowSeconds) * time.Second
+}
+
+// address returns the person's email or phone and when it was verified.
+func address(person *models.Person, channel string) (string, *time.Time) {
+	if channel == utils.OTP_CHANNEL_PHONE {
+		return utils.SafeString(person.Phone), person.PhoneVerifiedAt
+	}
+	return utils.SafeString(person.Email), person.EmailVerifiedAt
+}
+
+func addressIndex(channel, target string) (string, error) {
+	if channel == utils.OTP_CHANNEL_PHONE {
+		return pii.PhoneIndex(target)
+	}
+	return pii.EmailIndex(target)
+}
+
+func maskAddress(channel, target string) string {
+	if channel == utils.OTP_CHANNEL_PHONE {
+		return utils.MaskPhone(target)
+	}
+	return utils.MaskEmail(target)
+}
+
+// hashCode keys the code with the blind index key, bound to the person and channel,
+// so a leaked cache entry reveals neither the code nor a reusable hash.
+func hashCode(channel string, personId int, code string) (string, error) {
+	return pii.BlindIndex(fmt.Sprintf("otp:%s:%d:%s", channel, personId, code))
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"bytes"
+	"fin-auth/config"
+	"fin-auth/pii"
+	"fin-auth/utils"
+	"testing"
+	"time"
+)
+
+func TestHashCode(t *testing.T) {
+	if err := pii.Init(1, map[int][]byte{1: bytes.Repeat([]byte{1}, 32)}, bytes.Repeat([]byte{2}, 32)); err != nil {
+		t.Fatalf("pii.Init() error = %v", err)
+	}
+	want, err := hashCode(utils.OTP_CHANNEL_EMAIL, 1, "123456")
+	if err != nil {
+		t.Fatalf("hashCode() error = %v", err)
+	}
+	if want == "" || want == "123456" {
+		t.Fatalf("hashCode() = %q, want a keyed hash", want)
+	}
+
+	tests := []struct {
+		name     string
+		channel  string
+		personId int
+		code     string
+		equal    bool
+	}{
+		{"same inputs", utils.OTP_CHANNEL_EMAIL, 1, "123456", true},
+		{"other code", utils.OTP_CHANNEL_EMAIL, 1, "123457", false},
+		{"other person", utils.OTP_CHANNEL_EMAIL, 2, "123456", false},
+		{"other channel", utils.OTP_CHANNEL_PHONE, 1, "123456", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := hashCode(tt.channel, tt.personId, tt.code)
+			if err != nil {
+				t.Fatalf("hashCode() error = %v", err)
+			}
+			if (got == want) != tt.equal {
+				t.Errorf("hashCode() equal = %v, want %v", got == want, tt.equal)
+			}
+		})
+	}
+}
+
+func TestLimits(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         config.OTPConfig
+		length      int
+		ttl         time.Duration
+		maxAttempts int
+		maxSends    int
+		sendWindow  time.Duration
+	}{
+		{"defaults", config.OTPConfig{}, 6, 10 * time.Minute, 5, 5, time.Hour},
+		{"configured", config.OTPConfig{Length: 8, TTLSeconds: 300, MaxAttempts: 3, MaxSends: 10, SendWindowSeconds: 600},
+			8, 5 * time.Minute, 3, 10, 10 * time.Minute},
+		{"negative values", config.OTPConfig{Length: -1, TTLSeconds: -1, MaxAttempts: -1, MaxSends: -1, SendWindowSeconds: -1},
+			6, 10 * time.Minute, 5, 5, time.Hour},
+		{"code too short", config.OTPConfig{Length: 3}, 6, 10 * time.Minute, 5, 5, time.Hour},
+		{"code too long", config.OTPConfig{Length: 11}, 6, 10 * time.Minute, 5, 5, time.Hour},
+		{"code length bounds", config.OTPConfig{Length: 4}, 4, 10 * time.Minute, 5, 5, time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Verification{Config: tt.cfg}
+			if got := s.codeLength(); got != tt.length {
+				t.Errorf("codeLength() = %d, want %d", got, tt.length)
+			}
+			if got := s.ttl(); got != tt.ttl {
+				t.Errorf("ttl() = %s, want %s", got, tt.ttl)
+			}
+			if got := s.maxAttempts(); got != tt.maxAttempts {
+				t.Errorf("maxAttempts() = %d, want %d", got, tt.maxAttempts)
+			}
+			if got := s.maxSends(); got != tt.maxSends {
+				t.Errorf("maxSends() = %d, want %d", got, tt.maxSends)
+			}
+			if got := s.sendWindow(); got != tt.sendWindow {
+				t.Errorf("sendWindow() = %s, want %s", got, tt.sendWindow)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fin-auth/cache"
+	"fin-auth/models"
+	"fin-auth/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Webhook struct {
+	db    *gorm.DB
+	cache *cache.RedisCache
+}
+
+func NewWebhookRepository(db *gorm.DB, cache *cache.RedisCache) *Webhook {
+	return &Webhook{
+		db:    db,
+		cache: cache,
+	}
+}
+
+func (o *Webhook) GetDB(tx ...*gorm.DB) *gorm.DB {
+	db := o.db
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db
+}
+
+func (o *Webhook) CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error {
+	return o.db.Create(subscription).Error
+}
+
+func (o *Webhook) ListSubscriptions(ctx context.Context, clientId string) ([]*models.WebhookSubscription, error) {
+	var subscriptions []*models.WebhookSubscription
+	if err := o.db.Where("client_id = ?", clientId).Order("id").Find(&subscriptions).Error; err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+func (o *Webhook) FindSubscription(ctx context.Context, clientId string, id int) (*models.WebhookSubscription, error) {
+	var subscription models.WebhookSubscription
+	err := o.db.Where("id = ? AND client_id = ?", id, clientId).First(&subscription).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, utils.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func (o *Webhook) UpdateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error {
+	return o.db.Model(subscription).
+		Select("url", "event_types", "description", "is_active").
+		Updates(subscription).Error
+}
+
+func (o *Webhook) DeleteSubscription(ctx context.Context, clientId string, id int) error {
+	result := o.db.Where("id = ? AND client_id = ?", id, clientId).Delete(&models.WebhookSubscription{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return utils.ErrNotFound
+	}
+	return nil
+}
+
+func (o *Webhook) EnqueueEvent(ctx context.Context, tx *gorm.DB, clientId, eventType string, payload interface{}) error {
+	db := o.GetDB(tx)
+
+	var subscriptions []models.WebhookSubscription
+	err := db.Where("client_id = ? AND is_active = ? AND ? = ANY(event_types)", clientId, true, eventType).
+		Find(&subscriptions).Error
+	if err != nil {
+		return err
+	}
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	data, err := utils.ToDatatypesJSON(payload)
+	if err != nil {
+		return err
+	}
+
+	eventId := uuid.New().String()
+	now := utils.CurrentTime()
+	deliveries := make([]models.WebhookDelivery, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		deliveries = append(deliveries, models.WebhookDelivery{
+			SubscriptionID: subscription.ID,
+			ClientID:       clientId,
+			EventID:        eventId,
+			EventType:      eventType,
+			Payload:        data,
+			Status:         utils.WEBHOOK_DELIVERY_PENDING,
+			NextAttemptAt:  now,
+		})
+	}
+
+	return db.Create(&deliveries).Error
+}
+
+func (o *Webhook) ListDeliveries(ctx context.Context, clientId, status string, limit, offset int) ([]*models.WebhookDelivery, int64, error) {
+	query := o.db.Model(&models.WebhookDelivery{}).Where("client_id = ?", clientId)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var deliveries []*models.WebhookDelivery
+	if err := query.Order("id DESC").Limit(limit).Offset(offset).Find(&deliveries).Error; err != nil {
+		return nil, 0, err
+	}
+	return deliveries, total, nil
+}
+
+func (o *Webhook) FindDelivery(ctx context.Context, clientId string, id int) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	err := o.db.Where("id = ? AND client_id = ?", id, clientId).First(&delivery).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, utils.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (o *Webhook) ResetDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	return o.db.Model(delivery).
+		Select("status", "next_attempt_at", "last_error").
+		Updates(delivery).Error
+}
@@ -0,0 +1,151 @@
+package rest
+
+import (
+	"errors"
+	"fin-auth/domain"
+	"fin-auth/dto"
+	"fin-auth/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type WebhookHandler struct {
+	Service  domain.WebhookService
+	Response domain.Response
+}
+
+func SetupWebhookRoutes(api *echo.Group, s domain.WebhookService) {
+	handler := &WebhookHandler{
+		Service:  s,
+		Response: domain.NewResponse(),
+	}
+	webhooks := api.Group("/webhooks")
+	webhooks.POST("/subscriptions", handler.createSubscription)
+	webhooks.GET("/subscriptions", handler.listSubscriptions)
+	webhooks.PATCH("/subscriptions/:id", handler.updateSubscription)
+	webhooks.DELETE("/subscriptions/:id", handler.deleteSubscription)
+	webhooks.GET("/deliveries", handler.listDeliveries)
+	webhooks.POST("/deliveries/:id/redeliver", handler.redeliver)
+}
+
+func (h *WebhookHandler) createSubscription(c echo.Context) error {
+	var req dto.CreateWebhookSubscriptionReq
+	if err := c.Bind(&req); err != nil {
+		return h.Response.InvalidData(c, nil)
+	}
+
+	v := req.Validate()
+	if v.Status {
+		return h.Response.ValidationFail(c, v, nil)
+	}
+
+	clientId := c.Get("client_id").(string)
+	res, err := h.Service.CreateSubscription(c.Request().Context(), clientId, &req)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"success": true,
+		"message": "Webhook subscription created successfully",
+		"data":    res,
+	})
+}
+
+func (h *WebhookHandler) listSubscriptions(c echo.Context) error {
+	clientId := c.Get("client_id").(string)
+	subscriptions, err := h.Service.ListSubscriptions(c.Request().Context(), clientId)
+	if err != nil {
+		return h.Response.InternalServerError(c, err)
+	}
+	return h.Response.SuccessOk(c, subscriptions)
+}
+
+func (h *WebhookHandler) updateSubscription(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return h.Response.InvalidData(c, nil)
+	}
+
+	var req dto.UpdateWebhookSubscriptionReq
+	if err := c.Bind(&req); err != nil {
+		return h.Response.InvalidData(c, nil)
+	}
+
+	v := req.Validate()
+	if v.Status {
+		return h.Response.ValidationFail(c, v, nil)
+	}
+
+	clientId := c.Get("client_id").(string)
+	subscription, err := h.Service.UpdateSubscription(c.Request().Context(), clientId, id, &req)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+	return h.Response.SuccessOk(c, subscription)
+}
+
+func (h *WebhookHandler) deleteSubscription(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return h.Response.InvalidData(c, nil)
+	}
+
+	clientId := c.Get("client_id").(string)
+	if err := h.Service.DeleteSubscription(c.Request().Context(), clientId, id); err != nil {
+		return h.handleError(c, err)
+	}
+	return h.Response.SuccessMessage(c, "Webhook subscription deleted successfully")
+}
+
+func (h *WebhookHandler) listDeliveries(c echo.Context) error {
+	limit, page, offset := utils.ParsePaginationParams(c)
+	clientId := c.Get("client_id").(string)
+
+	deliveries, total, err := h.Service.ListDeliveries(c.Request().Context(), clientId, c.QueryParam("status"), limit, offset)
+	if err != nil {
+		return h.Response.InternalServerError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success":      true,
+		"data":         deliveries,
+		"total":        total,
+		"current_page": page,
+		"per_page":     limit,
+		"total_pages":  utils.CalculateTotalPages(int(total), limit),
+	})
+}
+
+func (h *WebhookHandler) redeliver(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return h.Response.InvalidData(c, nil)
+	}
+
+	clientId := c.Get("client_id").(string)
+	delivery, err := h.Service.Redeliver(c.Request().Context(), clientId, id)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+	return h.Response.SuccessOk(c, delivery)
+}
+
+func (h *WebhookHandler) handleError(c echo.Context, err error) error {
+	var fieldErrs utils.FieldErrors
+	switch {
+	case errors.As(err, &fieldErrs):
+		v := utils.NewValidationError()
+		v.Response = utils.ErrorResponse{}
+		for path, codes := range fieldErrs {
+			v.Response.Add(path, codes)
+		}
+		return h.Response.ValidationFail(c, v, nil)
+	case errors.Is(err, utils.ErrNotFound):
+		return h.Response.NotFound(c, nil)
+	default:
+		return h.Response.InternalServerError(c, err)
+	}
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fin-auth/cache"
+	"fin-auth/domain"
+	"fin-auth/dto"
+	"fin-auth/models"
+	"fin-auth/utils"
+	"strings"
+)
+
+type Webhook struct {
+	WebhookRepository domain.WebhookRepository
+	Cache             *cache.RedisCache
+}
+
+func NewWebhookService(repo domain.WebhookRepository, cache *cache.RedisCache) *Webhook {
+	return &Webhook{
+		WebhookRepository: repo,
+		Cache:             cache,
+	}
+}
+
+func (s *Webhook) CreateSubscription(ctx context.Context, clientId string, req *dto.CreateWebhookSubscriptionReq) (*dto.WebhookSubscriptionRes, error) {
+	if err := checkHost(ctx, req.URL); err != nil {
+		return nil, err
+	}
+	subscription := &models.WebhookSubscription{
+		ClientID:   clientId,
+		URL:        strings.TrimSpace(req.URL),
+		EventTypes: req.EventTypes,
+		Secret:     utils.GenerateRandomString(50),
+		IsActive:   true,
+	}
+	if req.Description != "" {
+		subscription.Description = utils.StringPtr(req.Description)
+	}
+
+	if err := s.WebhookRepository.CreateSubscription(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	// The signing secret is only ever returned on creation.
+	return &dto.WebhookSubscriptionRes{
+		WebhookSubscription: subscription,
+		Secret:              subscription.Secret,
+	}, nil
+}
+
+func (s *Webhook) ListSubscriptions(ctx context.Context, clientId string) ([]*models.WebhookSubscription, error) {
+	return s.WebhookRepository.ListSubscriptions(ctx, clientId)
+}
+
+func (s *Webhook) UpdateSubscription(ctx context.Context, clientId string, id int, req *dto.UpdateWebhookSubscriptionReq) (*models.WebhookSubscription, error) {
+	subscription, err := s.WebhookRepository.FindSubscription(ctx, clientId, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.URL != nil {
+		if err := checkHost(ctx, *req.URL); err != nil {
+			return nil, err
+		}
+		subscription.URL = strings.TrimSpace(*req.URL)
+	}
+	if req.EventTypes != nil {
+		subscription.EventTypes = req.EventTypes
+	}
+	if req.Description != nil {
+		subscription.Description = req.Description
+	}
+	if req.IsActive != nil {
+		subscription.IsActive = *req.IsActive
+	}
+
+	if err := s.WebhookRepository.UpdateSubscription(ctx, subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+func (s *Webhook) DeleteSubscription(ctx context.Context, clientId string, id int) error {
+	return s.WebhookRepository.DeleteSubscription(ctx, clientId, id)
+}
+
+func (s *Webhook) ListDeliveries(ctx context.Context, clientId, status string, limit, offset int) ([]*models.WebhookDelivery, int64, error) {
+	return s.WebhookRepository.ListDeliveries(ctx, clientId, strings.ToUpper(status), limit, offset)
+}
+
+// Redeliver schedules a delivery for an immediate retry, including dead-lettered ones.
+func (s *Webhook) Redeliver(ctx context.Context, clientId string, id int) (*models.WebhookDelivery, error) {
+	delivery, err := s.WebhookRepository.FindDelivery(ctx, clientId, id)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery.Status = utils.WEBHOOK_DELIVERY_PENDING
+	delivery.NextAttemptAt = utils.CurrentTime()
+	delivery.LastError = nil
+
+	if err := s.WebhookRepository.ResetDelivery(ctx, delivery); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// checkHost rejects URLs whose host resolves to an internal address, which the delivery
+// worker would otherwise call from inside the cluster. The worker checks the address
+// again when it connects, as DNS can change after this.
+func checkHost(ctx context.Context, url string) error {
+	err := utils.CheckPublicHost(ctx, url)
+	if errors.Is(err, utils.ErrNonPublicAddress) {
+		return utils.FieldErrors{"url": {utils.NonPublicAddressCode()}}
+	}
+	if err != nil {
+		return utils.FieldErrors{"url": {utils.UnresolvableHostCode()}}
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package worker
+
+import (
+	"context"
+	"fin-auth/domain"
+	"log"
+)
+
+type CorridorRefreshWorker struct {
+	corridors domain.CorridorService
+}
+
+func NewCorridorRefreshWorker(corridors domain.CorridorService) *CorridorRefreshWorker {
+	return &CorridorRefreshWorker{
+		corridors: corridors,
+	}
+}
+
+// Run refreshes every customer's corridors when a corridor was created or updated
+// since the previous run.
+func (w *CorridorRefreshWorker) Run() error {
+	refreshed, err := w.corridors.RefreshAll(context.Background())
+	if err != nil {
+		return err
+	}
+	if refreshed > 0 {
+		log.Printf("[corridor-refresh] refreshed %d customers", refreshed)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package worker
+
+import (
+	"context"
+	"fin-auth/domain"
+	"fin-auth/models"
+	"fin-auth/utils"
+	"fmt"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	outboxBatchSize   = 100
+	outboxMaxAttempts = 10
+	outboxBaseBackoff = 10 * time.Second
+	outboxMaxBackoff  = time.Hour
+)
+
+type OutboxDispatchWorker struct {
+	db    *gorm.DB
+	sinks []domain.OutboxSink
+}
+
+func NewOutboxDispatchWorker(db *gorm.DB, sinks ...domain.OutboxSink) *OutboxDispatchWorker {
+	return &OutboxDispatchWorker{
+		db:    db,
+		sinks: sinks,
+	}
+}
+
+// Run locks a batch of due events with FOR UPDATE SKIP LOCKED and hands each one to
+// every sink. Each event runs under its own savepoint so a failing event rolls back
+// only its own sink writes before being rescheduled.
+func (w *OutboxDispatchWorker) Run() error {
+	ctx := context.Background()
+	var delivered, failed int
+
+	err := w.db.Transaction(func(tx *gorm.DB) error {
+		var events []models.OutboxEvent
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND available_at <= ?", utils.OUTBOX_STATUS_PENDING, utils.CurrentTime()).
+			Order("id").
+			Limit(outboxBatchSize).
+			Find(&events).Error
+		if err != nil {
+			return err
+		}
+
+		for i := range events {
+			event := &events[i]
+			savepoint := fmt.Sprintf("outbox_%d", event.ID)
+			if err := tx.SavePoint(savepoint).Error; err != nil {
+				return err
+			}
+
+			if err := w.dispatch(ctx, tx, event); err != nil {
+				if err := tx.RollbackTo(savepoint).Error; err != nil {
+					return err
+				}
+				w.reschedule(event, err)
+				failed++
+			} else {
+				now := utils.CurrentTime()
+				event.Status = utils.OUTBOX_STATUS_DELIVERED
+				event.DeliveredAt = &now
+				event.LastError = nil
+				delivered++
+			}
+			event.Attempts++
+
+			err := tx.Model(event).
+				Select("status", "attempts", "last_error", "available_at", "delivered_at").
+				Updates(event).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[outbox-dispatch] delivered %d, failed %d", delivered, failed)
+	return nil
+}
+
+func (w *OutboxDispatchWorker) dispatch(ctx context.Context, tx *gorm.DB, event *models.OutboxEvent) error {
+	for _, sink := range w.sinks {
+		if err := sink.Dispatch(ctx, tx, event); err != nil {
+			return fmt.Errorf("%s sink: %w", sink.Name(), err)
+		}
+	}
+	return nil
+}
+
+func (w *OutboxDispatchWorker) reschedule(event *models.OutboxEvent, cause error) {
+	msg := cause.Error()
+	event.LastError = &msg
+
+	if event.Attempts+1 >= outboxMaxAttempts {
+		event.Status = utils.OUTBOX_STATUS_FAILED
+		return
+	}
+
+	backoff := outboxBaseBackoff * time.Duration(1<<event.Attempts)
+	if backoff > outboxMaxBackoff {
+		backoff = outboxMaxBackoff
+	}
+	event.AvailableAt = utils.CurrentTime().Add(backoff)
+}
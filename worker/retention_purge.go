@@ -0,0 +1,28 @@
+package worker
+
+import (
+	"context"
+	"fin-auth/domain"
+	"log"
+)
+
+type RetentionPurgeWorker struct {
+	retention domain.RetentionService
+	dryRun    bool
+}
+
+func NewRetentionPurgeWorker(retention domain.RetentionService, dryRun bool) *RetentionPurgeWorker {
+	return &RetentionPurgeWorker{
+		retention: retention,
+		dryRun:    dryRun,
+	}
+}
+
+func (w *RetentionPurgeWorker) Run() error {
+	run, err := w.retention.Run(context.Background(), w.dryRun)
+	if err != nil {
+		return err
+	}
+	log.Printf("[retention-purge] run %d %s (dry run: %t)", run.ID, run.Status, run.DryRun)
+	return nil
+}
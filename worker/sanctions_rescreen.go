@@ -0,0 +1,29 @@
+package worker
+
+import (
+	"context"
+	"fin-auth/domain"
+	"log"
+)
+
+type SanctionsRescreenWorker struct {
+	screening domain.ScreeningService
+}
+
+func NewSanctionsRescreenWorker(screening domain.ScreeningService) *SanctionsRescreenWorker {
+	return &SanctionsRescreenWorker{
+		screening: screening,
+	}
+}
+
+// Run rescreens every person when the watchlist file changed since the previous run.
+func (w *SanctionsRescreenWorker) Run() error {
+	screened, err := w.screening.RescreenAll(context.Background())
+	if err != nil {
+		return err
+	}
+	if screened > 0 {
+		log.Printf("[sanctions-rescreen] rescreened %d persons", screened)
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+package worker
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fin-auth/models"
+	"fin-auth/utils"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	webhookBatchSize    = 50
+	webhookMaxAttempts  = 8
+	webhookBaseBackoff  = 30 * time.Second
+	webhookMaxBackoff   = 6 * time.Hour
+	webhookClaimLease   = 2 * time.Minute
+	webhookHTTPTimeout  = 10 * time.Second
+	webhookMaxErrLength = 500
+)
+
+type WebhookDeliveryWorker struct {
+	db     *gorm.DB
+	client *http.Client
+}
+
+type webhookEnvelope struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+func NewWebhookDeliveryWorker(db *gorm.DB) *WebhookDeliveryWorker {
+	// Hosts are checked when a subscription is saved, but DNS can change afterwards, so
+	// every connection, redirects included, is checked again. No proxy: it would be the
+	// address dialed instead of the subscriber's.
+	dialer := &net.Dialer{Timeout: webhookHTTPTimeout, Control: dialPublicOnly}
+	transport := &http.Transport{
+		DialContext:         dialer.DialContext,
+		TLSHandshakeTimeout: webhookHTTPTimeout,
+		ForceAttemptHTTP2:   true,
+	}
+	return &WebhookDeliveryWorker{
+		db:     db,
+		client: &http.Client{Timeout: webhookHTTPTimeout, Transport: transport},
+	}
+}
+
+func dialPublicOnly(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if !utils.IsPublicIP(net.ParseIP(host)) {
+		return fmt.Errorf("%s: %w", host, utils.ErrNonPublicAddress)
+	}
+	return nil
+}
+
+func (w *WebhookDeliveryWorker) Run() error {
+	deliveries, err := w.claim()
+	if err != nil {
+		return err
+	}
+
+	var delivered, failed int
+	for i := range deliveries {
+		if w.deliver(&deliveries[i]) {
+			delivered++
+		} else {
+			failed++
+		}
+	}
+
+	log.Printf("[webhook-delivery] delivered %d, failed %d", delivered, failed)
+	return nil
+}
+
+// claim locks a batch of due deliveries and pushes their next attempt out by a lease,
+// so concurrent workers skip them while the HTTP calls run outside the transaction.
+func (w *WebhookDeliveryWorker) claim() ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	now := utils.CurrentTime()
+
+	err := w.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Preload("Subscription").
+			Where("status IN ? AND next_attempt_at <= ?", []string{utils.WEBHOOK_DELIVERY_PENDING, utils.WEBHOOK_DELIVERY_FAILED}, now).
+			Order("next_attempt_at").
+			Limit(webhookBatchSize).
+			Find(&deliveries).Error
+		if err != nil || len(deliveries) == 0 {
+			return err
+		}
+
+		ids := make([]int, 0, len(deliveries))
+		for _, d := range deliveries {
+			ids = append(ids, d.ID)
+		}
+		return tx.Model(&models.WebhookDelivery{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(webhookClaimLease)).Error
+	})
+
+	return deliveries, err
+}
+
+func (w *WebhookDeliveryWorker) deliver(d *models.WebhookDelivery) bool {
+	d.Attempts++
+
+	if d.Subscription == nil || !d.Subscription.IsActive {
+		w.finish(d, utils.WEBHOOK_DELIVERY_DEAD, nil, fmt.Errorf("subscription inactive or removed"))
+		return false
+	}
+
+	if !strings.HasPrefix(d.Subscription.URL, "https://") {
+		w.finish(d, utils.WEBHOOK_DELIVERY_DEAD, nil, fmt.Errorf("subscription url is not https"))
+		return false
+	}
+
+	body, err := json.Marshal(webhookEnvelope{
+		ID:        d.EventID,
+		Type:      d.EventType,
+		CreatedAt: d.CreatedAt,
+		Data:      json.RawMessage(d.Payload),
+	})
+	if err != nil {
+		w.finish(d, utils.WEBHOOK_DELIVERY_DEAD, nil, err)
+		return false
+	}
+
+	timestamp := strconv.FormatInt(utils.CurrentTime().Unix(), 10)
+	signature := utils.SignHMACSHA256(d.Subscription.Secret, []byte(fmt.Sprintf("%s.%s", timestamp, body)))
+
+	req, err := http.NewRequest(http.MethodPost, d.Subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		w.finish(d, utils.WEBHOOK_DELIVERY_DEAD, nil, err)
+		return false
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-Id", d.EventID)
+	req.Header.Set("X-Webhook-Event", d.EventType)
+	req.Header.Set("X-Webhook-Timestamp", timestamp)
+	req.Header.Set("X-Webhook-Signature", "sha256="+signature)
+
+	resp, err := w.client.Do(req)
+	if errors.Is(err, utils.ErrNonPublicAddress) {
+		w.finish(d, utils.WEBHOOK_DELIVERY_DEAD, nil, err)
+		return false
+	}
+	if err != nil {
+		w.retry(d, nil, err)
+		return false
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	code := resp.StatusCode
+	if code >= 200 && code < 300 {
+		w.finish(d, utils.WEBHOOK_DELIVERY_DELIVERED, &code, nil)
+		return true
+	}
+
+	w.retry(d, &code, fmt.Errorf("unexpected response status %d", code))
+	return false
+}
+
+// retry schedules the next attempt with exponential backoff, or dead-letters the delivery
+// once webhookMaxAttempts is reached.
+func (w *WebhookDeliveryWorker) retry(d *models.WebhookDelivery, code *int, cause error) {
+	if d.Attempts >= webhookMaxAttempts {
+		w.finish(d, utils.WEBHOOK_DELIVERY_DEAD, code, cause)
+		return
+	}
+
+	backoff := webhookBaseBackoff * time.Duration(1<<(d.Attempts-1))
+	if backoff > webhookMaxBackoff {
+		backoff = webhookMaxBackoff
+	}
+	d.NextAttemptAt = utils.CurrentTime().Add(backoff)
+	w.finish(d, utils.WEBHOOK_DELIVERY_FAILED, code, cause)
+}
+
+func (w *WebhookDeliveryWorker) finish(d *models.WebhookDelivery, status string, code *int, cause error) {
+	d.Status = status
+	d.LastResponseCode = code
+	d.LastError = nil
+	if cause != nil {
+		msg := cause.Error()
+		if len(msg) > webhookMaxErrLength {
+			msg = msg[:webhookMaxErrLength]
+		}
+		d.LastError = &msg
+	}
+	if status == utils.WEBHOOK_DELIVERY_DELIVERED {
+		now := utils.CurrentTime()
+		d.DeliveredAt = &now
+	}
+
+	err := w.db.Model(d).
+		Select("status", "attempts", "next_attempt_at", "last_response_code", "last_error", "delivered_at").
+		Updates(d).Error
+	if err != nil {
+		log.Printf("[webhook-delivery] failed to update delivery %d: %v", d.ID, err)
+	}
+}
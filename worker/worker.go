@@ -2,6 +2,7 @@ package worker
 
 import (
 	"log"
+	"sync"
 	"time"
 )
 
@@ -39,6 +40,19 @@ func (w *Worker) run() {
 	}
 }
 
+// StartAll runs every worker on its own goroutine and blocks while they run.
+func StartAll(workers ...*Worker) {
+	var wg sync.WaitGroup
+	for _, w := range workers {
+		wg.Add(1)
+		go func(w *Worker) {
+			defer wg.Done()
+			w.Start()
+		}(w)
+	}
+	wg.Wait()
+}
+
 func (w *Worker) Stop() {
 	// Implement the logic to gracefully stop the worker
 }